@@ -80,6 +80,13 @@ func (c *Client) Start() {
 	c.runHookFunc(cluster.Start)
 }
 
+// Restart 重启组件
+func (c *Client) Restart() error {
+	c.runHookFunc(cluster.Restart)
+
+	return nil
+}
+
 // Destroy 销毁组件
 func (c *Client) Destroy() {
 	c.setState(cluster.Shut)
@@ -61,6 +61,35 @@ func (e Event) String() string {
 	return ""
 }
 
+const (
+	AllowMultiple SessionPolicy = "multiple" // 允许多个会话（同一用户可同时在多个网关上保持连接）
+	KickOld       SessionPolicy = "kick"     // 踢掉旧会话（新会话绑定成功后，旧会话所在网关断开旧连接）
+	RejectNew     SessionPolicy = "reject"   // 拒绝新会话（用户已在线时拒绝新会话的绑定）
+)
+
+// SessionPolicy 重复登录时的会话策略
+type SessionPolicy string
+
+func (p SessionPolicy) String() string {
+	return string(p)
+}
+
+const (
+	KickDuplicateLogin KickReason = iota + 1 // 重复登录
+)
+
+// KickReason 踢下线原因
+type KickReason int32
+
+func (r KickReason) String() string {
+	switch r {
+	case KickDuplicateLogin:
+		return "duplicate_login"
+	}
+
+	return ""
+}
+
 const (
 	Init    Hook = iota // 初始化组件
 	Start               // 启动组件
@@ -90,6 +119,7 @@ type (
 	MulticastArgs  = link.MulticastArgs
 	BroadcastArgs  = link.BroadcastArgs
 	DisconnectArgs = link.DisconnectArgs
+	GroupArgs      = link.GroupArgs
 	Message        = link.Message
 )
 
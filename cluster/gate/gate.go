@@ -9,27 +9,38 @@ package gate
 
 import (
 	"context"
+	"encoding/binary"
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/component"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/locate"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
 	"github.com/dobyte/due/v2/registry"
 	"github.com/dobyte/due/v2/session"
 	"github.com/dobyte/due/v2/transport"
+	"github.com/dobyte/due/v2/utils/xnet"
+	"sync/atomic"
 	"time"
 )
 
 const timeout = 5 * time.Second
 
+type HookHandler func(gate *Gate)
+
 type Gate struct {
 	component.Base
-	opts        *options
+	opts        atomic.Pointer[options]
+	rawOpts     []Option
 	ctx         context.Context
 	cancel      context.CancelFunc
 	proxy       *proxy
+	hooks       map[cluster.Hook]HookHandler
 	instance    *registry.ServiceInstance
 	session     *session.Session
 	transporter transport.Server
+	revision    int64
 }
 
 func NewGate(opts ...Option) *Gate {
@@ -39,9 +50,11 @@ func NewGate(opts ...Option) *Gate {
 	}
 
 	g := &Gate{}
-	g.opts = o
+	g.opts.Store(o)
+	g.rawOpts = opts
 	g.proxy = newProxy(g)
-	g.session = session.NewSession()
+	g.hooks = make(map[cluster.Hook]HookHandler)
+	g.session = session.NewSession(session.WithMultiConn(o.multiConn))
 	g.ctx, g.cancel = context.WithCancel(o.ctx)
 
 	return g
@@ -49,30 +62,36 @@ func NewGate(opts ...Option) *Gate {
 
 // Name 组件名称
 func (g *Gate) Name() string {
-	return g.opts.name
+	return g.opts.Load().name
 }
 
 // Init 初始化
 func (g *Gate) Init() {
-	if g.opts.id == "" {
+	if g.opts.Load().id == "" {
 		log.Fatal("instance id can not be empty")
 	}
 
-	if g.opts.server == nil {
+	if g.opts.Load().server == nil {
 		log.Fatal("server component is not injected")
 	}
 
-	if g.opts.locator == nil {
+	if g.opts.Load().locator == nil {
 		log.Fatal("locator component is not injected")
 	}
 
-	if g.opts.registry == nil {
+	if _, ok := g.opts.Load().locator.(locate.MultiGateLocator); g.opts.Load().multiConn && !ok {
+		log.Fatal("locator component does not support multiple gates")
+	}
+
+	if g.opts.Load().registry == nil {
 		log.Fatal("registry component is not injected")
 	}
 
-	if g.opts.transporter == nil {
+	if g.opts.Load().transporter == nil {
 		log.Fatal("transporter component is not injected")
 	}
+
+	g.runHookFunc(cluster.Init)
 }
 
 // Start 启动组件
@@ -81,15 +100,60 @@ func (g *Gate) Start() {
 
 	g.startTransporter()
 
-	g.registerServiceInstance()
+	if err := g.registerServiceInstance(); err != nil {
+		log.Fatalf("register gate instance failed: %v", err)
+	}
 
 	g.proxy.watch(g.ctx)
 
+	g.proxy.keepalive(g.ctx)
+
 	g.debugPrint()
+
+	g.runHookFunc(cluster.Start)
+}
+
+// Restart 重启组件
+// 重新加载配置后仅重建发生变更的网络服务器与传输服务器，客户端连接不受影响
+// 传输服务器重建或实例名称变更时重新注册服务实例，踢下线策略变更时启动或取消用户网关绑定监听
+// 任一步骤失败时恢复原有配置并返回错误，原有的网络服务器、传输服务器及注册信息保持运行
+func (g *Gate) Restart() error {
+	oldOpts, newOpts := g.opts.Load(), g.reloadOptions()
+
+	g.opts.Store(newOpts)
+
+	if err := g.restartNetworkServer(); err != nil {
+		g.opts.Store(oldOpts)
+		return err
+	}
+
+	rebuilt, err := g.restartTransporter()
+	if err != nil {
+		g.opts.Store(oldOpts)
+		return err
+	}
+
+	if rebuilt || newOpts.name != oldOpts.name {
+		if err = g.registerServiceInstance(); err != nil {
+			return err
+		}
+	}
+
+	if err = g.proxy.syncGateBinding(g.ctx); err != nil {
+		return errors.NewError("user locate event watch failed", err)
+	}
+
+	g.debugPrint()
+
+	g.runHookFunc(cluster.Restart)
+
+	return nil
 }
 
 // Destroy 销毁组件
 func (g *Gate) Destroy() {
+	g.runHookFunc(cluster.Destroy)
+
 	g.deregisterServiceInstance()
 
 	g.stopNetworkServer()
@@ -101,18 +165,32 @@ func (g *Gate) Destroy() {
 
 // 启动网络服务器
 func (g *Gate) startNetworkServer() {
-	g.opts.server.OnConnect(g.handleConnect)
-	g.opts.server.OnDisconnect(g.handleDisconnect)
-	g.opts.server.OnReceive(g.handleReceive)
+	g.opts.Load().server.OnConnect(g.handleConnect)
+	g.opts.Load().server.OnDisconnect(g.handleDisconnect)
+	g.opts.Load().server.OnReceive(g.handleReceive)
 
-	if err := g.opts.server.Start(); err != nil {
+	if err := g.opts.Load().server.Start(); err != nil {
 		log.Fatalf("network server start failed: %v", err)
 	}
 }
 
+// 重启网络服务器，未实现network.Restarter接口的服务器保持运行
+func (g *Gate) restartNetworkServer() error {
+	restarter, ok := g.opts.Load().server.(network.Restarter)
+	if !ok {
+		return nil
+	}
+
+	if err := restarter.Restart(); err != nil {
+		return errors.NewError("network server restart failed", err)
+	}
+
+	return nil
+}
+
 // 停止网关服务器
 func (g *Gate) stopNetworkServer() {
-	if err := g.opts.server.Stop(); err != nil {
+	if err := g.opts.Load().server.Stop(); err != nil {
 		log.Errorf("network server stop failed: %v", err)
 	}
 }
@@ -122,7 +200,7 @@ func (g *Gate) handleConnect(conn network.Conn) {
 	g.session.AddConn(conn)
 
 	cid, uid := conn.ID(), conn.UID()
-	ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
+	ctx, cancel := context.WithTimeout(g.ctx, g.opts.Load().timeout)
 	g.proxy.trigger(ctx, cluster.Connect, cid, uid)
 	cancel()
 }
@@ -132,12 +210,16 @@ func (g *Gate) handleDisconnect(conn network.Conn) {
 	g.session.RemConn(conn)
 
 	if cid, uid := conn.ID(), conn.UID(); uid != 0 {
-		ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
-		_ = g.proxy.unbindGate(ctx, cid, uid)
-		g.proxy.trigger(ctx, cluster.Disconnect, cid, uid)
+		ctx, cancel := context.WithTimeout(g.ctx, g.opts.Load().timeout)
+		// 多端登录模式下用户在当前网关上仍存在其他连接时保留网关绑定
+		devices := g.session.Devices(uid)
+		if devices == 0 {
+			_ = g.proxy.unbindGate(ctx, cid, uid)
+		}
+		g.proxy.trigger(ctx, cluster.Disconnect, cid, uid, int32(devices))
 		cancel()
 	} else {
-		ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
+		ctx, cancel := context.WithTimeout(g.ctx, g.opts.Load().timeout)
 		g.proxy.trigger(ctx, cluster.Disconnect, cid, uid)
 		cancel()
 	}
@@ -146,20 +228,53 @@ func (g *Gate) handleDisconnect(conn network.Conn) {
 // 处理接收到的消息
 func (g *Gate) handleReceive(conn network.Conn, data []byte) {
 	cid, uid := conn.ID(), conn.UID()
-	ctx, cancel := context.WithTimeout(g.ctx, g.opts.timeout)
+	ctx, cancel := context.WithTimeout(g.ctx, g.opts.Load().timeout)
 	g.proxy.deliver(ctx, cid, uid, data)
 	cancel()
 }
 
+// 踢掉连接，推送踢下线通知后关闭连接
+func (g *Gate) kick(cid int64, reason cluster.KickReason) {
+	if g.opts.Load().kickRoute != 0 {
+		buf := make([]byte, 4)
+		binary.BigEndian.PutUint32(buf, uint32(reason))
+
+		msg, err := packet.PackMessage(&packet.Message{Route: g.opts.Load().kickRoute, Buffer: buf})
+		if err != nil {
+			log.Errorf("pack kick message failed: %v", err)
+		} else if err = g.session.Push(session.Conn, cid, msg); err != nil {
+			log.Warnf("push kick message failed, cid: %d err: %v", cid, err)
+		}
+	}
+
+	if err := g.session.Close(session.Conn, cid); err != nil && err != errors.ErrNotFoundSession {
+		log.Warnf("kick connection failed, cid: %d reason: %s err: %v", cid, reason, err)
+	}
+}
+
+// 踢掉在其他网关上重新登录的用户，连接关闭前不解绑用户，以保证断开连接事件携带用户ID
+func (g *Gate) kickUser(uid int64, reason cluster.KickReason) {
+	cid, err := g.session.CID(uid)
+	if err != nil {
+		return
+	}
+
+	g.kick(cid, reason)
+}
+
 // 启动传输服务器
 func (g *Gate) startTransporter() {
-	transporter, err := g.opts.transporter.NewGateServer(&provider{g})
+	transporter, err := g.opts.Load().transporter.NewGateServer(&provider{g})
 	if err != nil {
 		log.Fatalf("transporter create failed: %v", err)
 	}
 
 	g.transporter = transporter
 
+	if reloader, ok := g.opts.Load().transporter.(transport.Reloader); ok {
+		g.revision = reloader.Revision()
+	}
+
 	go func() {
 		if err = g.transporter.Start(); err != nil {
 			log.Fatalf("transporter start failed: %v", err)
@@ -174,37 +289,106 @@ func (g *Gate) stopTransporter() {
 	}
 }
 
-// 注册服务实例
-func (g *Gate) registerServiceInstance() {
-	g.instance = &registry.ServiceInstance{
-		ID:       g.opts.id,
+// 重启传输服务器，仅当传输器的配置版本发生变更时重建，返回是否已重建
+// 新的传输服务器创建失败或新的监听地址不可用时保留原传输服务器
+func (g *Gate) restartTransporter() (bool, error) {
+	reloader, ok := g.opts.Load().transporter.(transport.Reloader)
+	if !ok {
+		return false, nil
+	}
+
+	revision := reloader.Reload()
+	if revision == g.revision {
+		return false, nil
+	}
+
+	transporter, err := g.opts.Load().transporter.NewGateServer(&provider{g})
+	if err != nil {
+		return false, errors.NewError("transporter create failed", err)
+	}
+
+	if transporter.Addr() != g.transporter.Addr() {
+		if err = xnet.CheckListenAddr(transporter.Addr()); err != nil {
+			return false, errors.NewError("transporter listen failed", err)
+		}
+	}
+
+	g.stopTransporter()
+
+	g.transporter = transporter
+	g.revision = revision
+
+	go func() {
+		if err := transporter.Start(); err != nil {
+			log.Errorf("transporter start failed: %v", err)
+		}
+	}()
+
+	return true, nil
+}
+
+// 注册服务实例，服务实例以实例ID注册，重复注册时将覆盖原有的注册信息，注册失败时保留原有的注册信息
+func (g *Gate) registerServiceInstance() error {
+	instance := &registry.ServiceInstance{
+		ID:       g.opts.Load().id,
 		Name:     string(cluster.Gate),
 		Kind:     cluster.Gate.String(),
-		Alias:    g.opts.name,
+		Alias:    g.opts.Load().name,
 		State:    cluster.Work.String(),
 		Endpoint: g.transporter.Endpoint().String(),
 	}
 
 	ctx, cancel := context.WithTimeout(g.ctx, timeout)
-	err := g.opts.registry.Register(ctx, g.instance)
+	err := g.opts.Load().registry.Register(ctx, instance)
 	cancel()
 	if err != nil {
-		log.Fatalf("register gate instance failed: %v", err)
+		return err
 	}
+
+	g.instance = instance
+
+	return nil
 }
 
 // 解注册服务实例
 func (g *Gate) deregisterServiceInstance() {
 	ctx, cancel := context.WithTimeout(g.ctx, timeout)
-	err := g.opts.registry.Deregister(ctx, g.instance)
+	err := g.opts.Load().registry.Deregister(ctx, g.instance)
 	defer cancel()
 	if err != nil {
 		log.Errorf("deregister gate instance failed: %v", err)
 	}
 }
 
+// 重新加载配置，实例ID、上下文与多端登录模式在运行期间保持不变
+func (g *Gate) reloadOptions() *options {
+	o := defaultOptions()
+	for _, opt := range g.rawOpts {
+		opt(o)
+	}
+
+	oldOpts := g.opts.Load()
+	o.id = oldOpts.id
+	o.ctx = oldOpts.ctx
+	o.multiConn = oldOpts.multiConn
+
+	return o
+}
+
+// AddHookListener 添加钩子监听器，需在组件启动前添加
+func (g *Gate) AddHookListener(hook cluster.Hook, handler HookHandler) {
+	g.hooks[hook] = handler
+}
+
+// 执行钩子函数
+func (g *Gate) runHookFunc(hook cluster.Hook) {
+	if handler, ok := g.hooks[hook]; ok {
+		handler(g)
+	}
+}
+
 func (g *Gate) debugPrint() {
 	log.Debugf("gate server startup successful")
-	log.Debugf("%s server listen on %s", g.opts.server.Protocol(), g.opts.server.Addr())
+	log.Debugf("%s server listen on %s", g.opts.Load().server.Protocol(), g.opts.Load().server.Addr())
 	log.Debugf("%s server listen on %s", g.transporter.Scheme(), g.transporter.Addr())
 }
@@ -9,6 +9,7 @@ package gate
 
 import (
 	"context"
+	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/locate"
 	"github.com/dobyte/due/v2/transport"
@@ -20,14 +21,18 @@ import (
 )
 
 const (
-	defaultName    = "gate"          // 默认名称
-	defaultTimeout = 3 * time.Second // 默认超时时间
+	defaultName    = "gate"                // 默认名称
+	defaultTimeout = 3 * time.Second       // 默认超时时间
+	defaultPolicy  = cluster.AllowMultiple // 默认会话策略
 )
 
 const (
-	defaultIDKey      = "etc.cluster.gate.id"
-	defaultNameKey    = "etc.cluster.gate.name"
-	defaultTimeoutKey = "etc.cluster.gate.timeout"
+	defaultIDKey        = "etc.cluster.gate.id"
+	defaultNameKey      = "etc.cluster.gate.name"
+	defaultTimeoutKey   = "etc.cluster.gate.timeout"
+	defaultPolicyKey    = "etc.cluster.gate.sessionPolicy"
+	defaultKickRouteKey = "etc.cluster.gate.kickRoute"
+	defaultMultiConnKey = "etc.cluster.multiConn"
 )
 
 type Option func(o *options)
@@ -41,6 +46,9 @@ type options struct {
 	locator     locate.Locator        // 用户定位器
 	registry    registry.Registry     // 服务注册器
 	transporter transport.Transporter // 消息传输器
+	policy      cluster.SessionPolicy // 重复登录时的会话策略
+	kickRoute   int32                 // 踢下线通知路由，为0时不推送踢下线通知
+	multiConn   bool                  // 是否开启多端登录模式，默认读取网关、节点与网格共用的配置
 }
 
 func defaultOptions() *options {
@@ -48,6 +56,7 @@ func defaultOptions() *options {
 		ctx:     context.Background(),
 		name:    defaultName,
 		timeout: defaultTimeout,
+		policy:  defaultPolicy,
 	}
 
 	if id := etc.Get(defaultIDKey).String(); id != "" {
@@ -64,6 +73,14 @@ func defaultOptions() *options {
 		opts.timeout = timeout
 	}
 
+	if policy := etc.Get(defaultPolicyKey).String(); policy != "" {
+		opts.policy = cluster.SessionPolicy(policy)
+	}
+
+	opts.kickRoute = etc.Get(defaultKickRouteKey).Int32()
+
+	opts.multiConn = etc.Get(defaultMultiConnKey).Bool()
+
 	return opts
 }
 
@@ -106,3 +123,20 @@ func WithRegistry(r registry.Registry) Option {
 func WithTransporter(transporter transport.Transporter) Option {
 	return func(o *options) { o.transporter = transporter }
 }
+
+// WithSessionPolicy 设置重复登录时的会话策略
+func WithSessionPolicy(policy cluster.SessionPolicy) Option {
+	return func(o *options) { o.policy = policy }
+}
+
+// WithKickRoute 设置踢下线通知路由
+// 设置后，会话被踢下线前会向客户端推送该路由的消息，消息体为4字节大端序的踢下线原因码（cluster.KickReason）
+func WithKickRoute(route int32) Option {
+	return func(o *options) { o.kickRoute = route }
+}
+
+// WithMultiConn 设置是否开启多端登录模式
+// 开启后同一用户可在多个网关上同时绑定多个连接，会话策略将不再生效，用户定位器需实现locate.MultiGateLocator接口
+func WithMultiConn(multiConn bool) Option {
+	return func(o *options) { o.multiConn = multiConn }
+}
@@ -2,6 +2,7 @@ package gate
 
 import (
 	"context"
+	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/packet"
@@ -18,6 +19,23 @@ func (p *provider) Bind(ctx context.Context, cid, uid int64) error {
 		return errors.ErrInvalidArgument
 	}
 
+	// 多端登录模式下会话策略不生效
+	policy := p.gate.opts.Load().policy
+	if p.gate.opts.Load().multiConn {
+		policy = cluster.AllowMultiple
+	}
+
+	oldCID, _ := p.gate.session.CID(uid)
+	if oldCID == cid {
+		oldCID = 0
+	}
+
+	if policy == cluster.RejectNew {
+		if err := p.checkOnline(ctx, uid, oldCID); err != nil {
+			return err
+		}
+	}
+
 	err := p.gate.session.Bind(cid, uid)
 	if err != nil {
 		return err
@@ -26,9 +44,44 @@ func (p *provider) Bind(ctx context.Context, cid, uid int64) error {
 	err = p.gate.proxy.bindGate(ctx, cid, uid)
 	if err != nil {
 		_, _ = p.gate.session.Unbind(uid)
+		return err
 	}
 
-	return err
+	if policy == cluster.KickOld && oldCID != 0 {
+		p.gate.kick(oldCID, cluster.KickDuplicateLogin)
+	}
+
+	return nil
+}
+
+// 检测用户是否已在其他连接或其他网关上在线
+func (p *provider) checkOnline(ctx context.Context, uid, oldCID int64) error {
+	if oldCID != 0 {
+		return errors.ErrUserAlreadyOnline
+	}
+
+	gid, err := p.gate.opts.Load().locator.LocateGate(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	if gid == "" || gid == p.gate.opts.Load().id {
+		return nil
+	}
+
+	// 网关异常退出时用户绑定不会被清除，仅当绑定的网关仍在注册中心存活时才视为在线
+	services, err := p.gate.proxy.link.FetchServiceList(ctx, cluster.Gate.String())
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		if service.ID == gid {
+			return errors.ErrUserAlreadyOnline
+		}
+	}
+
+	return nil
 }
 
 // Unbind 解绑用户与网关间的关系
@@ -57,7 +110,9 @@ func (p *provider) IsOnline(ctx context.Context, kind session.Kind, target int64
 
 // Push 发送消息
 func (p *provider) Push(ctx context.Context, kind session.Kind, target int64, message *packet.Message) error {
-	log.Debugf("push message: kind: %s target: %d route: %d buffer: %s", kind.String(), target, message.Route, string(message.Buffer))
+	if log.Enabled(log.DebugLevel) {
+		log.WithContext(ctx).WithFields(log.Fields{"kind": kind.String(), "target": target, "route": message.Route}).Debugf("push message: %s", string(message.Buffer))
+	}
 
 	msg, err := packet.PackMessage(message)
 	if err != nil {
@@ -66,7 +121,7 @@ func (p *provider) Push(ctx context.Context, kind session.Kind, target int64, me
 
 	err = p.gate.session.Push(kind, target, msg)
 	if kind == session.User && err == errors.ErrNotFoundSession {
-		err = p.gate.opts.locator.UnbindGate(ctx, target, p.gate.opts.id)
+		err = p.gate.proxy.unlocateGate(ctx, target)
 		if err != nil {
 			return err
 		}
@@ -81,22 +136,24 @@ func (p *provider) Multicast(ctx context.Context, kind session.Kind, targets []i
 		return 0, nil
 	}
 
-	msg, err := packet.PackMessage(message)
+	buf, err := packet.PackBuffer(message)
 	if err != nil {
 		return 0, err
 	}
+	defer buf.Release()
 
-	return p.gate.session.Multicast(kind, targets, msg)
+	return p.gate.session.MulticastBuffer(kind, targets, buf)
 }
 
 // Broadcast 推送广播消息
 func (p *provider) Broadcast(ctx context.Context, kind session.Kind, message *packet.Message) (int64, error) {
-	msg, err := packet.PackMessage(message)
+	buf, err := packet.PackBuffer(message)
 	if err != nil {
 		return 0, err
 	}
+	defer buf.Release()
 
-	return p.gate.session.Broadcast(kind, msg)
+	return p.gate.session.BroadcastBuffer(kind, buf)
 }
 
 // Stat 统计会话总数
@@ -108,3 +165,21 @@ func (p *provider) Stat(ctx context.Context, kind session.Kind) (int64, error) {
 func (p *provider) Disconnect(ctx context.Context, kind session.Kind, target int64, isForce bool) error {
 	return p.gate.session.Close(kind, target, isForce)
 }
+
+// JoinGroup 将用户加入分组
+func (p *provider) JoinGroup(ctx context.Context, gid int64, uids []int64) (int64, error) {
+	if len(uids) == 0 {
+		return 0, nil
+	}
+
+	return p.gate.session.JoinGroup(gid, uids...), nil
+}
+
+// LeaveGroup 将用户移出分组
+func (p *provider) LeaveGroup(ctx context.Context, gid int64, uids []int64) (int64, error) {
+	if len(uids) == 0 {
+		return 0, nil
+	}
+
+	return p.gate.session.LeaveGroup(gid, uids...), nil
+}
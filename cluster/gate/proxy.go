@@ -5,27 +5,38 @@ import (
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/internal/link"
+	"github.com/dobyte/due/v2/locate"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/packet"
+	"sync"
+	"time"
 )
 
 type proxy struct {
-	gate *Gate      // 网关服
-	link *link.Link // 连接
+	gate          *Gate              // 网关服
+	link          *link.Link         // 连接
+	mu            sync.Mutex         // 锁
+	bindingCancel context.CancelFunc // 取消用户网关绑定监听
 }
 
 func newProxy(gate *Gate) *proxy {
 	return &proxy{gate: gate, link: link.NewLink(&link.Options{
-		GID:         gate.opts.id,
-		Locator:     gate.opts.locator,
-		Registry:    gate.opts.registry,
-		Transporter: gate.opts.transporter,
+		GID:         gate.opts.Load().id,
+		Locator:     gate.opts.Load().locator,
+		Registry:    gate.opts.Load().registry,
+		Transporter: gate.opts.Load().transporter,
+		MultiConn:   gate.opts.Load().multiConn,
 	})}
 }
 
 // 绑定用户与网关间的关系
 func (p *proxy) bindGate(ctx context.Context, cid, uid int64) error {
-	err := p.gate.opts.locator.BindGate(ctx, uid, p.gate.opts.id)
+	var err error
+	if p.gate.opts.Load().multiConn {
+		err = p.gate.opts.Load().locator.(locate.MultiGateLocator).AddGate(ctx, uid, p.gate.opts.Load().id)
+	} else {
+		err = p.gate.opts.Load().locator.BindGate(ctx, uid, p.gate.opts.Load().id)
+	}
 	if err != nil {
 		return err
 	}
@@ -37,22 +48,37 @@ func (p *proxy) bindGate(ctx context.Context, cid, uid int64) error {
 
 // 解绑用户与网关间的关系
 func (p *proxy) unbindGate(ctx context.Context, cid, uid int64) error {
-	err := p.gate.opts.locator.UnbindGate(ctx, uid, p.gate.opts.id)
+	err := p.unlocateGate(ctx, uid)
 	if err != nil {
-		log.Errorf("user unbind failed, gid: %d, cid: %d, uid: %d, err: %v", p.gate.opts.id, cid, uid, err)
+		log.WithFields(log.Fields{"gid": p.gate.opts.Load().id, "cid": cid, "uid": uid}).Errorf("user unbind failed: %v", err)
 	}
 
 	return err
 }
 
-// 触发事件
-func (p *proxy) trigger(ctx context.Context, event cluster.Event, cid, uid int64) {
-	if err := p.link.Trigger(ctx, &link.TriggerArgs{
+// 移除用户在定位器中的网关绑定
+func (p *proxy) unlocateGate(ctx context.Context, uid int64) error {
+	if p.gate.opts.Load().multiConn {
+		return p.gate.opts.Load().locator.(locate.MultiGateLocator).RemGate(ctx, uid, p.gate.opts.Load().id)
+	}
+
+	return p.gate.opts.Load().locator.UnbindGate(ctx, uid, p.gate.opts.Load().id)
+}
+
+// 触发事件，断开连接事件将携带用户在当前网关上剩余的连接数
+func (p *proxy) trigger(ctx context.Context, event cluster.Event, cid, uid int64, devices ...int32) {
+	args := &link.TriggerArgs{
 		Event: int(event),
 		CID:   cid,
 		UID:   uid,
-	}); err != nil && err != errors.ErrNotFoundEvent && err != errors.ErrNotFoundUserLocation {
-		log.Warnf("trigger event failed, cid: %d, uid: %d, event: %v, err: %v", cid, uid, event.String(), err)
+	}
+
+	if len(devices) > 0 {
+		args.Devices = devices[0]
+	}
+
+	if err := p.link.Trigger(ctx, args); err != nil && err != errors.ErrNotFoundEvent && err != errors.ErrNotFoundUserLocation {
+		log.WithFields(log.Fields{"cid": cid, "uid": uid, "event": event.String()}).Warnf("trigger event failed: %v", err)
 	}
 }
 
@@ -64,14 +90,17 @@ func (p *proxy) deliver(ctx context.Context, cid, uid int64, data []byte) {
 		return
 	}
 
-	log.Debugf("deliver message: cid: %d uid: %d route: %d buffer: %s", cid, uid, message.Route, string(message.Buffer))
+	// 每条消息都会经过此处，仅在输出调试日志时构建日志字段
+	if log.Enabled(log.DebugLevel) {
+		log.WithContext(ctx).WithFields(log.Fields{"cid": cid, "uid": uid, "route": message.Route}).Debugf("deliver message: %s", string(message.Buffer))
+	}
 
 	if err = p.link.Deliver(ctx, &link.DeliverArgs{
 		CID:     cid,
 		UID:     uid,
 		Message: message,
 	}); err != nil {
-		log.Errorf("deliver message failed, cid = %d uid = %d route = %d err = %v", cid, uid, message.Route, err)
+		log.WithContext(ctx).WithFields(log.Fields{"cid": cid, "uid": uid, "route": message.Route}).Errorf("deliver message failed: %v", err)
 	}
 }
 
@@ -80,4 +109,102 @@ func (p *proxy) watch(ctx context.Context) {
 	p.link.WatchUserLocate(ctx, cluster.Node.String())
 
 	p.link.WatchServiceInstance(ctx, cluster.Node.String())
+
+	if err := p.syncGateBinding(ctx); err != nil {
+		log.Fatalf("user locate event watch failed: %v", err)
+	}
+}
+
+// 根据当前配置启动或取消用户网关绑定监听，仅在踢掉旧会话且非多端登录模式下监听
+func (p *proxy) syncGateBinding(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	opts := p.gate.opts.Load()
+	need := opts.policy == cluster.KickOld && !opts.multiConn
+
+	switch {
+	case need && p.bindingCancel == nil:
+		cancel, err := p.watchGateBinding(ctx)
+		if err != nil {
+			return err
+		}
+		p.bindingCancel = cancel
+	case !need && p.bindingCancel != nil:
+		p.bindingCancel()
+		p.bindingCancel = nil
+	}
+
+	return nil
+}
+
+// 定期刷新用户与网关间绑定关系的有效期，定位器不支持绑定关系过期时不刷新
+func (p *proxy) keepalive(ctx context.Context) {
+	refresher, ok := p.gate.opts.Load().locator.(locate.Refresher)
+	if !ok || refresher.TTL() <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(refresher.TTL() / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				uids := p.gate.session.UIDs()
+				if len(uids) == 0 {
+					continue
+				}
+
+				rctx, rcancel := context.WithTimeout(ctx, p.gate.opts.Load().timeout)
+				if err := refresher.RefreshGate(rctx, p.gate.opts.Load().id, uids); err != nil {
+					log.Warnf("refresh user's gate binding failed: %v", err)
+				}
+				rcancel()
+			}
+		}
+	}()
+}
+
+// 监听用户网关绑定，用户在其他网关上重新绑定时踢掉本网关上的旧会话
+// 返回的取消函数用于停止监听
+func (p *proxy) watchGateBinding(ctx context.Context) (context.CancelFunc, error) {
+	rctx, rcancel := context.WithTimeout(ctx, 10*time.Second)
+	watcher, err := p.gate.opts.Load().locator.Watch(rctx, cluster.Gate.String())
+	rcancel()
+	if err != nil {
+		return nil, err
+	}
+
+	wctx, wcancel := context.WithCancel(ctx)
+
+	go func() {
+		<-wctx.Done()
+		_ = watcher.Stop()
+	}()
+
+	go func() {
+		for {
+			select {
+			case <-wctx.Done():
+				return
+			default:
+				// exec watch
+			}
+			events, err := watcher.Next()
+			if err != nil {
+				continue
+			}
+			for _, event := range events {
+				if event.Type == locate.BindGate && event.InsID != p.gate.opts.Load().id {
+					p.gate.kickUser(event.UID, cluster.KickDuplicateLogin)
+				}
+			}
+		}
+	}()
+
+	return wcancel, nil
 }
@@ -76,6 +76,13 @@ func (m *Master) Start() {
 	m.runHookFunc(cluster.Start)
 }
 
+// Restart 重启组件
+func (m *Master) Restart() error {
+	m.runHookFunc(cluster.Restart)
+
+	return nil
+}
+
 // Destroy 销毁组件
 func (m *Master) Destroy() {
 	m.cancel()
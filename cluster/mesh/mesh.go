@@ -4,9 +4,11 @@ import (
 	"context"
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/component"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/registry"
 	"github.com/dobyte/due/v2/transport"
+	"github.com/dobyte/due/v2/utils/xnet"
 	"github.com/dobyte/due/v2/utils/xuuid"
 	"golang.org/x/sync/errgroup"
 	"sync/atomic"
@@ -19,7 +21,8 @@ type HookHandler func(proxy *Proxy)
 
 type Mesh struct {
 	component.Base
-	opts        *options
+	opts        atomic.Pointer[options]
+	rawOpts     []Option
 	ctx         context.Context
 	cancel      context.CancelFunc
 	state       int32
@@ -28,6 +31,7 @@ type Mesh struct {
 	instances   []*registry.ServiceInstance
 	hooks       map[cluster.Hook]HookHandler
 	transporter transport.Server
+	revision    int64
 }
 
 type serviceEntity struct {
@@ -43,7 +47,9 @@ func NewMesh(opts ...Option) *Mesh {
 	}
 
 	m := &Mesh{}
-	m.opts = o
+	m.opts.Store(o)
+	m.rawOpts = opts
+	m.hooks = make(map[cluster.Hook]HookHandler)
 	m.services = make([]*serviceEntity, 0)
 	m.instances = make([]*registry.ServiceInstance, 0)
 	m.proxy = newProxy(m)
@@ -56,20 +62,20 @@ func NewMesh(opts ...Option) *Mesh {
 
 // Name 组件名称
 func (m *Mesh) Name() string {
-	return m.opts.name
+	return m.opts.Load().name
 }
 
 // Init 初始化节点
 func (m *Mesh) Init() {
-	if m.opts.codec == nil {
+	if m.opts.Load().codec == nil {
 		log.Fatal("codec component is not injected")
 	}
 
-	if m.opts.registry == nil {
+	if m.opts.Load().registry == nil {
 		log.Fatal("registry component is not injected")
 	}
 
-	if m.opts.transporter == nil {
+	if m.opts.Load().transporter == nil {
 		log.Fatal("transporter component is not injected")
 	}
 
@@ -82,7 +88,9 @@ func (m *Mesh) Start() {
 
 	m.startTransporter()
 
-	m.registerServiceInstances()
+	if err := m.registerServiceInstances(); err != nil {
+		log.Fatalf("register mesh instance failed: %v", err)
+	}
 
 	m.proxy.watch(m.ctx)
 
@@ -91,11 +99,42 @@ func (m *Mesh) Start() {
 	m.runHookFunc(cluster.Start)
 }
 
+// Restart 重启
+// 重新加载配置后仅在传输器配置发生变更时重建传输服务器并重新注册所有服务实例
+// 任一步骤失败时恢复原有配置并返回错误，原有的传输服务器及注册信息保持运行
+func (m *Mesh) Restart() error {
+	oldOpts := m.opts.Load()
+
+	m.opts.Store(m.reloadOptions())
+
+	rebuilt, err := m.restartTransporter()
+	if err != nil {
+		m.opts.Store(oldOpts)
+		return err
+	}
+
+	if rebuilt {
+		instances := m.instances
+
+		if err = m.registerServiceInstances(); err != nil {
+			return err
+		}
+
+		m.deregisterServiceInstances(instances)
+	}
+
+	m.debugPrint()
+
+	m.runHookFunc(cluster.Restart)
+
+	return nil
+}
+
 // Destroy 销毁网关服务器
 func (m *Mesh) Destroy() {
 	m.setState(cluster.Shut)
 
-	m.deregisterServiceInstances()
+	m.deregisterServiceInstances(m.instances)
 
 	m.stopTransporter()
 
@@ -111,15 +150,19 @@ func (m *Mesh) Proxy() *Proxy {
 
 // 启动传输服务器
 func (m *Mesh) startTransporter() {
-	m.opts.transporter.SetDefaultDiscovery(m.opts.registry)
+	m.opts.Load().transporter.SetDefaultDiscovery(m.opts.Load().registry)
 
-	transporter, err := m.opts.transporter.NewServiceServer()
+	transporter, err := m.opts.Load().transporter.NewServiceServer()
 	if err != nil {
 		log.Fatalf("transporter create failed: %v", err)
 	}
 
 	m.transporter = transporter
 
+	if reloader, ok := m.opts.Load().transporter.(transport.Reloader); ok {
+		m.revision = reloader.Revision()
+	}
+
 	for _, entity := range m.services {
 		err = m.transporter.RegisterService(entity.desc, entity.provider)
 		if err != nil {
@@ -141,13 +184,58 @@ func (m *Mesh) stopTransporter() {
 	}
 }
 
-// 注册服务实例
-func (m *Mesh) registerServiceInstances() {
+// 重启传输服务器，仅当传输器的配置版本发生变更时重建，返回是否已重建
+// 新的传输服务器创建失败或新的监听地址不可用时保留原传输服务器
+func (m *Mesh) restartTransporter() (bool, error) {
+	reloader, ok := m.opts.Load().transporter.(transport.Reloader)
+	if !ok {
+		return false, nil
+	}
+
+	revision := reloader.Reload()
+	if revision == m.revision {
+		return false, nil
+	}
+
+	transporter, err := m.opts.Load().transporter.NewServiceServer()
+	if err != nil {
+		return false, errors.NewError("transporter create failed", err)
+	}
+
+	for _, entity := range m.services {
+		if err = transporter.RegisterService(entity.desc, entity.provider); err != nil {
+			return false, errors.NewError("register service failed", err)
+		}
+	}
+
+	if transporter.Addr() != m.transporter.Addr() {
+		if err = xnet.CheckListenAddr(transporter.Addr()); err != nil {
+			return false, errors.NewError("transporter listen failed", err)
+		}
+	}
+
+	m.stopTransporter()
+
+	m.transporter = transporter
+	m.revision = revision
+
+	go func() {
+		if err := transporter.Start(); err != nil {
+			log.Errorf("transporter start failed: %v", err)
+		}
+	}()
+
+	return true, nil
+}
+
+// 注册服务实例，注册失败时解注册本次注册的服务实例，并保留原有的注册信息
+func (m *Mesh) registerServiceInstances() error {
 	var (
-		id       string
-		check    = make(map[string]struct{}, len(m.services))
-		endpoint = m.transporter.Endpoint().String()
-		state    = m.getState().String()
+		id        string
+		check     = make(map[string]struct{}, len(m.services))
+		endpoint  = m.transporter.Endpoint().String()
+		state     = m.getState().String()
+		instances = make([]*registry.ServiceInstance, 0, len(m.services))
 	)
 
 	for _, entity := range m.services {
@@ -159,7 +247,7 @@ func (m *Mesh) registerServiceInstances() {
 			}
 		}
 
-		m.instances = append(m.instances, &registry.ServiceInstance{
+		instances = append(instances, &registry.ServiceInstance{
 			ID:       id,
 			Name:     entity.name,
 			Kind:     cluster.Mesh.String(),
@@ -170,29 +258,34 @@ func (m *Mesh) registerServiceInstances() {
 	}
 
 	eg, ctx := errgroup.WithContext(m.ctx)
-	for i := range m.instances {
-		instance := m.instances[i]
+	for i := range instances {
+		instance := instances[i]
 		eg.Go(func() error {
 			rctx, rcancel := context.WithTimeout(ctx, timeout)
 			defer rcancel()
-			return m.opts.registry.Register(rctx, instance)
+			return m.opts.Load().registry.Register(rctx, instance)
 		})
 	}
 
 	if err := eg.Wait(); err != nil {
-		log.Fatalf("register mesh instance failed: %v", err)
+		m.deregisterServiceInstances(instances)
+		return err
 	}
+
+	m.instances = instances
+
+	return nil
 }
 
 // 解注册服务实例
-func (m *Mesh) deregisterServiceInstances() {
+func (m *Mesh) deregisterServiceInstances(instances []*registry.ServiceInstance) {
 	eg, ctx := errgroup.WithContext(m.ctx)
-	for i := range m.instances {
-		instance := m.instances[i]
+	for i := range instances {
+		instance := instances[i]
 		eg.Go(func() error {
 			dctx, dcancel := context.WithTimeout(ctx, timeout)
 			defer dcancel()
-			return m.opts.registry.Deregister(dctx, instance)
+			return m.opts.Load().registry.Deregister(dctx, instance)
 		})
 	}
 
@@ -211,6 +304,20 @@ func (m *Mesh) getState() cluster.State {
 	return cluster.State(atomic.LoadInt32(&m.state))
 }
 
+// 重新加载配置，上下文与多端登录模式在运行期间保持不变
+func (m *Mesh) reloadOptions() *options {
+	o := defaultOptions()
+	for _, opt := range m.rawOpts {
+		opt(o)
+	}
+
+	oldOpts := m.opts.Load()
+	o.ctx = oldOpts.ctx
+	o.multiConn = oldOpts.multiConn
+
+	return o
+}
+
 func (m *Mesh) debugPrint() {
 	log.Debugf("mesh server startup successful")
 	log.Debugf("%s server listen on %s", m.transporter.Scheme(), m.transporter.Addr())
@@ -18,9 +18,10 @@ const (
 )
 
 const (
-	defaultNameKey    = "etc.cluster.mesh.name"
-	defaultCodecKey   = "etc.cluster.mesh.codec"
-	defaultTimeoutKey = "etc.cluster.mesh.timeout"
+	defaultNameKey      = "etc.cluster.mesh.name"
+	defaultCodecKey     = "etc.cluster.mesh.codec"
+	defaultTimeoutKey   = "etc.cluster.mesh.timeout"
+	defaultMultiConnKey = "etc.cluster.multiConn"
 )
 
 type Option func(o *options)
@@ -34,6 +35,7 @@ type options struct {
 	registry    registry.Registry     // 服务注册器
 	transporter transport.Transporter // 消息传输器
 	encryptor   crypto.Encryptor      // 消息加密器
+	multiConn   bool                  // 是否开启多端登录模式，默认读取网关、节点与网格共用的配置
 }
 
 func defaultOptions() *options {
@@ -56,6 +58,8 @@ func defaultOptions() *options {
 		opts.timeout = time.Duration(timeout) * time.Second
 	}
 
+	opts.multiConn = etc.Get(defaultMultiConnKey).Bool()
+
 	return opts
 }
 
@@ -98,3 +102,8 @@ func WithTransporter(transporter transport.Transporter) Option {
 func WithEncryptor(encryptor crypto.Encryptor) Option {
 	return func(o *options) { o.encryptor = encryptor }
 }
+
+// WithMultiConn 设置是否开启多端登录模式，开启后推送给用户的消息将发送至用户绑定的所有网关
+func WithMultiConn(multiConn bool) Option {
+	return func(o *options) { o.multiConn = multiConn }
+}
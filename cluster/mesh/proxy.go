@@ -16,11 +16,12 @@ type Proxy struct {
 
 func newProxy(mesh *Mesh) *Proxy {
 	return &Proxy{mesh: mesh, link: link.NewLink(&link.Options{
-		Codec:       mesh.opts.codec,
-		Locator:     mesh.opts.locator,
-		Registry:    mesh.opts.registry,
-		Encryptor:   mesh.opts.encryptor,
-		Transporter: mesh.opts.transporter,
+		Codec:       mesh.opts.Load().codec,
+		Locator:     mesh.opts.Load().locator,
+		Registry:    mesh.opts.Load().registry,
+		Encryptor:   mesh.opts.Load().encryptor,
+		Transporter: mesh.opts.Load().transporter,
+		MultiConn:   mesh.opts.Load().multiConn,
 	})}
 }
 
@@ -39,7 +40,7 @@ func (p *Proxy) AddHookListener(hook cluster.Hook, handler HookHandler) {
 // 直连模式: 	direct://127.0.0.1:8011
 // 服务发现模式: 	discovery://service_name
 func (p *Proxy) NewServiceClient(target string) (transport.ServiceClient, error) {
-	return p.mesh.opts.transporter.NewServiceClient(target)
+	return p.mesh.opts.Load().transporter.NewServiceClient(target)
 }
 
 // BindGate 绑定网关
@@ -126,6 +127,17 @@ func (p *Proxy) Disconnect(ctx context.Context, args *cluster.DisconnectArgs) er
 	return p.link.Disconnect(ctx, args)
 }
 
+// JoinGroup 将用户加入分组，分组可作为推送消息的会话目标
+// 分组关系仅保存在用户所在网关的会话中，用户解绑或断开全部连接后将自动退出所有分组，重连后需重新加入
+func (p *Proxy) JoinGroup(ctx context.Context, args *cluster.GroupArgs) (int64, error) {
+	return p.link.JoinGroup(ctx, args)
+}
+
+// LeaveGroup 将用户移出分组
+func (p *Proxy) LeaveGroup(ctx context.Context, args *cluster.GroupArgs) (int64, error) {
+	return p.link.LeaveGroup(ctx, args)
+}
+
 // 启动监听
 func (p *Proxy) watch(ctx context.Context) {
 	p.link.WatchUserLocate(ctx, cluster.Gate.String())
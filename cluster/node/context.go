@@ -14,6 +14,8 @@ type Context interface {
 	CID() int64
 	// UID 获取用户ID
 	UID() int64
+	// Devices 获取用户在网关上剩余的连接数，仅多端登录模式下的断开连接事件有效
+	Devices() int32
 	// Seq 获取消息序列号
 	Seq() int32
 	// Route 获取消息路由号
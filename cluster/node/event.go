@@ -8,12 +8,13 @@ import (
 )
 
 type event struct {
-	proxy *Proxy
-	ctx   context.Context
-	gid   string
-	cid   int64
-	uid   int64
-	kind  cluster.Event
+	proxy   *Proxy
+	ctx     context.Context
+	gid     string
+	cid     int64
+	uid     int64
+	devices int32
+	kind    cluster.Event
 }
 
 // GID 获取网关ID
@@ -36,6 +37,11 @@ func (e *event) UID() int64 {
 	return e.uid
 }
 
+// Devices 获取用户在网关上剩余的连接数，仅多端登录模式下的断开连接事件有效
+func (e *event) Devices() int32 {
+	return e.devices
+}
+
 // Seq 获取消息序列号
 func (e *event) Seq() int32 {
 	return 0
@@ -59,11 +65,12 @@ func (e *event) Parse(v interface{}) error {
 // Clone 克隆Context
 func (e *event) Clone() Context {
 	return &event{
-		ctx:   context.Background(),
-		gid:   e.gid,
-		cid:   e.cid,
-		uid:   e.uid,
-		proxy: e.proxy,
+		ctx:     context.Background(),
+		gid:     e.gid,
+		cid:     e.cid,
+		uid:     e.uid,
+		devices: e.devices,
+		proxy:   e.proxy,
 	}
 }
 
@@ -4,10 +4,12 @@ import (
 	"context"
 	"github.com/dobyte/due/v2/cluster"
 	"github.com/dobyte/due/v2/component"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/registry"
 	"github.com/dobyte/due/v2/transport"
 	"github.com/dobyte/due/v2/utils/xcall"
+	"github.com/dobyte/due/v2/utils/xnet"
 	"sync/atomic"
 	"time"
 )
@@ -18,7 +20,8 @@ type HookHandler func(proxy *Proxy)
 
 type Node struct {
 	component.Base
-	opts        *options
+	opts        atomic.Pointer[options]
+	rawOpts     []Option
 	ctx         context.Context
 	cancel      context.CancelFunc
 	state       int32
@@ -28,6 +31,7 @@ type Node struct {
 	hooks       map[cluster.Hook]HookHandler
 	instance    *registry.ServiceInstance
 	transporter transport.Server
+	revision    int64
 	fnChan      chan func()
 }
 
@@ -38,7 +42,8 @@ func NewNode(opts ...Option) *Node {
 	}
 
 	n := &Node{}
-	n.opts = o
+	n.opts.Store(o)
+	n.rawOpts = opts
 	n.proxy = newProxy(n)
 	n.router = newRouter(n)
 	n.trigger = newTrigger(n)
@@ -53,32 +58,32 @@ func NewNode(opts ...Option) *Node {
 
 // Name 组件名称
 func (n *Node) Name() string {
-	return n.opts.name
+	return n.opts.Load().name
 }
 
 // Init 初始化节点
 func (n *Node) Init() {
-	if n.opts.id == "" {
+	if n.opts.Load().id == "" {
 		log.Fatal("instance id can not be empty")
 	}
 
-	if n.opts.name == "" {
+	if n.opts.Load().name == "" {
 		log.Fatal("instance name can not be empty")
 	}
 
-	if n.opts.codec == nil {
+	if n.opts.Load().codec == nil {
 		log.Fatal("codec component is not injected")
 	}
 
-	if n.opts.locator == nil {
+	if n.opts.Load().locator == nil {
 		log.Fatal("locator component is not injected")
 	}
 
-	if n.opts.registry == nil {
+	if n.opts.Load().registry == nil {
 		log.Fatal("registry component is not injected")
 	}
 
-	if n.opts.transporter == nil {
+	if n.opts.Load().transporter == nil {
 		log.Fatal("transporter component is not injected")
 	}
 
@@ -91,7 +96,9 @@ func (n *Node) Start() {
 
 	n.startTransporter()
 
-	n.registerServiceInstance()
+	if err := n.registerServiceInstance(); err != nil {
+		log.Fatalf("register node instance failed: %v", err)
+	}
 
 	n.proxy.watch(n.ctx)
 
@@ -102,6 +109,34 @@ func (n *Node) Start() {
 	n.runHookFunc(cluster.Start)
 }
 
+// Restart 重启节点
+// 重新加载配置后仅在传输器配置发生变更时重建传输服务器，已注册的路由与事件保持不变
+// 传输服务器重建或实例名称变更时重新注册服务实例
+// 任一步骤失败时恢复原有配置并返回错误，原有的传输服务器及注册信息保持运行
+func (n *Node) Restart() error {
+	oldOpts, newOpts := n.opts.Load(), n.reloadOptions()
+
+	n.opts.Store(newOpts)
+
+	rebuilt, err := n.restartTransporter()
+	if err != nil {
+		n.opts.Store(oldOpts)
+		return err
+	}
+
+	if rebuilt || newOpts.name != oldOpts.name {
+		if err = n.registerServiceInstance(); err != nil {
+			return err
+		}
+	}
+
+	n.debugPrint()
+
+	n.runHookFunc(cluster.Restart)
+
+	return nil
+}
+
 // Destroy 销毁网关服务器
 func (n *Node) Destroy() {
 	n.setState(cluster.Shut)
@@ -155,15 +190,19 @@ func (n *Node) dispatch() {
 
 // 启动传输服务器
 func (n *Node) startTransporter() {
-	n.opts.transporter.SetDefaultDiscovery(n.opts.registry)
+	n.opts.Load().transporter.SetDefaultDiscovery(n.opts.Load().registry)
 
-	transporter, err := n.opts.transporter.NewNodeServer(&provider{n})
+	transporter, err := n.opts.Load().transporter.NewNodeServer(&provider{n})
 	if err != nil {
 		log.Fatalf("transporter create failed: %v", err)
 	}
 
 	n.transporter = transporter
 
+	if reloader, ok := n.opts.Load().transporter.(transport.Reloader); ok {
+		n.revision = reloader.Revision()
+	}
+
 	go func() {
 		if err = n.transporter.Start(); err != nil {
 			log.Errorf("transporter start failed: %v", err)
@@ -178,8 +217,46 @@ func (n *Node) stopTransporter() {
 	}
 }
 
-// 注册服务实例
-func (n *Node) registerServiceInstance() {
+// 重启传输服务器，仅当传输器的配置版本发生变更时重建，返回是否已重建
+// 新的传输服务器创建失败或新的监听地址不可用时保留原传输服务器
+func (n *Node) restartTransporter() (bool, error) {
+	reloader, ok := n.opts.Load().transporter.(transport.Reloader)
+	if !ok {
+		return false, nil
+	}
+
+	revision := reloader.Reload()
+	if revision == n.revision {
+		return false, nil
+	}
+
+	transporter, err := n.opts.Load().transporter.NewNodeServer(&provider{n})
+	if err != nil {
+		return false, errors.NewError("transporter create failed", err)
+	}
+
+	if transporter.Addr() != n.transporter.Addr() {
+		if err = xnet.CheckListenAddr(transporter.Addr()); err != nil {
+			return false, errors.NewError("transporter listen failed", err)
+		}
+	}
+
+	n.stopTransporter()
+
+	n.transporter = transporter
+	n.revision = revision
+
+	go func() {
+		if err := transporter.Start(); err != nil {
+			log.Errorf("transporter start failed: %v", err)
+		}
+	}()
+
+	return true, nil
+}
+
+// 注册服务实例，服务实例以实例ID注册，重复注册时将覆盖原有的注册信息，注册失败时保留原有的注册信息
+func (n *Node) registerServiceInstance() error {
 	routes := make([]registry.Route, 0, len(n.router.routes))
 	for _, entity := range n.router.routes {
 		routes = append(routes, registry.Route{
@@ -194,11 +271,11 @@ func (n *Node) registerServiceInstance() {
 		events = append(events, int(event))
 	}
 
-	n.instance = &registry.ServiceInstance{
-		ID:       n.opts.id,
+	instance := &registry.ServiceInstance{
+		ID:       n.opts.Load().id,
 		Name:     string(cluster.Node),
 		Kind:     cluster.Node.String(),
-		Alias:    n.opts.name,
+		Alias:    n.opts.Load().name,
 		State:    n.getState().String(),
 		Routes:   routes,
 		Events:   events,
@@ -206,17 +283,21 @@ func (n *Node) registerServiceInstance() {
 	}
 
 	ctx, cancel := context.WithTimeout(n.ctx, timeout)
-	err := n.opts.registry.Register(ctx, n.instance)
+	err := n.opts.Load().registry.Register(ctx, instance)
 	cancel()
 	if err != nil {
-		log.Fatalf("register node instance failed: %v", err)
+		return err
 	}
+
+	n.instance = instance
+
+	return nil
 }
 
 // 解注册服务实例
 func (n *Node) deregisterServiceInstance() {
 	ctx, cancel := context.WithTimeout(n.ctx, timeout)
-	err := n.opts.registry.Deregister(ctx, n.instance)
+	err := n.opts.Load().registry.Deregister(ctx, n.instance)
 	cancel()
 	if err != nil {
 		log.Errorf("deregister node instance failed: %v", err)
@@ -245,7 +326,7 @@ func (n *Node) updateState(state cluster.State) error {
 	ctx, cancel := context.WithTimeout(n.ctx, timeout)
 	defer cancel()
 
-	err := n.opts.registry.Register(ctx, instance)
+	err := n.opts.Load().registry.Register(ctx, instance)
 	if err != nil {
 		return err
 	}
@@ -265,6 +346,21 @@ func (n *Node) addHookListener(hook cluster.Hook, handler HookHandler) {
 	}
 }
 
+// 重新加载配置，实例ID、上下文与多端登录模式在运行期间保持不变
+func (n *Node) reloadOptions() *options {
+	o := defaultOptions()
+	for _, opt := range n.rawOpts {
+		opt(o)
+	}
+
+	oldOpts := n.opts.Load()
+	o.id = oldOpts.id
+	o.ctx = oldOpts.ctx
+	o.multiConn = oldOpts.multiConn
+
+	return o
+}
+
 func (n *Node) debugPrint() {
 	log.Debugf("node server startup successful")
 	log.Debugf("%s server listen on %s", n.transporter.Scheme(), n.transporter.Addr())
@@ -19,10 +19,11 @@ const (
 )
 
 const (
-	defaultIDKey      = "etc.cluster.node.id"
-	defaultNameKey    = "etc.cluster.node.name"
-	defaultCodecKey   = "etc.cluster.node.codec"
-	defaultTimeoutKey = "etc.cluster.node.timeout"
+	defaultIDKey        = "etc.cluster.node.id"
+	defaultNameKey      = "etc.cluster.node.name"
+	defaultCodecKey     = "etc.cluster.node.codec"
+	defaultTimeoutKey   = "etc.cluster.node.timeout"
+	defaultMultiConnKey = "etc.cluster.multiConn"
 )
 
 const (
@@ -46,6 +47,7 @@ type options struct {
 	transporter     transport.Transporter // 消息传输器
 	encryptor       crypto.Encryptor      // 消息加密器
 	schedulingModel SchedulingModel       // 调度模型
+	multiConn       bool                  // 是否开启多端登录模式，默认读取网关、节点与网格共用的配置
 }
 
 func defaultOptions() *options {
@@ -74,6 +76,8 @@ func defaultOptions() *options {
 		opts.timeout = timeout
 	}
 
+	opts.multiConn = etc.Get(defaultMultiConnKey).Bool()
+
 	return opts
 }
 
@@ -126,3 +130,8 @@ func WithEncryptor(encryptor crypto.Encryptor) Option {
 func WithSchedulingModel(schedulingModel SchedulingModel) Option {
 	return func(o *options) { o.schedulingModel = schedulingModel }
 }
+
+// WithMultiConn 设置是否开启多端登录模式，开启后推送给用户的消息将发送至用户绑定的所有网关
+func WithMultiConn(multiConn bool) Option {
+	return func(o *options) { o.multiConn = multiConn }
+}
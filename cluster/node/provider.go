@@ -23,7 +23,7 @@ func (p *provider) Trigger(ctx context.Context, args *transport.TriggerArgs) (bo
 			return false, errors.ErrInvalidArgument
 		}
 
-		_, ok, err := p.node.proxy.AskNode(ctx, args.UID, p.node.opts.name, p.node.opts.id)
+		_, ok, err := p.node.proxy.AskNode(ctx, args.UID, p.node.opts.Load().name, p.node.opts.Load().id)
 		if err != nil {
 			return false, err
 		}
@@ -33,7 +33,7 @@ func (p *provider) Trigger(ctx context.Context, args *transport.TriggerArgs) (bo
 		}
 	case cluster.Disconnect:
 		if args.UID > 0 {
-			_, ok, err := p.node.proxy.AskNode(ctx, args.UID, p.node.opts.name, p.node.opts.id)
+			_, ok, err := p.node.proxy.AskNode(ctx, args.UID, p.node.opts.Load().name, p.node.opts.Load().id)
 			if err != nil {
 				return false, err
 			}
@@ -44,7 +44,7 @@ func (p *provider) Trigger(ctx context.Context, args *transport.TriggerArgs) (bo
 		}
 	}
 
-	p.node.trigger.trigger(evt, args.GID, args.CID, args.UID)
+	p.node.trigger.trigger(evt, args.GID, args.CID, args.UID, args.Devices)
 
 	return false, nil
 }
@@ -63,7 +63,7 @@ func (p *provider) Deliver(ctx context.Context, args *transport.DeliverArgs) (bo
 			return false, errors.ErrInvalidArgument
 		}
 
-		_, ok, err := p.node.proxy.AskNode(ctx, args.UID, p.node.opts.name, p.node.opts.id)
+		_, ok, err := p.node.proxy.AskNode(ctx, args.UID, p.node.opts.Load().name, p.node.opts.Load().id)
 		if err != nil {
 			return false, err
 		}
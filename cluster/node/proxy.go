@@ -16,23 +16,24 @@ type Proxy struct {
 
 func newProxy(node *Node) *Proxy {
 	return &Proxy{node: node, link: link.NewLink(&link.Options{
-		NID:         node.opts.id,
-		Codec:       node.opts.codec,
-		Locator:     node.opts.locator,
-		Registry:    node.opts.registry,
-		Encryptor:   node.opts.encryptor,
-		Transporter: node.opts.transporter,
+		NID:         node.opts.Load().id,
+		Codec:       node.opts.Load().codec,
+		Locator:     node.opts.Load().locator,
+		Registry:    node.opts.Load().registry,
+		Encryptor:   node.opts.Load().encryptor,
+		Transporter: node.opts.Load().transporter,
+		MultiConn:   node.opts.Load().multiConn,
 	})}
 }
 
 // GetID 获取当前节点ID
 func (p *Proxy) GetID() string {
-	return p.node.opts.id
+	return p.node.opts.Load().id
 }
 
 // GetName 获取当前节点名称
 func (p *Proxy) GetName() string {
-	return p.node.opts.name
+	return p.node.opts.Load().name
 }
 
 // GetState 获取当前节点状态
@@ -90,7 +91,7 @@ func (p *Proxy) AddHookListener(hook cluster.Hook, handler HookHandler) {
 // 直连模式: 	direct://127.0.0.1:8011
 // 服务发现模式: 	discovery://service_name
 func (p *Proxy) NewServiceClient(target string) (transport.ServiceClient, error) {
-	return p.node.opts.transporter.NewServiceClient(target)
+	return p.node.opts.Load().transporter.NewServiceClient(target)
 }
 
 // BindGate 绑定网关
@@ -110,7 +111,7 @@ func (p *Proxy) BindNode(ctx context.Context, uid int64, nameAndNID ...string) e
 	if len(nameAndNID) >= 2 && nameAndNID[0] != "" && nameAndNID[1] != "" {
 		return p.link.BindNode(ctx, uid, nameAndNID[0], nameAndNID[1])
 	} else {
-		return p.link.BindNode(ctx, uid, p.node.opts.name, p.node.opts.id)
+		return p.link.BindNode(ctx, uid, p.node.opts.Load().name, p.node.opts.Load().id)
 	}
 }
 
@@ -121,7 +122,7 @@ func (p *Proxy) UnbindNode(ctx context.Context, uid int64, nameAndNID ...string)
 	if len(nameAndNID) >= 2 && nameAndNID[0] != "" && nameAndNID[1] != "" {
 		return p.link.UnbindNode(ctx, uid, nameAndNID[0], nameAndNID[1])
 	} else {
-		return p.link.UnbindNode(ctx, uid, p.node.opts.name, p.node.opts.id)
+		return p.link.UnbindNode(ctx, uid, p.node.opts.Load().name, p.node.opts.Load().id)
 	}
 }
 
@@ -210,6 +211,17 @@ func (p *Proxy) Disconnect(ctx context.Context, args *cluster.DisconnectArgs) er
 	return p.link.Disconnect(ctx, args)
 }
 
+// JoinGroup 将用户加入分组，分组可作为推送消息的会话目标
+// 分组关系仅保存在用户所在网关的会话中，用户解绑或断开全部连接后将自动退出所有分组，重连后需重新加入
+func (p *Proxy) JoinGroup(ctx context.Context, args *cluster.GroupArgs) (int64, error) {
+	return p.link.JoinGroup(ctx, args)
+}
+
+// LeaveGroup 将用户移出分组
+func (p *Proxy) LeaveGroup(ctx context.Context, args *cluster.GroupArgs) (int64, error) {
+	return p.link.LeaveGroup(ctx, args)
+}
+
 // Invoke 调用函数（线程安全）
 func (p *Proxy) Invoke(fn func()) {
 	p.node.fnChan <- fn
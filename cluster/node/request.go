@@ -45,6 +45,11 @@ func (r *request) UID() int64 {
 	return r.uid
 }
 
+// Devices 获取用户在网关上剩余的连接数，仅多端登录模式下的断开连接事件有效
+func (r *request) Devices() int32 {
+	return 0
+}
+
 // Seq 获取消息序列号
 func (r *request) Seq() int32 {
 	return r.message.Seq
@@ -73,16 +78,16 @@ func (r *request) Parse(v interface{}) error {
 		return nil
 	}
 
-	if r.gid != "" && r.node.opts.encryptor != nil {
-		data, err := r.node.opts.encryptor.Decrypt(msg)
+	if r.gid != "" && r.node.opts.Load().encryptor != nil {
+		data, err := r.node.opts.Load().encryptor.Decrypt(msg)
 		if err != nil {
 			return err
 		}
 
-		return r.node.opts.codec.Unmarshal(data, v)
+		return r.node.opts.Load().codec.Unmarshal(data, v)
 	}
 
-	return r.node.opts.codec.Unmarshal(msg, v)
+	return r.node.opts.Load().codec.Unmarshal(msg, v)
 }
 
 // Clone 克隆Context
@@ -25,12 +25,13 @@ func newTrigger(node *Node) *Trigger {
 	}
 }
 
-func (e *Trigger) trigger(kind cluster.Event, gid string, cid, uid int64) {
+func (e *Trigger) trigger(kind cluster.Event, gid string, cid, uid int64, devices int32) {
 	evt := e.evtPool.Get().(*event)
 	evt.kind = kind
 	evt.gid = gid
 	evt.cid = cid
 	evt.uid = uid
+	evt.devices = devices
 	e.evtChan <- evt
 }
 
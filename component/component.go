@@ -7,8 +7,8 @@ type Component interface {
 	Init()
 	// Start 启动组件
 	Start()
-	// Restart 重启组件
-	Restart()
+	// Restart 重启组件，重启失败时组件应保持原有状态继续运行
+	Restart() error
 	// Destroy 销毁组件
 	Destroy()
 }
@@ -26,7 +26,7 @@ func (b *Base) Init() {}
 func (b *Base) Start() {}
 
 // Restart 重启组件
-func (b *Base) Restart() {}
+func (b *Base) Restart() error { return nil }
 
 // Destroy 销毁组件
 func (b *Base) Destroy() {}
@@ -5,7 +5,12 @@ import (
 	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/log"
 	"net/http"
-	_ "net/http/pprof"
+	httppprof "net/http/pprof"
+)
+
+const (
+	defaultAddrKey     = "etc.pprof.addr"
+	defaultLogLevelKey = "etc.pprof.logLevel"
 )
 
 var _ component.Component = &pprof{}
@@ -22,13 +27,29 @@ func (*pprof) Name() string {
 	return "pprof"
 }
 
+// Start 启动pprof服务，使用独立的路由，不暴露注册在http.DefaultServeMux上的其他接口
+// 日志级别管理接口可在运行期间修改日志级别，仅在显式开启时注册
 func (*pprof) Start() {
-	if addr := etc.Get("etc.pprof.addr").String(); addr != "" {
-		go func() {
-			err := http.ListenAndServe(addr, nil)
-			if err != nil {
-				log.Errorf("pprof server start failed: %v", err)
-			}
-		}()
+	addr := etc.Get(defaultAddrKey).String()
+	if addr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", httppprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+
+	if etc.Get(defaultLogLevelKey).Bool() {
+		mux.Handle("/debug/log/level", log.LevelHandler())
 	}
+
+	go func() {
+		err := http.ListenAndServe(addr, mux)
+		if err != nil {
+			log.Errorf("pprof server start failed: %v", err)
+		}
+	}()
 }
@@ -0,0 +1,425 @@
+package config
+
+import (
+	"fmt"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/utils/xconv"
+	"github.com/dobyte/due/v2/utils/xvalidate"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultTag  = "default"  // 默认值标签
+	validateTag = "validate" // 校验规则标签
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+type Binder[T any] struct {
+	c        Configurator
+	pattern  string
+	value    atomic.Pointer[T]
+	rw       sync.RWMutex
+	missing  []string
+	err      error
+	handlers []func(val *T)
+	unwatch  func()
+}
+
+// Bind 绑定配置到结构体
+// 绑定时会先填充default标签声明的默认值，再使用配置值进行覆盖，最后按照validate标签声明的规则进行校验
+// 支持的校验规则：required、min=n、max=n、len=n、in=a|b|c、email、url、mobile、telephone、qq
+// 配置发生变更时会重新构建一个新的结构体，校验通过后原子替换，可通过Load获取最新的配置
+// 绑定使用调用时的全局配置器，通过SetConfigurator替换全局配置器后需重新绑定
+func Bind[T any](pattern string, dest *T) (*Binder[T], error) {
+	return BindWith(globalConfigurator, pattern, dest)
+}
+
+// BindWith 使用指定的配置器绑定配置到结构体，配置器为空时仅填充默认值并进行校验
+func BindWith[T any](c Configurator, pattern string, dest *T) (*Binder[T], error) {
+	if dest == nil {
+		return nil, errors.ErrInvalidPointer
+	}
+
+	if reflect.TypeOf(dest).Elem().Kind() != reflect.Struct {
+		return nil, errors.ErrInvalidPointer
+	}
+
+	b := &Binder[T]{c: c, pattern: pattern}
+
+	val, missing, err := b.populate()
+	if err != nil {
+		return nil, err
+	}
+
+	*dest = *val
+	b.missing = missing
+	b.value.Store(dest)
+
+	if b.c != nil {
+		b.unwatch = b.c.Watch(func(names ...string) {
+			if b.isWatched(names...) {
+				b.reload()
+			}
+		})
+	}
+
+	return b, nil
+}
+
+// Load 获取最新的配置
+func (b *Binder[T]) Load() *T {
+	return b.value.Load()
+}
+
+// Missing 获取配置中缺失的键（缺失的键将使用默认值或零值）
+func (b *Binder[T]) Missing() []string {
+	b.rw.RLock()
+	defer b.rw.RUnlock()
+
+	return b.missing
+}
+
+// Err 获取最近一次重新加载配置时产生的错误
+func (b *Binder[T]) Err() error {
+	b.rw.RLock()
+	defer b.rw.RUnlock()
+
+	return b.err
+}
+
+// OnChange 设置配置变更回调
+func (b *Binder[T]) OnChange(fn func(val *T)) {
+	b.rw.Lock()
+	b.handlers = append(b.handlers, fn)
+	b.rw.Unlock()
+}
+
+// Close 取消配置监听，关闭后不再随配置变更重新加载
+func (b *Binder[T]) Close() {
+	if b.unwatch != nil {
+		b.unwatch()
+	}
+}
+
+// 检测变更的配置文件是否包含绑定的配置
+// 配置文件名本身可能包含“.”，因此以文件名作为匹配规则的前缀进行匹配，与配置读取时的规则保持一致
+func (b *Binder[T]) isWatched(names ...string) bool {
+	for _, name := range names {
+		if b.pattern == name || strings.HasPrefix(b.pattern, name+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 重新加载配置
+func (b *Binder[T]) reload() {
+	val, missing, err := b.populate()
+
+	b.rw.Lock()
+	b.err = err
+	if err != nil {
+		b.rw.Unlock()
+		log.Printf("rebind configure failed: %v", err)
+		return
+	}
+	b.missing = missing
+	handlers := b.handlers
+	b.rw.Unlock()
+
+	b.value.Store(val)
+
+	for _, handler := range handlers {
+		handler(val)
+	}
+}
+
+// 构建并校验结构体
+func (b *Binder[T]) populate() (*T, []string, error) {
+	val := new(T)
+	rv := reflect.ValueOf(val).Elem()
+
+	if err := applyDefaults(rv); err != nil {
+		return nil, nil, err
+	}
+
+	var raw interface{}
+	if b.c != nil && b.c.Has(b.pattern) {
+		v := b.c.Get(b.pattern)
+		if err := v.Scan(val); err != nil {
+			return nil, nil, err
+		}
+		raw = v.Value()
+	}
+
+	bc := &bindChecker{}
+	bc.check(rv, raw, b.pattern)
+
+	if len(bc.invalid) > 0 || len(bc.required) > 0 {
+		texts := make([]string, 0, 2)
+		if len(bc.required) > 0 {
+			texts = append(texts, fmt.Sprintf("missing required keys: %s", strings.Join(bc.required, ", ")))
+		}
+		if len(bc.invalid) > 0 {
+			texts = append(texts, fmt.Sprintf("invalid keys: %s", strings.Join(bc.invalid, ", ")))
+		}
+
+		return nil, nil, errors.NewError(strings.Join(texts, "; "), errors.ErrInvalidConfigValue)
+	}
+
+	return val, bc.missing, nil
+}
+
+// 填充默认值
+func applyDefaults(rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+
+		if def, ok := field.Tag.Lookup(defaultTag); ok && fv.IsZero() {
+			if err := setString(fv, def); err != nil {
+				return errors.NewError(fmt.Sprintf("invalid default value of field %s", field.Name), err)
+			}
+			continue
+		}
+
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Time{}) {
+			if err := applyDefaults(fv); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// 将字符串值设置到字段上
+func setString(fv reflect.Value, s string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		fv.SetBool(xconv.Bool(s))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fv.Type() == durationType {
+			fv.SetInt(int64(xconv.Duration(s)))
+		} else {
+			fv.SetInt(xconv.Int64(s))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(xconv.Uint64(s))
+	case reflect.Float32, reflect.Float64:
+		fv.SetFloat(xconv.Float64(s))
+	case reflect.Slice:
+		if s == "" {
+			return nil
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(s), "[") {
+			return json.Unmarshal([]byte(s), fv.Addr().Interface())
+		}
+
+		items := strings.Split(s, ",")
+		slice := reflect.MakeSlice(fv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setString(slice.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+	default:
+		return json.Unmarshal([]byte(s), fv.Addr().Interface())
+	}
+
+	return nil
+}
+
+type bindChecker struct {
+	missing  []string // 缺失的键
+	required []string // 缺失的必填键
+	invalid  []string // 校验失败的键
+}
+
+// 检测结构体
+func (bc *bindChecker) check(rv reflect.Value, raw interface{}, prefix string) {
+	rt := rv.Type()
+	values, _ := raw.(map[string]interface{})
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		fv := rv.Field(i)
+		sub, exists := lookupKey(values, name)
+
+		if !exists {
+			bc.missing = append(bc.missing, key)
+		}
+
+		if rules, ok := field.Tag.Lookup(validateTag); ok {
+			bc.validate(fv, key, rules, exists)
+		}
+
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Time{}) {
+			bc.check(fv, sub, key)
+		}
+	}
+}
+
+// 校验字段
+func (bc *bindChecker) validate(fv reflect.Value, key string, rules string, exists bool) {
+	isZero := fv.IsZero()
+
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		name, arg, _ := strings.Cut(rule, "=")
+
+		if name == "required" {
+			if isZero {
+				if exists {
+					bc.invalid = append(bc.invalid, key+"(required)")
+				} else {
+					bc.required = append(bc.required, key)
+				}
+				return
+			}
+			continue
+		}
+
+		// 非必填的零值不做校验
+		if isZero {
+			return
+		}
+
+		if !checkRule(fv, name, arg) {
+			bc.invalid = append(bc.invalid, key+"("+rule+")")
+		}
+	}
+}
+
+// 检测校验规则
+func checkRule(fv reflect.Value, name, arg string) bool {
+	switch name {
+	case "min", "max", "len":
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return false
+		}
+
+		switch fv.Kind() {
+		case reflect.String:
+			switch name {
+			case "min":
+				return xvalidate.MinLength(fv.String(), int(n))
+			case "max":
+				return xvalidate.MaxLength(fv.String(), int(n))
+			default:
+				return xvalidate.Length(fv.String(), int(n))
+			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return compare(name, float64(fv.Len()), n)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return compare(name, float64(fv.Int()), n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return compare(name, float64(fv.Uint()), n)
+		case reflect.Float32, reflect.Float64:
+			return compare(name, fv.Float(), n)
+		default:
+			return false
+		}
+	case "in":
+		items := strings.Split(arg, "|")
+		set := reflect.MakeSlice(reflect.SliceOf(fv.Type()), len(items), len(items))
+		for i, item := range items {
+			if err := setString(set.Index(i), item); err != nil {
+				return false
+			}
+		}
+		return xvalidate.In(fv.Interface(), set.Interface())
+	case "email":
+		return fv.Kind() == reflect.String && xvalidate.IsEmail(fv.String())
+	case "url":
+		return fv.Kind() == reflect.String && xvalidate.IsUrl(fv.String())
+	case "mobile":
+		return fv.Kind() == reflect.String && xvalidate.IsMobile(fv.String())
+	case "telephone":
+		return fv.Kind() == reflect.String && xvalidate.IsTelephone(fv.String())
+	case "qq":
+		return fv.Kind() == reflect.String && xvalidate.IsQQ(fv.String())
+	default:
+		return false
+	}
+}
+
+// 比较数值
+func compare(name string, v, n float64) bool {
+	switch name {
+	case "min":
+		return v >= n
+	case "max":
+		return v <= n
+	default:
+		return v == n
+	}
+}
+
+// 获取字段对应的配置键
+func fieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// 查找配置键，优先精确匹配，不存在时忽略大小写进行匹配（与json解码保持一致）
+func lookupKey(values map[string]interface{}, name string) (interface{}, bool) {
+	if values == nil {
+		return nil, false
+	}
+
+	if v, ok := values[name]; ok {
+		return v, true
+	}
+
+	for k, v := range values {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"github.com/dobyte/due/v2/core/value"
+	"github.com/dobyte/due/v2/errors"
 )
 
 var globalConfigurator Configurator
@@ -61,13 +62,13 @@ func Match(patterns ...string) Matcher {
 	return globalConfigurator.Match(patterns...)
 }
 
-// Watch 设置监听回调
-func Watch(cb WatchCallbackFunc, names ...string) {
+// Watch 设置监听回调，返回取消监听的函数
+func Watch(cb WatchCallbackFunc, names ...string) (unwatch func()) {
 	if globalConfigurator == nil {
-		return
+		return func() {}
 	}
 
-	globalConfigurator.Watch(cb, names...)
+	return globalConfigurator.Watch(cb, names...)
 }
 
 // Load 加载配置项
@@ -88,6 +89,62 @@ func Store(ctx context.Context, source string, file string, content interface{},
 	return globalConfigurator.Store(ctx, source, file, content, override...)
 }
 
+// Revisions 获取配置项的修订版本列表
+func Revisions(ctx context.Context, source string, file string) ([]*Revision, error) {
+	if globalConfigurator == nil {
+		return nil, nil
+	}
+
+	revisioner, ok := globalConfigurator.(Revisioner)
+	if !ok {
+		return nil, errors.ErrNotSupportRevision
+	}
+
+	return revisioner.Revisions(ctx, source, file)
+}
+
+// Diff 比较配置项的两个修订版本
+func Diff(ctx context.Context, source string, file string, from, to int64) (string, error) {
+	if globalConfigurator == nil {
+		return "", nil
+	}
+
+	revisioner, ok := globalConfigurator.(Revisioner)
+	if !ok {
+		return "", errors.ErrNotSupportRevision
+	}
+
+	return revisioner.Diff(ctx, source, file, from, to)
+}
+
+// Rollback 回滚配置项至某个修订版本
+func Rollback(ctx context.Context, source string, file string, version int64) error {
+	if globalConfigurator == nil {
+		return nil
+	}
+
+	revisioner, ok := globalConfigurator.(Revisioner)
+	if !ok {
+		return errors.ErrNotSupportRevision
+	}
+
+	return revisioner.Rollback(ctx, source, file, version)
+}
+
+// Reload 重新加载配置
+func Reload() error {
+	if globalConfigurator == nil {
+		return nil
+	}
+
+	reloader, ok := globalConfigurator.(Reloader)
+	if !ok {
+		return errors.ErrNotSupportReload
+	}
+
+	return reloader.Reload()
+}
+
 // Close 关闭配置监听
 func Close() {
 	if globalConfigurator != nil {
@@ -4,6 +4,10 @@ import (
 	"context"
 	"github.com/dobyte/due/v2/config"
 	"github.com/dobyte/due/v2/config/file"
+	"github.com/dobyte/due/v2/errors"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -73,3 +77,106 @@ func BenchmarkGet(b *testing.B) {
 		config.Get("config").Value()
 	}
 }
+
+func TestBind(t *testing.T) {
+	type Item struct {
+		Name  string `json:"name" validate:"required"`
+		Level int    `json:"level" default:"1" validate:"min=1,max=100"`
+		Kind  string `json:"kind" default:"weapon" validate:"in=weapon|armor"`
+	}
+
+	if err := config.Set("config.item", map[string]interface{}{"name": "sword"}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := config.Bind("config.item", &Item{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := b.Load()
+	if item.Name != "sword" || item.Level != 1 || item.Kind != "weapon" {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+
+	if missing := b.Missing(); !reflect.DeepEqual(missing, []string{"config.item.level", "config.item.kind"}) {
+		t.Fatalf("unexpected missing keys: %v", missing)
+	}
+
+	if err = config.Set("config.invalid", map[string]interface{}{"name": "sword", "level": 200}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = config.Bind("config.invalid", &Item{}); errors.Next(err) != errors.ErrInvalidConfigValue {
+		t.Fatalf("expected invalid config value, got %v", err)
+	}
+}
+
+func TestBinder_Close(t *testing.T) {
+	type App struct {
+		Name string `json:"name"`
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.json"), []byte(`{"name":"due"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := config.NewConfigurator(config.WithSources(file.NewSource(file.WithPath(dir))))
+	defer c.Close()
+
+	b, err := config.BindWith(c, "app", &App{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chChange := make(chan *App, 1)
+	b.OnChange(func(val *App) { chChange <- val })
+
+	if err = c.(config.Reloader).Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case val := <-chChange:
+		if val.Name != "due" {
+			t.Fatalf("unexpected app: %+v", val)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("binder is not reloaded")
+	}
+
+	b.Close()
+
+	if err = c.(config.Reloader).Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case val := <-chChange:
+		t.Fatalf("binder is reloaded after close: %+v", val)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestDiffContent(t *testing.T) {
+	diff := config.DiffContent([]byte("a = 1\nb = 2\n"), []byte("a = 1\nb = 3\nc = 4\n"))
+
+	if expected := "  a = 1\n- b = 2\n+ b = 3\n+ c = 4\n"; diff != expected {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+}
+
+func TestRollback_InvalidFile(t *testing.T) {
+	ctx := context.Background()
+
+	for _, filename := range []string{"../config.json", "/etc/config.json", ""} {
+		if err := config.Rollback(ctx, file.Name, filename, 1); err != errors.ErrInvalidConfigFile {
+			t.Fatalf("unexpected error for %q: %v", filename, err)
+		}
+
+		if _, err := config.Revisions(ctx, file.Name, filename); err != errors.ErrInvalidConfigFile {
+			t.Fatalf("unexpected error for %q: %v", filename, err)
+		}
+	}
+}
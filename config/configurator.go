@@ -27,8 +27,8 @@ type Configurator interface {
 	Set(pattern string, value interface{}) error
 	// Match 匹配多个规则
 	Match(patterns ...string) Matcher
-	// Watch 设置监听回调
-	Watch(cb WatchCallbackFunc, names ...string)
+	// Watch 设置监听回调，返回取消监听的函数
+	Watch(cb WatchCallbackFunc, names ...string) (unwatch func())
 	// Load 加载配置项
 	Load(ctx context.Context, source string, file ...string) ([]*Configuration, error)
 	// Store 保存配置项
@@ -37,6 +37,22 @@ type Configurator interface {
 	Close()
 }
 
+// Reloader 支持重新加载的配置器，为可选接口
+type Reloader interface {
+	// Reload 重新加载配置
+	Reload() error
+}
+
+// Revisioner 支持版本管理的配置器，为可选接口
+type Revisioner interface {
+	// Revisions 获取配置项的修订版本列表
+	Revisions(ctx context.Context, source string, file string) ([]*Revision, error)
+	// Diff 比较配置项的两个修订版本
+	Diff(ctx context.Context, source string, file string, from, to int64) (string, error)
+	// Rollback 回滚配置项至某个修订版本
+	Rollback(ctx context.Context, source string, file string, version int64) error
+}
+
 type WatchCallbackFunc func(names ...string)
 
 type watcher struct {
@@ -45,18 +61,27 @@ type watcher struct {
 }
 
 type defaultConfigurator struct {
-	opts     *options
-	ctx      context.Context
-	cancel   context.CancelFunc
-	sources  map[string]Source
-	mu       sync.Mutex
-	idx      int64
-	values   [2]map[string]interface{}
-	rw       sync.RWMutex
-	watchers []*watcher
+	opts      *options
+	ctx       context.Context
+	cancel    context.CancelFunc
+	sources   map[string]Source
+	mu        sync.Mutex
+	layers    []map[string]interface{} // 各配置源的配置，按配置源顺序合并，排在后面的配置源优先级更高
+	idx       int64
+	values    [2]map[string]interface{}
+	rw        sync.RWMutex
+	watchers  []*watcher
+	overrides []*override // 通过Set设置的覆盖值，按设置顺序应用
+}
+
+type override struct {
+	pattern string
+	value   interface{}
 }
 
 var _ Configurator = &defaultConfigurator{}
+var _ Reloader = &defaultConfigurator{}
+var _ Revisioner = &defaultConfigurator{}
 
 func NewConfigurator(opts ...Option) Configurator {
 	o := defaultOptions()
@@ -81,11 +106,49 @@ func (c *defaultConfigurator) init() {
 		c.sources[s.Name()] = s
 	}
 
-	values := make(map[string]interface{})
-	for _, s := range c.opts.sources {
-		cs, err := s.Load(c.ctx)
-		if err != nil {
-			log.Printf("load configure failed: %v", err)
+	layers, _ := c.loadSources()
+
+	c.layers = layers
+	c.store(mergeLayers(layers))
+}
+
+// 合并各配置源的配置，并重新应用通过Set设置的覆盖值，调用方需持有c.mu
+func (c *defaultConfigurator) merge() map[string]interface{} {
+	values := mergeLayers(c.layers)
+	if len(c.overrides) == 0 {
+		return values
+	}
+
+	// 合并后的配置与各配置源共享子节点，需深拷贝后再应用覆盖值
+	dst, err := deepCopy(values)
+	if err != nil {
+		log.Printf("copy configure failed: %v", err)
+		return values
+	}
+
+	for _, o := range c.overrides {
+		if err = setValue(dst, o.pattern, o.value); err != nil {
+			log.Printf("apply configure override failed: %v", err)
+		}
+	}
+
+	return dst
+}
+
+// 加载所有配置源
+func (c *defaultConfigurator) loadSources() ([]map[string]interface{}, error) {
+	var (
+		err    error
+		layers = make([]map[string]interface{}, len(c.opts.sources))
+	)
+
+	for i, s := range c.opts.sources {
+		layers[i] = make(map[string]interface{})
+
+		cs, e := s.Load(c.ctx)
+		if e != nil {
+			log.Printf("load configure failed: %v", e)
+			err = e
 			continue
 		}
 
@@ -102,11 +165,38 @@ func (c *defaultConfigurator) init() {
 				continue
 			}
 
-			values[cc.Name] = v
+			layers[i][cc.Name] = v
 		}
 	}
 
-	c.store(values)
+	return layers, err
+}
+
+// Reload 重新加载配置
+// 重新从所有配置源中加载配置，并通知所有监听器
+func (c *defaultConfigurator) Reload() error {
+	layers, err := c.loadSources()
+
+	values := mergeLayers(layers)
+	if err != nil && len(values) == 0 {
+		return err
+	}
+
+	c.mu.Lock()
+	c.layers = layers
+	c.store(c.merge())
+	c.mu.Unlock()
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	if len(names) > 0 {
+		go c.notify(names...)
+	}
+
+	return err
 }
 
 // 保存配置
@@ -123,8 +213,11 @@ func (c *defaultConfigurator) load() map[string]interface{} {
 
 // 拷贝配置
 func (c *defaultConfigurator) copy() (map[string]interface{}, error) {
-	values := c.load()
+	return deepCopy(c.load())
+}
 
+// 深拷贝配置
+func deepCopy(values map[string]interface{}) (map[string]interface{}, error) {
 	dst := make(map[string]interface{})
 
 	err := copier.CopyWithOption(&dst, values, copier.Option{
@@ -139,7 +232,9 @@ func (c *defaultConfigurator) copy() (map[string]interface{}, error) {
 
 // 监听配置源变化
 func (c *defaultConfigurator) watch() {
-	for _, s := range c.opts.sources {
+	for i, s := range c.opts.sources {
+		i := i
+
 		w, err := s.Watch(c.ctx)
 		if err != nil {
 			log.Printf("watching configure change failed: %v", err)
@@ -180,17 +275,12 @@ func (c *defaultConfigurator) watch() {
 					c.mu.Lock()
 					defer c.mu.Unlock()
 
-					dst, err := c.copy()
-					if err != nil {
-						return
-					}
-
-					err = mergo.Merge(&dst, values, mergo.WithOverride)
-					if err != nil {
-						return
+					// 整体替换配置文件内容，确保已删除的配置项同步移除
+					for name, v := range values {
+						c.layers[i][name] = v
 					}
 
-					c.store(dst)
+					c.store(c.merge())
 				}()
 
 				if len(names) > 0 {
@@ -252,7 +342,7 @@ func (c *defaultConfigurator) doHas(pattern string) bool {
 	for _, key := range keys {
 		switch vs := node.(type) {
 		case map[string]interface{}:
-			if v, ok := vs[key]; ok {
+			if v, ok := lookupKey(vs, key); ok {
 				node = v
 			} else {
 				found = false
@@ -310,7 +400,7 @@ func (c *defaultConfigurator) doGet(pattern string) (value.Value, bool) {
 	for _, key := range keys {
 		switch vs := node.(type) {
 		case map[string]interface{}:
-			if v, ok := vs[key]; ok {
+			if v, ok := lookupKey(vs, key); ok {
 				node = v
 			} else {
 				found = false
@@ -342,12 +432,8 @@ NOTFOUND:
 }
 
 // Set 设置配置值
+// 设置的配置值将作为优先级最高的覆盖层保留，配置源重新加载或发生变更后依然生效
 func (c *defaultConfigurator) Set(pattern string, value interface{}) error {
-	var (
-		keys = strings.Split(pattern, ".")
-		node interface{}
-	)
-
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -356,7 +442,31 @@ func (c *defaultConfigurator) Set(pattern string, value interface{}) error {
 		return err
 	}
 
-	keys = reviseKeys(keys, values)
+	if err = setValue(values, pattern, value); err != nil {
+		return err
+	}
+
+	c.store(values)
+
+	for i, o := range c.overrides {
+		if o.pattern == pattern {
+			c.overrides = append(c.overrides[:i], c.overrides[i+1:]...)
+			break
+		}
+	}
+
+	c.overrides = append(c.overrides, &override{pattern: pattern, value: value})
+
+	return nil
+}
+
+// 按层级设置配置值
+func setValue(values map[string]interface{}, pattern string, value interface{}) error {
+	var (
+		keys = reviseKeys(strings.Split(pattern, "."), values)
+		node interface{}
+	)
+
 	node = values
 	for i, key := range keys {
 		switch vs := node.(type) {
@@ -440,13 +550,11 @@ func (c *defaultConfigurator) Set(pattern string, value interface{}) error {
 		}
 	}
 
-	c.store(values)
-
 	return nil
 }
 
-// Watch 设置监听回调
-func (c *defaultConfigurator) Watch(cb WatchCallbackFunc, names ...string) {
+// Watch 设置监听回调，返回取消监听的函数
+func (c *defaultConfigurator) Watch(cb WatchCallbackFunc, names ...string) (unwatch func()) {
 	w := &watcher{}
 	w.names = make(map[string]struct{}, len(names))
 	w.callback = cb
@@ -458,6 +566,18 @@ func (c *defaultConfigurator) Watch(cb WatchCallbackFunc, names ...string) {
 	c.rw.Lock()
 	c.watchers = append(c.watchers, w)
 	c.rw.Unlock()
+
+	return func() {
+		c.rw.Lock()
+		defer c.rw.Unlock()
+
+		for i, item := range c.watchers {
+			if item == w {
+				c.watchers = append(c.watchers[:i], c.watchers[i+1:]...)
+				break
+			}
+		}
+	}
 }
 
 // Load 加载配置项
@@ -485,6 +605,10 @@ func (c *defaultConfigurator) Store(ctx context.Context, source string, file str
 		return errors.ErrInvalidConfigContent
 	}
 
+	if err := checkFile(file); err != nil {
+		return err
+	}
+
 	s, ok := c.sources[source]
 	if !ok {
 		return errors.ErrNotFoundConfigSource
@@ -545,6 +669,120 @@ func (c *defaultConfigurator) Store(ctx context.Context, source string, file str
 	return s.Store(ctx, file, buf)
 }
 
+// 按顺序合并各配置源的配置
+func mergeLayers(layers []map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	for _, layer := range layers {
+		mergeValues(values, layer)
+	}
+
+	return values
+}
+
+// 合并配置值，不会修改源配置
+// 当目标中不存在完全一致的键时，将忽略大小写进行匹配，以便环境变量等无法区分大小写的配置源能够覆盖驼峰形式的键
+func mergeValues(dst, src map[string]interface{}) {
+	for key, sv := range src {
+		if _, ok := dst[key]; !ok {
+			for k := range dst {
+				if strings.EqualFold(k, key) {
+					key = k
+					break
+				}
+			}
+		}
+
+		if sm, ok := sv.(map[string]interface{}); ok {
+			if dm, ok := dst[key].(map[string]interface{}); ok {
+				m := make(map[string]interface{}, len(dm)+len(sm))
+				mergeValues(m, dm)
+				mergeValues(m, sm)
+				dst[key] = m
+				continue
+			}
+		}
+
+		dst[key] = sv
+	}
+}
+
+// Revisions 获取配置项的修订版本列表
+func (c *defaultConfigurator) Revisions(ctx context.Context, source string, file string) ([]*Revision, error) {
+	if err := checkFile(file); err != nil {
+		return nil, err
+	}
+
+	r, err := c.reviser(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Revisions(ctx, file)
+}
+
+// Diff 比较配置项的两个修订版本
+func (c *defaultConfigurator) Diff(ctx context.Context, source string, file string, from, to int64) (string, error) {
+	if err := checkFile(file); err != nil {
+		return "", err
+	}
+
+	r, err := c.reviser(source)
+	if err != nil {
+		return "", err
+	}
+
+	a, err := r.Revision(ctx, file, from)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := r.Revision(ctx, file, to)
+	if err != nil {
+		return "", err
+	}
+
+	return DiffContent(a.Content, b.Content), nil
+}
+
+// Rollback 回滚配置项至某个修订版本
+func (c *defaultConfigurator) Rollback(ctx context.Context, source string, file string, version int64) error {
+	if err := checkFile(file); err != nil {
+		return err
+	}
+
+	r, err := c.reviser(source)
+	if err != nil {
+		return err
+	}
+
+	return r.Rollback(ctx, file, version)
+}
+
+// 校验配置文件名，仅允许配置源内的相对路径，防止路径穿越
+func checkFile(file string) error {
+	if !filepath.IsLocal(file) {
+		return errors.ErrInvalidConfigFile
+	}
+
+	return nil
+}
+
+// 获取支持版本管理的配置源
+func (c *defaultConfigurator) reviser(source string) (Reviser, error) {
+	s, ok := c.sources[source]
+	if !ok {
+		return nil, errors.ErrNotFoundConfigSource
+	}
+
+	r, ok := s.(Reviser)
+	if !ok {
+		return nil, errors.ErrNotSupportRevision
+	}
+
+	return r, nil
+}
+
 func reviseKeys(keys []string, values map[string]interface{}) []string {
 	for i := 1; i < len(keys); i++ {
 		key := strings.Join(keys[:i+1], ".")
@@ -8,15 +8,17 @@ import (
 )
 
 const (
-	defaultAddr = "127.0.0.1:8500"
-	defaultPath = "config"
-	defaultMode = config.ReadOnly
+	defaultAddr    = "127.0.0.1:8500"
+	defaultPath    = "config"
+	defaultMode    = config.ReadOnly
+	defaultHistory = 0
 )
 
 const (
-	defaultAddrKey = "etc.config.consul.addr"
-	defaultPathKey = "etc.config.consul.path"
-	defaultModeKey = "etc.config.consul.mode"
+	defaultAddrKey    = "etc.config.consul.addr"
+	defaultPathKey    = "etc.config.consul.path"
+	defaultModeKey    = "etc.config.consul.mode"
+	defaultHistoryKey = "etc.config.consul.history"
 )
 
 type Option func(o *options)
@@ -41,14 +43,19 @@ type options struct {
 	// 读写模式
 	// 支持read-only、write-only和read-write三种模式，默认为read-only模式
 	mode config.Mode
+
+	// 保留的历史版本数量
+	// 历史版本以ModifyIndex作为版本号保存在与配置路径同级的.history后缀路径中，默认为0，即不保留历史版本
+	history int
 }
 
 func defaultOptions() *options {
 	return &options{
-		ctx:  context.Background(),
-		addr: etc.Get(defaultAddrKey, defaultAddr).String(),
-		path: etc.Get(defaultPathKey, defaultPath).String(),
-		mode: config.Mode(etc.Get(defaultModeKey, defaultMode).String()),
+		ctx:     context.Background(),
+		addr:    etc.Get(defaultAddrKey, defaultAddr).String(),
+		path:    etc.Get(defaultPathKey, defaultPath).String(),
+		mode:    config.Mode(etc.Get(defaultModeKey, defaultMode).String()),
+		history: etc.Get(defaultHistoryKey, defaultHistory).Int(),
 	}
 }
 
@@ -71,3 +78,8 @@ func WithContext(ctx context.Context) Option {
 func WithMode(mode config.Mode) Option {
 	return func(o *options) { o.mode = mode }
 }
+
+// WithHistory 设置保留的历史版本数量
+func WithHistory(history int) Option {
+	return func(o *options) { o.history = history }
+}
@@ -3,18 +3,26 @@ package consul
 import (
 	"context"
 	"github.com/dobyte/due/v2/config"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/hashicorp/consul/api"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 const Name = "consul"
 
+const historySuffix = ".history"
+
 type Source struct {
 	err  error
 	opts *options
 }
 
+var _ config.Reviser = &Source{}
+
 func NewSource(opts ...Option) config.Source {
 	o := defaultOptions()
 	for _, opt := range opts {
@@ -84,12 +92,12 @@ func (s *Source) Load(ctx context.Context, file ...string) ([]*config.Configurat
 
 // Store 保存配置项
 func (s *Source) Store(ctx context.Context, file string, content []byte) error {
-	var key string
+	key := s.key(file)
 
-	if s.opts.path != "" {
-		key = s.opts.path + "/" + strings.TrimPrefix(file, "/")
-	} else {
-		key = strings.TrimPrefix(file, "/")
+	if s.opts.history > 0 {
+		if err := s.archive(key); err != nil {
+			return err
+		}
 	}
 
 	_, err := s.opts.client.KV().Put(&api.KVPair{
@@ -100,6 +108,137 @@ func (s *Source) Store(ctx context.Context, file string, content []byte) error {
 	return err
 }
 
+// Revisions 获取配置文件的修订版本列表
+func (s *Source) Revisions(ctx context.Context, file string) ([]*config.Revision, error) {
+	key := s.key(file)
+
+	current, _, err := s.opts.client.KV().Get(key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	kvs, _, err := s.opts.client.KV().List(s.historyKey(key)+"/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*config.Revision, 0, len(kvs)+1)
+
+	if current != nil {
+		revisions = append(revisions, &config.Revision{Version: int64(current.ModifyIndex), Current: true, Content: current.Value})
+	}
+
+	for _, kv := range kvs {
+		version, err := strconv.ParseInt(path.Base(kv.Key), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		revisions = append(revisions, &config.Revision{Version: version, Content: kv.Value})
+	}
+
+	sort.SliceStable(revisions, func(i, j int) bool { return revisions[i].Version > revisions[j].Version })
+
+	return revisions, nil
+}
+
+// Revision 获取配置文件的某个修订版本
+func (s *Source) Revision(ctx context.Context, file string, version int64) (*config.Revision, error) {
+	key := s.key(file)
+
+	current, _, err := s.opts.client.KV().Get(key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if current != nil && int64(current.ModifyIndex) == version {
+		return &config.Revision{Version: version, Current: true, Content: current.Value}, nil
+	}
+
+	kv, _, err := s.opts.client.KV().Get(s.historyKey(key)+"/"+strconv.FormatInt(version, 10), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if kv == nil {
+		return nil, errors.ErrNotFoundRevision
+	}
+
+	return &config.Revision{Version: version, Content: kv.Value}, nil
+}
+
+// Rollback 回滚配置文件至某个修订版本
+func (s *Source) Rollback(ctx context.Context, file string, version int64) error {
+	revision, err := s.Revision(ctx, file, version)
+	if err != nil {
+		return err
+	}
+
+	if revision.Current {
+		return nil
+	}
+
+	return s.Store(ctx, file, revision.Content)
+}
+
+// 归档当前版本，并清理超出保留数量的历史版本
+func (s *Source) archive(key string) error {
+	current, _, err := s.opts.client.KV().Get(key, nil)
+	if err != nil || current == nil {
+		return err
+	}
+
+	prefix := s.historyKey(key) + "/"
+
+	_, err = s.opts.client.KV().Put(&api.KVPair{
+		Key:   prefix + strconv.FormatUint(current.ModifyIndex, 10),
+		Value: current.Value,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	keys, _, err := s.opts.client.KV().Keys(prefix, "", nil)
+	if err != nil {
+		return err
+	}
+
+	versions := make([]int64, 0, len(keys))
+	for _, k := range keys {
+		if version, err := strconv.ParseInt(path.Base(k), 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+	for i := s.opts.history; i < len(versions); i++ {
+		if _, err = s.opts.client.KV().Delete(prefix+strconv.FormatInt(versions[i], 10), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 获取配置键
+func (s *Source) key(file string) string {
+	if s.opts.path != "" {
+		return s.opts.path + "/" + strings.TrimPrefix(file, "/")
+	}
+
+	return strings.TrimPrefix(file, "/")
+}
+
+// 获取历史版本键前缀，历史版本保存在配置路径之外，避免被加载及监听
+func (s *Source) historyKey(key string) string {
+	if s.opts.path != "" {
+		return s.opts.path + historySuffix + strings.TrimPrefix(key, s.opts.path)
+	}
+
+	return historySuffix + "/" + key
+}
+
 // Watch 监听配置项
 func (s *Source) Watch(ctx context.Context) (config.Watcher, error) {
 	return newWatcher(ctx, s)
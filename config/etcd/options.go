@@ -19,6 +19,7 @@ const (
 	defaultDialTimeout = "5s"
 	defaultPath        = "/config"
 	defaultMode        = config.ReadOnly
+	defaultHistory     = 0
 )
 
 const (
@@ -26,6 +27,7 @@ const (
 	defaultDialTimeoutKey = "etc.config.etcd.dialTimeout"
 	defaultPathKey        = "etc.config.etcd.path"
 	defaultModeKey        = "etc.config.etcd.mode"
+	defaultHistoryKey     = "etc.config.etcd.history"
 )
 
 type Option func(o *options)
@@ -50,6 +52,10 @@ type options struct {
 	// 读写模式
 	// 支持read-only、write-only和read-write三种模式，默认为read-only模式
 	mode config.Mode
+
+	// 查询的历史版本数量
+	// 历史版本基于etcd的修订版本实现，受etcd压缩策略影响，默认为0，即不保留历史版本
+	history int
 }
 
 func defaultOptions() *options {
@@ -58,6 +64,7 @@ func defaultOptions() *options {
 		dialTimeout: etc.Get(defaultDialTimeoutKey, defaultDialTimeout).Duration(),
 		path:        etc.Get(defaultPathKey, defaultPath).String(),
 		mode:        config.Mode(etc.Get(defaultModeKey, defaultMode).String()),
+		history:     etc.Get(defaultHistoryKey, defaultHistory).Int(),
 	}
 }
 
@@ -85,3 +92,8 @@ func WithPath(path string) Option {
 func WithMode(mode config.Mode) Option {
 	return func(o *options) { o.mode = mode }
 }
+
+// WithHistory 设置查询的历史版本数量
+func WithHistory(history int) Option {
+	return func(o *options) { o.history = history }
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/dobyte/due/v2/config"
 	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/utils/xconv"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/v3"
 	"path/filepath"
 	"strings"
@@ -19,6 +20,8 @@ type Source struct {
 	builtin bool
 }
 
+var _ config.Reviser = &Source{}
+
 func NewSource(opts ...Option) config.Source {
 	o := defaultOptions()
 	for _, opt := range opts {
@@ -101,6 +104,96 @@ func (s *Source) Store(ctx context.Context, file string, content []byte) error {
 	return err
 }
 
+// Revisions 获取配置文件的修订版本列表
+// 基于etcd的修订版本实现，已被压缩的修订版本将无法查询
+func (s *Source) Revisions(ctx context.Context, file string) ([]*config.Revision, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var (
+		key       = s.opts.path + "/" + strings.TrimPrefix(file, "/")
+		revisions = make([]*config.Revision, 0, s.opts.history+1)
+		opts      []clientv3.OpOption
+	)
+
+	for len(revisions) <= s.opts.history {
+		res, err := s.opts.client.Get(ctx, key, opts...)
+		if err != nil {
+			if errors.Is(err, rpctypes.ErrCompacted) {
+				break
+			}
+			return nil, err
+		}
+
+		if len(res.Kvs) == 0 {
+			break
+		}
+
+		kv := res.Kvs[0]
+
+		revisions = append(revisions, &config.Revision{
+			Version: kv.ModRevision,
+			Current: len(revisions) == 0,
+			Content: kv.Value,
+		})
+
+		if kv.Version <= 1 {
+			break
+		}
+
+		opts = []clientv3.OpOption{clientv3.WithRev(kv.ModRevision - 1)}
+	}
+
+	return revisions, nil
+}
+
+// Revision 获取配置文件的某个修订版本
+func (s *Source) Revision(ctx context.Context, file string, version int64) (*config.Revision, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	key := s.opts.path + "/" + strings.TrimPrefix(file, "/")
+
+	current, err := s.opts.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(current.Kvs) > 0 && current.Kvs[0].ModRevision == version {
+		return &config.Revision{Version: version, Current: true, Content: current.Kvs[0].Value}, nil
+	}
+
+	res, err := s.opts.client.Get(ctx, key, clientv3.WithRev(version))
+	if err != nil {
+		if errors.Is(err, rpctypes.ErrCompacted) {
+			return nil, errors.ErrNotFoundRevision
+		}
+		return nil, err
+	}
+
+	if len(res.Kvs) == 0 || res.Kvs[0].ModRevision != version {
+		return nil, errors.ErrNotFoundRevision
+	}
+
+	return &config.Revision{Version: version, Content: res.Kvs[0].Value}, nil
+}
+
+// Rollback 回滚配置文件至某个修订版本
+func (s *Source) Rollback(ctx context.Context, file string, version int64) error {
+	revision, err := s.Revision(ctx, file, version)
+	if err != nil {
+		return err
+	}
+
+	if revision.Current {
+		return nil
+	}
+
+	return s.Store(ctx, file, revision.Content)
+}
+
 // Watch 监听配置项
 func (s *Source) Watch(ctx context.Context) (config.Watcher, error) {
 	if s.err != nil {
@@ -9,20 +9,36 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 const Name = "file"
 
+const historySuffix = ".history"
+
 type Source struct {
-	path string
-	mode config.Mode
+	path    string
+	mode    config.Mode
+	history int
 }
 
-var _ config.Source = &Source{}
+var (
+	_ config.Source  = &Source{}
+	_ config.Reviser = &Source{}
+)
+
+// NewSource 新建文件配置源
+// history为保留的历史版本数量，历史版本保存在与配置目录同级的.history后缀目录中；为0时不保留历史版本
+func NewSource(path string, mode config.Mode, history ...int) *Source {
+	s := &Source{path: strings.TrimSuffix(path, "/"), mode: mode}
 
-func NewSource(path string, mode config.Mode) *Source {
-	return &Source{path: strings.TrimSuffix(path, "/"), mode: mode}
+	if len(history) > 0 && history[0] > 0 {
+		s.history = history[0]
+	}
+
+	return s
 }
 
 // Name 配置源名称
@@ -79,9 +95,162 @@ func (s *Source) Store(ctx context.Context, file string, content []byte) error {
 		return errors.New("the specified file cannot be modified under the file path")
 	}
 
+	if s.history > 0 {
+		if err = s.archive(file); err != nil {
+			return err
+		}
+	}
+
 	return xfile.WriteFile(filepath.Join(s.path, file), content)
 }
 
+// Revisions 获取配置文件的修订版本列表
+func (s *Source) Revisions(ctx context.Context, file string) ([]*config.Revision, error) {
+	revisions := make([]*config.Revision, 0, s.history+1)
+
+	current, err := s.current(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if current != nil {
+		revisions = append(revisions, current)
+	}
+
+	versions, err := s.versions(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		content, err := os.ReadFile(filepath.Join(s.historyDir(file), strconv.FormatInt(version, 10)))
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, &config.Revision{Version: version, Content: content})
+	}
+
+	return revisions, nil
+}
+
+// Revision 获取配置文件的某个修订版本
+func (s *Source) Revision(ctx context.Context, file string, version int64) (*config.Revision, error) {
+	current, err := s.current(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if current != nil && current.Version == version {
+		return current, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(s.historyDir(file), strconv.FormatInt(version, 10)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.ErrNotFoundRevision
+		}
+		return nil, err
+	}
+
+	return &config.Revision{Version: version, Content: content}, nil
+}
+
+// Rollback 回滚配置文件至某个修订版本
+func (s *Source) Rollback(ctx context.Context, file string, version int64) error {
+	revision, err := s.Revision(ctx, file, version)
+	if err != nil {
+		return err
+	}
+
+	if revision.Current {
+		return nil
+	}
+
+	return s.Store(ctx, file, revision.Content)
+}
+
+// 获取当前版本，文件的修改时间作为版本号
+func (s *Source) current(file string) (*config.Revision, error) {
+	path := filepath.Join(s.path, file)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.Revision{Version: info.ModTime().UnixNano(), Current: true, Content: content}, nil
+}
+
+// 归档当前版本，并清理超出保留数量的历史版本
+func (s *Source) archive(file string) error {
+	current, err := s.current(file)
+	if err != nil || current == nil {
+		return err
+	}
+
+	dir := s.historyDir(file)
+
+	if err = xfile.WriteFile(filepath.Join(dir, strconv.FormatInt(current.Version, 10)), current.Content); err != nil {
+		return err
+	}
+
+	versions, err := s.versions(file)
+	if err != nil {
+		return err
+	}
+
+	for i := s.history; i < len(versions); i++ {
+		if err = os.Remove(filepath.Join(dir, strconv.FormatInt(versions[i], 10))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 获取历史版本号列表，按版本号由新到旧排列
+func (s *Source) versions(file string) ([]int64, error) {
+	entries, err := os.ReadDir(s.historyDir(file))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	versions := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		version, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+	return versions, nil
+}
+
+// 获取历史版本目录
+func (s *Source) historyDir(file string) string {
+	return filepath.Join(s.path+historySuffix, file)
+}
+
 // Watch 监听配置变化
 func (s *Source) Watch(ctx context.Context) (config.Watcher, error) {
 	return newWatcher(ctx, s)
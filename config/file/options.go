@@ -6,13 +6,15 @@ import (
 )
 
 const (
-	defaultPath = "./config"
-	defaultMode = config.ReadOnly
+	defaultPath    = "./config"
+	defaultMode    = config.ReadOnly
+	defaultHistory = 0
 )
 
 const (
-	defaultPathKey = "etc.config.file.path"
-	defaultModeKey = "etc.config.file.mode"
+	defaultPathKey    = "etc.config.file.path"
+	defaultModeKey    = "etc.config.file.mode"
+	defaultHistoryKey = "etc.config.file.history"
 )
 
 type Option func(o *options)
@@ -24,12 +26,17 @@ type options struct {
 	// 读写模式
 	// 支持read-only、write-only和read-write三种模式，默认为read-only模式
 	mode config.Mode
+
+	// 保留的历史版本数量
+	// 历史版本保存在与配置目录同级的.history后缀目录中，默认为0，即不保留历史版本
+	history int
 }
 
 func defaultOptions() *options {
 	return &options{
-		path: etc.Get(defaultPathKey, defaultPath).String(),
-		mode: config.Mode(etc.Get(defaultModeKey, defaultMode).String()),
+		path:    etc.Get(defaultPathKey, defaultPath).String(),
+		mode:    config.Mode(etc.Get(defaultModeKey, defaultMode).String()),
+		history: etc.Get(defaultHistoryKey, defaultHistory).Int(),
 	}
 }
 
@@ -42,3 +49,8 @@ func WithPath(path string) Option {
 func WithMode(mode config.Mode) Option {
 	return func(o *options) { o.mode = mode }
 }
+
+// WithHistory 设置保留的历史版本数量
+func WithHistory(history int) Option {
+	return func(o *options) { o.history = history }
+}
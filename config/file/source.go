@@ -22,5 +22,5 @@ func NewSource(opts ...Option) config.Source {
 		log.Fatal("no config file path specified")
 	}
 
-	return core.NewSource(o.path, o.mode)
+	return core.NewSource(o.path, o.mode, o.history)
 }
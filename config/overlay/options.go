@@ -0,0 +1,49 @@
+package overlay
+
+const (
+	defaultFile      = "etc"
+	defaultEnvPrefix = "DUE_"
+)
+
+// 框架保留的环境变量，分别用于指定etc配置路径及运行模式，不作为配置项覆盖
+var defaultEnvIgnores = []string{"DUE_ETC", "DUE_MODE"}
+
+type Option func(o *options)
+
+type options struct {
+	// 覆盖的目标配置文件名称，默认为etc
+	file string
+
+	// 键前缀
+	// 环境变量默认为DUE_，命令行参数默认为目标配置文件名称加点，如：etc.；不包含前缀的环境变量或命令行参数将被忽略
+	prefix string
+
+	// 忽略的键，需包含前缀
+	ignores []string
+}
+
+// WithFile 设置覆盖的目标配置文件名称
+func WithFile(file string) Option {
+	return func(o *options) { o.file = file }
+}
+
+// WithPrefix 设置键前缀
+func WithPrefix(prefix string) Option {
+	return func(o *options) { o.prefix = prefix }
+}
+
+// WithIgnores 设置忽略的键，需包含前缀
+func WithIgnores(keys ...string) Option {
+	return func(o *options) { o.ignores = append(o.ignores, keys...) }
+}
+
+// 是否为忽略的键
+func (o *options) isIgnored(key string) bool {
+	for _, k := range o.ignores {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,75 @@
+package overlay_test
+
+import (
+	"github.com/dobyte/due/v2/config"
+	"github.com/dobyte/due/v2/config/overlay"
+	"os"
+	"testing"
+)
+
+func TestEnvSource(t *testing.T) {
+	_ = os.Setenv("DUE_TEST_CLUSTER_NODE_ID", "node-1")
+	_ = os.Setenv("DUE_TEST_TASK_NONBLOCKING", "true")
+
+	c := config.NewConfigurator(config.WithSources(overlay.NewEnvSource(overlay.WithPrefix("DUE_TEST_"))))
+	defer c.Close()
+
+	if id := c.Get("etc.cluster.node.id").String(); id != "node-1" {
+		t.Fatalf("invalid node id: %s", id)
+	}
+
+	if !c.Get("etc.task.nonblocking").Bool() {
+		t.Fatal("invalid task nonblocking")
+	}
+}
+
+func TestEnvSource_MissingKey(t *testing.T) {
+	_ = os.Setenv("DUE_MISSING_TASK_DISABLEPURGE", "true")
+
+	c := config.NewConfigurator(config.WithSources(overlay.NewEnvSource(overlay.WithPrefix("DUE_MISSING_"))))
+	defer c.Close()
+
+	if !c.Has("etc.task.disablePurge") {
+		t.Fatal("env-only key should be matched case-insensitively")
+	}
+
+	if !c.Get("etc.task.disablePurge").Bool() {
+		t.Fatal("invalid task disablePurge")
+	}
+}
+
+func TestEnvSource_Ignores(t *testing.T) {
+	_ = os.Setenv("DUE_ETC", "./etc")
+	_ = os.Setenv("DUE_MODE", "debug")
+
+	c := config.NewConfigurator(config.WithSources(overlay.NewEnvSource()))
+	defer c.Close()
+
+	if c.Has("etc.etc") || c.Has("etc.mode") {
+		t.Fatal("reserved environment variables should be ignored")
+	}
+}
+
+func TestSetOverride(t *testing.T) {
+	_ = os.Setenv("DUE_OVERRIDE_CLUSTER_NODE_ID", "node-1")
+	_ = os.Setenv("DUE_OVERRIDE_CLUSTER_NODE_NAME", "node")
+
+	c := config.NewConfigurator(config.WithSources(overlay.NewEnvSource(overlay.WithPrefix("DUE_OVERRIDE_"))))
+	defer c.Close()
+
+	if err := c.Set("etc.cluster.node.id", "node-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.(config.Reloader).Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if id := c.Get("etc.cluster.node.id").String(); id != "node-2" {
+		t.Fatalf("invalid node id: %s", id)
+	}
+
+	if name := c.Get("etc.cluster.node.name").String(); name != "node" {
+		t.Fatalf("invalid node name: %s", name)
+	}
+}
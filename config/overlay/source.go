@@ -0,0 +1,176 @@
+package overlay
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/config"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/flag"
+	"os"
+	"strings"
+)
+
+const (
+	EnvName  = "env"
+	FlagName = "flag"
+)
+
+type Source struct {
+	name   string
+	opts   *options
+	values func() map[string]string
+}
+
+var _ config.Source = &Source{}
+
+// NewEnvSource 新建环境变量覆盖配置源
+// 去除前缀后以下划线分隔层级，并转换为小写，如：DUE_CLUSTER_NODE_ID 映射为 etc.cluster.node.id
+// 合并时会忽略大小写匹配已存在的键，读取时同样忽略大小写匹配，因此无论配置文件中是否存在该键
+// DUE_TASK_DISABLEPURGE 均能通过 etc.task.disablePurge 读取
+// 框架保留的 DUE_ETC、DUE_MODE 环境变量默认被忽略
+func NewEnvSource(opts ...Option) *Source {
+	o := &options{file: defaultFile, prefix: defaultEnvPrefix, ignores: append([]string(nil), defaultEnvIgnores...)}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Source{name: EnvName, opts: o, values: func() map[string]string {
+		values := make(map[string]string)
+
+		for _, env := range os.Environ() {
+			key, val, ok := strings.Cut(env, "=")
+			if !ok || !strings.HasPrefix(key, o.prefix) || o.isIgnored(key) {
+				continue
+			}
+
+			key = strings.TrimPrefix(key, o.prefix)
+			if key == "" {
+				continue
+			}
+
+			values[strings.ToLower(strings.ReplaceAll(key, "_", "."))] = val
+		}
+
+		return values
+	}}
+}
+
+// NewFlagSource 新建命令行参数覆盖配置源
+// 键需以目标配置文件名称加点为前缀，以点分隔层级，如：--etc.cluster.node.id 映射为 etc.cluster.node.id
+// 前缀不可为空，以免 --etc、--mode、-test.v 等非配置类的命令行参数被当作配置项
+func NewFlagSource(opts ...Option) *Source {
+	o := &options{file: defaultFile}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.prefix == "" {
+		o.prefix = o.file + "."
+	}
+
+	return &Source{name: FlagName, opts: o, values: func() map[string]string {
+		values := make(map[string]string)
+
+		for key, val := range flag.Values() {
+			if !strings.HasPrefix(key, o.prefix) || o.isIgnored(key) {
+				continue
+			}
+
+			key = strings.TrimPrefix(key, o.prefix)
+			if key == "" {
+				continue
+			}
+
+			values[key] = val
+		}
+
+		return values
+	}}
+}
+
+// Name 配置源名称
+func (s *Source) Name() string {
+	return s.name
+}
+
+// Load 加载配置项
+func (s *Source) Load(ctx context.Context, file ...string) ([]*config.Configuration, error) {
+	if len(file) > 0 && file[0] != "" && file[0] != s.opts.file {
+		return nil, nil
+	}
+
+	values := s.values()
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	content := make(map[string]interface{})
+	for key, val := range values {
+		set(content, strings.Split(key, "."), parse(val))
+	}
+
+	buf, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*config.Configuration{{
+		Path:     "/" + s.opts.file + "." + json.Name,
+		File:     s.opts.file + "." + json.Name,
+		Name:     s.opts.file,
+		Format:   json.Name,
+		Content:  buf,
+		FullPath: s.name + "://" + s.opts.file,
+	}}, nil
+}
+
+// Store 保存配置项
+func (s *Source) Store(ctx context.Context, file string, content []byte) error {
+	return errors.ErrNoOperationPermission
+}
+
+// Watch 监听配置项
+// 环境变量与命令行参数在进程运行期间不会发生变化，因此监听器不会产出任何变更
+func (s *Source) Watch(ctx context.Context) (config.Watcher, error) {
+	return newWatcher(ctx), nil
+}
+
+// Close 关闭配置源
+func (s *Source) Close() error {
+	return nil
+}
+
+// 按层级设置值
+func set(values map[string]interface{}, keys []string, val interface{}) {
+	for i, key := range keys {
+		if i == len(keys)-1 {
+			values[key] = val
+			return
+		}
+
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			values[key] = next
+		}
+		values = next
+	}
+}
+
+// 解析值
+// 布尔值、数组及对象按照JSON进行解析；数值保留为字符串，以免较长的ID等数值丢失精度，读取时可自行转换
+func parse(val string) interface{} {
+	switch v := strings.TrimSpace(val); {
+	case v == "true":
+		return true
+	case v == "false":
+		return false
+	case strings.HasPrefix(v, "[") || strings.HasPrefix(v, "{"):
+		var dest interface{}
+		if err := json.Unmarshal([]byte(v), &dest); err == nil {
+			return dest
+		}
+	}
+
+	return val
+}
@@ -0,0 +1,31 @@
+package overlay
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/config"
+)
+
+type watcher struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newWatcher(ctx context.Context) config.Watcher {
+	w := &watcher{}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+
+	return w
+}
+
+// Next 返回配置列表
+func (w *watcher) Next() ([]*config.Configuration, error) {
+	<-w.ctx.Done()
+
+	return nil, w.ctx.Err()
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
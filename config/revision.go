@@ -0,0 +1,81 @@
+package config
+
+import (
+	"context"
+	"strings"
+)
+
+// Revision 配置修订版本
+type Revision struct {
+	Version int64  // 版本号
+	Current bool   // 是否为当前版本
+	Content []byte // 文件内容
+}
+
+// Reviser 配置版本管理，配置源可选择性实现
+type Reviser interface {
+	// Revisions 获取配置文件的修订版本列表，按版本号由新到旧排列
+	Revisions(ctx context.Context, file string) ([]*Revision, error)
+	// Revision 获取配置文件的某个修订版本
+	Revision(ctx context.Context, file string, version int64) (*Revision, error)
+	// Rollback 回滚配置文件至某个修订版本
+	Rollback(ctx context.Context, file string, version int64) error
+}
+
+// DiffContent 逐行比较两个版本的配置内容
+// 未变更的行以两个空格开头，删除的行以"- "开头，新增的行以"+ "开头
+func DiffContent(from, to []byte) string {
+	var (
+		a = splitLines(from)
+		b = splitLines(to)
+		m = len(a)
+		n = len(b)
+		l = make([][]int, m+1)
+	)
+
+	for i := range l {
+		l[i] = make([]int, n+1)
+	}
+
+	for i := m - 1; i >= 0; i-- {
+		for j := n - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				l[i][j] = l[i+1][j+1] + 1
+			} else if l[i+1][j] >= l[i][j+1] {
+				l[i][j] = l[i+1][j]
+			} else {
+				l[i][j] = l[i][j+1]
+			}
+		}
+	}
+
+	var (
+		i, j int
+		sb   strings.Builder
+	)
+
+	for i < m || j < n {
+		switch {
+		case i < m && j < n && a[i] == b[j]:
+			sb.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case j < n && (i == m || l[i][j+1] > l[i+1][j]):
+			sb.WriteString("+ " + b[j] + "\n")
+			j++
+		default:
+			sb.WriteString("- " + a[i] + "\n")
+			i++
+		}
+	}
+
+	return sb.String()
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n"), "\n")
+}
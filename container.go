@@ -12,11 +12,13 @@ import (
 	"os/signal"
 	"runtime"
 	"strconv"
+	"sync"
 	"syscall"
 )
 
 type Container struct {
 	sig        chan os.Signal
+	mu         sync.Mutex
 	components []component.Component
 }
 
@@ -48,18 +50,29 @@ func (c *Container) Serve() {
 	case `windows`:
 		signal.Notify(c.sig, syscall.SIGINT, syscall.SIGKILL, syscall.SIGTERM)
 	default:
-		signal.Notify(c.sig, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGABRT, syscall.SIGKILL, syscall.SIGTERM)
+		signal.Notify(c.sig, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGABRT, syscall.SIGKILL, syscall.SIGTERM, syscall.SIGHUP)
 	}
 
-	sig := <-c.sig
+	for {
+		sig := <-c.sig
 
-	log.Warnf("process got signal %v, container will close", sig)
+		if sig == syscall.SIGHUP {
+			log.Warnf("process got signal %v, container will restart", sig)
+			c.Restart()
+			continue
+		}
+
+		log.Warnf("process got signal %v, container will close", sig)
+		break
+	}
 
 	signal.Stop(c.sig)
 
+	c.mu.Lock()
 	for _, comp := range c.components {
 		comp.Destroy()
 	}
+	c.mu.Unlock()
 
 	if err := eventbus.Close(); err != nil {
 		log.Errorf("eventbus close failed: %v", err)
@@ -74,6 +87,24 @@ func (c *Container) Serve() {
 	log.Close()
 }
 
+// Restart 重启容器
+// 重新加载etc配置后，按照添加顺序依次重启所有组件
+// 组件重启失败时仅记录错误，失败的组件保持原有状态继续运行，不影响其他组件重启
+func (c *Container) Restart() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := etc.Reload(); err != nil {
+		log.Errorf("etc reload failed: %v", err)
+	}
+
+	for _, comp := range c.components {
+		if err := comp.Restart(); err != nil {
+			log.Errorf("%s component restart failed: %v", comp.Name(), err)
+		}
+	}
+}
+
 func (c *Container) doSavePID() {
 	filename := etc.Get("etc.pid").String()
 	if filename == "" {
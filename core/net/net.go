@@ -133,6 +133,16 @@ func AssignRandPort(ip ...string) (int, error) {
 	return port, nil
 }
 
+// CheckListenAddr 检测TCP监听地址是否可用
+func CheckListenAddr(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return listener.Close()
+}
+
 // FulfillAddr 补全地址
 func FulfillAddr(addr string) string {
 	host, port, err := net.SplitHostPort(addr)
@@ -37,6 +37,17 @@ var (
 	ErrIllegalOperation      = New("illegal operation")
 	ErrInvalidPointer        = New("invalid pointer")
 	ErrNotFoundLocator       = New("not found locator")
+	ErrInvalidConfigValue    = New("invalid config value")
+	ErrNotSupportRevision    = New("config source does not support revision")
+	ErrNotSupportReload      = New("configurator does not support reload")
+	ErrNotFoundRevision      = New("not found config revision")
+	ErrInvalidConfigFile     = New("invalid config file")
+	ErrNotSupportAck         = New("eventbus does not support acknowledgement")
+	ErrNotSupportGroup       = New("eventbus does not support consumer group")
+	ErrNotSupportWildcard    = New("eventbus does not support wildcard topic")
+	ErrNotSupportService     = New("transport does not support service")
+	ErrUserAlreadyOnline     = New("user already online")
+	ErrNotSupportMultiGate   = New("locator does not support multiple gates")
 )
 
 // NewError 新建一个错误
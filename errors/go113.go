@@ -15,3 +15,6 @@ func As(err error, target interface{}) bool { return errors.As(err, target) }
 
 // Unwrap Wrapping for errors.Unwrap standard library
 func Unwrap(err error) error { return errors.Unwrap(err) }
+
+// Join Wrapping for errors.Join standard library
+func Join(errs ...error) error { return errors.Join(errs...) }
@@ -3,13 +3,20 @@ package etc
 import (
 	"github.com/dobyte/due/v2/config"
 	"github.com/dobyte/due/v2/config/file/core"
+	"github.com/dobyte/due/v2/config/overlay"
 	"github.com/dobyte/due/v2/core/value"
 	"github.com/dobyte/due/v2/env"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/flag"
+	"sync"
 )
 
 // etc主要被当做项目启动配置存在；常用于集群配置、服务组件配置等。
-// etc只能通过配置文件进行配置；并且无法通过master管理服进行修改。
+// etc可通过配置文件、环境变量及命令行参数进行配置；并且无法通过master管理服进行修改。
+// 优先级由低到高依次为：配置文件 < 环境变量 < 命令行参数。
+// 环境变量以DUE_为前缀，以下划线分隔层级，如：DUE_CLUSTER_NODE_ID 对应 etc.cluster.node.id。
+// 命令行参数以etc.为前缀，以点分隔层级，如：--etc.cluster.node.id 对应 etc.cluster.node.id。
+// etc配置文件发生变化时会被自动重新加载，可通过Watch监听配置变化。
 // 如想在业务使用配置，推荐使用config配置中心进行实现。
 // config配置中心的配置信息可通过master管理服进行动态修改。
 
@@ -19,21 +26,40 @@ const (
 	defaultEtcPath = "./etc"
 )
 
-var globalConfigurator config.Configurator
+var (
+	globalConfigurator config.Configurator
+	globalWatchers     []*watcher
+	mu                 sync.Mutex
+)
+
+type watcher struct {
+	callback config.WatchCallbackFunc
+	names    map[string]struct{}
+}
 
 func init() {
 	path := flag.String(dueEtcArgName, defaultEtcPath)
 	path = env.Get(dueEtcEnvName, path).String()
-	globalConfigurator = config.NewConfigurator(config.WithSources(core.NewSource(path, config.ReadOnly)))
+	globalConfigurator = config.NewConfigurator(config.WithSources(
+		core.NewSource(path, config.ReadOnly),
+		overlay.NewEnvSource(),
+		overlay.NewFlagSource(),
+	))
+	globalConfigurator.Watch(notify)
 }
 
 // SetConfigurator 设置配置器
+// 已设置的监听回调将会迁移至新的配置器上
 func SetConfigurator(configurator config.Configurator) {
+	mu.Lock()
+	defer mu.Unlock()
+
 	if globalConfigurator != nil {
 		globalConfigurator.Close()
 	}
 
 	globalConfigurator = configurator
+	globalConfigurator.Watch(notify)
 }
 
 // GetConfigurator 获取配置器
@@ -61,6 +87,67 @@ func Match(patterns ...string) config.Matcher {
 	return globalConfigurator.Match(patterns...)
 }
 
+// Watch 设置监听回调，返回取消监听的函数
+// 当etc配置文件发生变化时，将回调变化的配置文件名称
+func Watch(cb config.WatchCallbackFunc, names ...string) (unwatch func()) {
+	w := &watcher{callback: cb, names: make(map[string]struct{}, len(names))}
+	for _, name := range names {
+		w.names[name] = struct{}{}
+	}
+
+	mu.Lock()
+	globalWatchers = append(globalWatchers, w)
+	mu.Unlock()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for i, item := range globalWatchers {
+			if item == w {
+				globalWatchers = append(globalWatchers[:i], globalWatchers[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// 通知给监听回调
+func notify(names ...string) {
+	mu.Lock()
+	watchers := make([]*watcher, len(globalWatchers))
+	copy(watchers, globalWatchers)
+	mu.Unlock()
+
+	for _, w := range watchers {
+		if len(w.names) == 0 {
+			w.callback(names...)
+			continue
+		}
+
+		validNames := make([]string, 0, len(names))
+		for _, name := range names {
+			if _, ok := w.names[name]; ok {
+				validNames = append(validNames, name)
+			}
+		}
+
+		if len(validNames) > 0 {
+			w.callback(validNames...)
+		}
+	}
+}
+
+// Reload 重新加载配置
+func Reload() error {
+	reloader, ok := globalConfigurator.(config.Reloader)
+	if !ok {
+		return errors.ErrNotSupportReload
+	}
+
+	return reloader.Reload()
+}
+
 // Close 关闭配置监听
 func Close() {
 	globalConfigurator.Close()
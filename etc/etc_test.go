@@ -1,11 +1,55 @@
 package etc_test
 
 import (
+	"github.com/dobyte/due/v2/config"
+	"github.com/dobyte/due/v2/config/file/core"
 	"github.com/dobyte/due/v2/etc"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func Test_Get(t *testing.T) {
 	v := etc.Get("c.redis.addrs.1A", "192.168.0.1:3308").String()
 	t.Log(v)
 }
+
+func Test_Watch(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "app.json"), []byte(`{"name":"due"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	etc.SetConfigurator(config.NewConfigurator(config.WithSources(core.NewSource(dir, config.ReadOnly))))
+
+	chNames := make(chan []string, 1)
+
+	unwatch := etc.Watch(func(names ...string) { chNames <- names }, "app")
+
+	if err := etc.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case names := <-chNames:
+		if len(names) != 1 || names[0] != "app" {
+			t.Fatalf("invalid names: %v", names)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watch callback timeout")
+	}
+
+	unwatch()
+
+	if err := etc.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case names := <-chNames:
+		t.Fatalf("unexpected callback after unwatch: %v", names)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -1,53 +1,86 @@
 package eventbus
 
 import (
-	"github.com/dobyte/due/v2/task"
-	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 type consumer struct {
-	rw       sync.RWMutex
-	handlers map[uintptr][]EventHandler
+	rw          sync.RWMutex
+	subscribers []*Subscriber            // 所有订阅者
+	broadcasts  []*Subscriber            // 未设置消费组的订阅者
+	groups      map[string][]*Subscriber // 按照消费组划分的订阅者
+	counter     atomic.Uint64            // 事件计数，用于在消费组中轮询选择订阅者
 }
 
-// 添加处理器
-func (c *consumer) addHandler(handler EventHandler) int {
-	pointer := reflect.ValueOf(handler).Pointer()
-
+// 添加订阅者
+func (c *consumer) addSubscriber(subscriber *Subscriber) int {
 	c.rw.Lock()
 	defer c.rw.Unlock()
 
-	if _, ok := c.handlers[pointer]; !ok {
-		c.handlers[pointer] = make([]EventHandler, 0, 1)
-	}
-
-	c.handlers[pointer] = append(c.handlers[pointer], handler)
+	c.subscribers = append(c.subscribers[:len(c.subscribers):len(c.subscribers)], subscriber)
+	c.rebuild()
 
-	return len(c.handlers[pointer])
+	return len(c.subscribers)
 }
 
-// 移除处理器
-func (c *consumer) remHandler(handler EventHandler) int {
-	pointer := reflect.ValueOf(handler).Pointer()
+// 移除订阅者
+func (c *consumer) remSubscriber(id string) int {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	c.subscribers = RemoveSubscriber(c.subscribers, id)
+	c.rebuild()
+
+	return len(c.subscribers)
+}
 
+// 关闭所有处理器
+func (c *consumer) close() {
 	c.rw.Lock()
 	defer c.rw.Unlock()
 
-	delete(c.handlers, pointer)
+	for _, s := range c.subscribers {
+		s.Close()
+	}
+
+	c.subscribers = nil
+	c.rebuild()
+}
+
+// 重建订阅者分组
+func (c *consumer) rebuild() {
+	broadcasts := make([]*Subscriber, 0, len(c.subscribers))
+	groups := make(map[string][]*Subscriber)
+
+	for _, s := range c.subscribers {
+		if s.Group() == "" {
+			broadcasts = append(broadcasts, s)
+		} else {
+			groups[s.Group()] = append(groups[s.Group()], s)
+		}
+	}
 
-	return len(c.handlers)
+	c.broadcasts = broadcasts
+	c.groups = groups
 }
 
 // 分发数据
 func (c *consumer) dispatch(event *Event) {
 	c.rw.RLock()
-	defer c.rw.RUnlock()
+	broadcasts, groups := c.broadcasts, c.groups
+	c.rw.RUnlock()
 
-	for _, handlers := range c.handlers {
-		for i := range handlers {
-			handler := handlers[i]
-			task.AddTask(func() { handler(event) })
-		}
+	for _, s := range broadcasts {
+		s.Deliver(event)
+	}
+
+	if len(groups) == 0 {
+		return
+	}
+
+	n := c.counter.Add(1)
+	for _, members := range groups {
+		members[n%uint64(len(members))].Deliver(event)
 	}
 }
@@ -3,6 +3,7 @@ package eventbus
 import (
 	"context"
 	"github.com/dobyte/due/v2/core/value"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/utils/xtime"
 	"github.com/dobyte/due/v2/utils/xuuid"
@@ -18,6 +19,9 @@ func init() {
 
 type EventHandler func(event *Event)
 
+// AckHandler 需确认的事件处理器，返回nil时确认事件已处理完成，返回错误时事件将会被重新投递
+type AckHandler func(event *Event) error
+
 type Event struct {
 	ID        string      // 事件ID
 	Topic     string      // 事件主题
@@ -30,10 +34,17 @@ type Eventbus interface {
 	Close() error
 	// Publish 发布事件
 	Publish(ctx context.Context, topic string, message interface{}) error
-	// Subscribe 订阅事件
-	Subscribe(ctx context.Context, topic string, handler EventHandler) error
-	// Unsubscribe 取消订阅
-	Unsubscribe(ctx context.Context, topic string, handler EventHandler) error
+	// Subscribe 订阅事件，返回订阅ID
+	Subscribe(ctx context.Context, topic string, handler EventHandler, opts ...SubscribeOption) (string, error)
+	// Unsubscribe 通过订阅ID取消订阅
+	Unsubscribe(ctx context.Context, topic string, id string) error
+}
+
+// AckEventbus 支持事件确认的事件总线，通过SubscribeAck订阅的事件至少会被成功处理一次
+type AckEventbus interface {
+	Eventbus
+	// SubscribeAck 订阅需确认的事件，返回订阅ID，可通过Unsubscribe取消订阅
+	SubscribeAck(ctx context.Context, topic string, handler AckHandler, opts ...SubscribeOption) (string, error)
 }
 
 type defaultEventbus struct {
@@ -41,12 +52,16 @@ type defaultEventbus struct {
 	cancel context.CancelFunc
 
 	rw        sync.RWMutex
-	consumers map[string]*consumer
+	consumers map[string]*consumer // 精确主题的消费者
+	wildcards map[string]*consumer // 通配主题的消费者
 }
 
+// NewEventbus 创建进程内的事件总线，事件仅在当前进程中分发，无需依赖任何外部服务
+// 可与registry/memory、locate/memory配合使用，使网关、节点、网格运行于同一进程中
 func NewEventbus() *defaultEventbus {
 	eb := &defaultEventbus{}
 	eb.consumers = make(map[string]*consumer)
+	eb.wildcards = make(map[string]*consumer)
 
 	return eb
 }
@@ -54,50 +69,75 @@ func NewEventbus() *defaultEventbus {
 // Publish 发布事件
 func (eb *defaultEventbus) Publish(ctx context.Context, topic string, payload interface{}) error {
 	eb.rw.RLock()
-	defer eb.rw.RUnlock()
+	consumers := make([]*consumer, 0, 1)
+	if c, ok := eb.consumers[topic]; ok {
+		consumers = append(consumers, c)
+	}
+	for pattern, c := range eb.wildcards {
+		if MatchTopic(pattern, topic) {
+			consumers = append(consumers, c)
+		}
+	}
+	eb.rw.RUnlock()
 
-	c, ok := eb.consumers[topic]
-	if !ok {
+	if len(consumers) == 0 {
 		return nil
 	}
 
-	c.dispatch(&Event{
+	event := &Event{
 		ID:        xuuid.UUID(),
 		Topic:     topic,
 		Payload:   value.NewValue(payload),
 		Timestamp: xtime.UnixNano(xtime.Now().UnixNano()),
-	})
+	}
+
+	for _, c := range consumers {
+		c.dispatch(event)
+	}
 
 	return nil
 }
 
 // Subscribe 订阅事件
-func (eb *defaultEventbus) Subscribe(ctx context.Context, topic string, handler EventHandler) error {
+// 主题支持通配符，*匹配一级主题，**匹配零或多级主题（仅可位于末尾），主题层级以.分隔，例如：player.*
+func (eb *defaultEventbus) Subscribe(ctx context.Context, topic string, handler EventHandler, opts ...SubscribeOption) (string, error) {
+	subscriber := NewSubscriber(handler, opts...)
+
 	eb.rw.Lock()
 	defer eb.rw.Unlock()
 
-	c, ok := eb.consumers[topic]
+	consumers := eb.consumers
+	if IsWildcard(topic) {
+		consumers = eb.wildcards
+	}
+
+	c, ok := consumers[topic]
 	if !ok {
-		c = &consumer{handlers: make(map[uintptr][]EventHandler)}
-		eb.consumers[topic] = c
+		c = &consumer{}
+		consumers[topic] = c
 	}
 
-	c.addHandler(handler)
+	c.addSubscriber(subscriber)
 
-	return nil
+	return subscriber.ID(), nil
 }
 
 // Unsubscribe 取消订阅
-func (eb *defaultEventbus) Unsubscribe(ctx context.Context, topic string, handler EventHandler) error {
+func (eb *defaultEventbus) Unsubscribe(ctx context.Context, topic string, id string) error {
 	eb.rw.Lock()
 	defer eb.rw.Unlock()
 
-	if c, ok := eb.consumers[topic]; ok {
-		if c.remHandler(handler) != 0 {
+	consumers := eb.consumers
+	if IsWildcard(topic) {
+		consumers = eb.wildcards
+	}
+
+	if c, ok := consumers[topic]; ok {
+		if c.remSubscriber(id) != 0 {
 			return nil
 		}
 
-		delete(eb.consumers, topic)
+		delete(consumers, topic)
 	}
 
 	return nil
@@ -105,6 +145,20 @@ func (eb *defaultEventbus) Unsubscribe(ctx context.Context, topic string, handle
 
 // Close 停止监听
 func (eb *defaultEventbus) Close() error {
+	eb.rw.Lock()
+	defer eb.rw.Unlock()
+
+	for _, c := range eb.consumers {
+		c.close()
+	}
+
+	for _, c := range eb.wildcards {
+		c.close()
+	}
+
+	eb.consumers = make(map[string]*consumer)
+	eb.wildcards = make(map[string]*consumer)
+
 	return nil
 }
 
@@ -134,14 +188,24 @@ func Publish(ctx context.Context, topic string, message interface{}) error {
 	return globalEventbus.Publish(ctx, topic, message)
 }
 
-// Subscribe 订阅事件
-func Subscribe(ctx context.Context, topic string, handler EventHandler) error {
-	return globalEventbus.Subscribe(ctx, topic, handler)
+// Subscribe 订阅事件，返回订阅ID
+func Subscribe(ctx context.Context, topic string, handler EventHandler, opts ...SubscribeOption) (string, error) {
+	return globalEventbus.Subscribe(ctx, topic, handler, opts...)
 }
 
-// Unsubscribe 取消订阅
-func Unsubscribe(ctx context.Context, topic string, handler EventHandler) error {
-	return globalEventbus.Unsubscribe(ctx, topic, handler)
+// Unsubscribe 通过订阅ID取消订阅
+func Unsubscribe(ctx context.Context, topic string, id string) error {
+	return globalEventbus.Unsubscribe(ctx, topic, id)
+}
+
+// SubscribeAck 订阅需确认的事件，返回订阅ID，当前事件总线不支持事件确认时返回errors.ErrNotSupportAck
+func SubscribeAck(ctx context.Context, topic string, handler AckHandler, opts ...SubscribeOption) (string, error) {
+	eb, ok := globalEventbus.(AckEventbus)
+	if !ok {
+		return "", errors.ErrNotSupportAck
+	}
+
+	return eb.SubscribeAck(ctx, topic, handler, opts...)
 }
 
 // Close 关闭事件总线
@@ -29,12 +29,12 @@ func TestEventbus_Subscribe(t *testing.T) {
 		ctx = context.Background()
 	)
 
-	err = eb.Subscribe(ctx, loginTopic, loginEventHandler)
+	_, err = eb.Subscribe(ctx, loginTopic, loginEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
+	_, err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -55,3 +55,70 @@ func TestEventbus_Subscribe(t *testing.T) {
 
 	time.Sleep(30 * time.Second)
 }
+
+func TestEventbus_Wildcard(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		eb       = eventbus.NewEventbus()
+		received = make(chan string, 10)
+	)
+
+	newHandler := func(name string) eventbus.EventHandler {
+		return func(event *eventbus.Event) {
+			received <- name + ":" + event.Topic
+		}
+	}
+
+	first, second := newHandler("first"), newHandler("second")
+
+	_, _ = eb.Subscribe(ctx, "player.*", first, eventbus.WithSync())
+
+	// 两个处理器由同一函数创建，取消订阅时仅应移除订阅ID对应的处理器
+	id, err := eb.Subscribe(ctx, "player.*", second, eventbus.WithOrdered())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = eb.Unsubscribe(ctx, "player.*", id); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = eb.Publish(ctx, "player.login", "login")
+	_ = eb.Publish(ctx, "player.login.success", "ignored")
+
+	close(received)
+
+	var events []string
+	for v := range received {
+		events = append(events, v)
+	}
+
+	if len(events) != 1 || events[0] != "first:player.login" {
+		t.Fatalf("unexpected events: %v", events)
+	}
+}
+
+func TestEventbus_Group(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		eb     = eventbus.NewEventbus()
+		counts = make(map[string]int)
+	)
+
+	for _, name := range []string{"first", "second"} {
+		name := name
+		_, _ = eb.Subscribe(ctx, paidTopic, func(event *eventbus.Event) {
+			counts[name]++
+		}, eventbus.WithGroup("settlement"), eventbus.WithSync())
+	}
+
+	for i := 0; i < 4; i++ {
+		_ = eb.Publish(ctx, paidTopic, i)
+	}
+
+	if counts["first"]+counts["second"] != 4 {
+		t.Fatalf("each event should be handled once by the group, got %v", counts)
+	}
+
+	t.Log(counts)
+}
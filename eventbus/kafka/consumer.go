@@ -2,46 +2,105 @@ package kafka
 
 import (
 	"context"
+	"github.com/IBM/sarama"
 	"github.com/dobyte/due/v2/eventbus"
 	"github.com/dobyte/due/v2/log"
-	"github.com/dobyte/due/v2/task"
-	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 type consumer struct {
-	ctx      context.Context
-	cancel   context.CancelFunc
-	rw       sync.RWMutex
-	handlers map[uintptr][]eventbus.EventHandler
+	ctx         context.Context
+	cancel      context.CancelFunc
+	topic       string        // 主题
+	group       string        // 消费组，同一消费组中的事件仅会投递给其中一个订阅者
+	counter     atomic.Uint64 // 事件计数，用于在消费组中轮询选择订阅者
+	rw          sync.RWMutex
+	subscribers []*eventbus.Subscriber
 }
 
-// 添加处理器
-func (c *consumer) addHandler(handler eventbus.EventHandler) int {
-	pointer := reflect.ValueOf(handler).Pointer()
+// 添加订阅者
+func (c *consumer) addSubscriber(subscriber *eventbus.Subscriber) int {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	c.subscribers = append(c.subscribers[:len(c.subscribers):len(c.subscribers)], subscriber)
+
+	return len(c.subscribers)
+}
+
+// 移除订阅者
+func (c *consumer) remSubscriber(id string) int {
+	c.rw.Lock()
+	defer c.rw.Unlock()
+
+	c.subscribers = eventbus.RemoveSubscriber(c.subscribers, id)
 
+	return len(c.subscribers)
+}
+
+// 关闭所有处理器
+func (c *consumer) close() {
 	c.rw.Lock()
 	defer c.rw.Unlock()
 
-	if _, ok := c.handlers[pointer]; !ok {
-		c.handlers[pointer] = make([]eventbus.EventHandler, 0, 1)
+	for _, s := range c.subscribers {
+		s.Close()
 	}
 
-	c.handlers[pointer] = append(c.handlers[pointer], handler)
+	c.subscribers = nil
+}
 
-	return len(c.handlers[pointer])
+// Setup 消费组会话开始
+func (c *consumer) Setup(_ sarama.ConsumerGroupSession) error {
+	return nil
 }
 
-// 移除处理器
-func (c *consumer) remHandler(handler eventbus.EventHandler) int {
-	pointer := reflect.ValueOf(handler).Pointer()
+// Cleanup 消费组会话结束
+func (c *consumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
 
-	c.rw.Lock()
-	defer c.rw.Unlock()
+// ConsumeClaim 消费分区中的事件
+func (c *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case <-session.Context().Done():
+			return nil
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			// 事件处理完毕后再提交位移，消费者异常退出时未处理完毕的事件将被重新消费
+			c.handle(message.Value)
+
+			session.MarkMessage(message, "")
+		}
+	}
+}
+
+// 同步处理消费组中的事件，轮询选择其中一个订阅者进行处理
+func (c *consumer) handle(data []byte) {
+	event, err := deserialize(data)
+	if err != nil {
+		log.Error("invalid event data")
+		return
+	}
+
+	c.rw.RLock()
+	subscribers := c.subscribers
+	c.rw.RUnlock()
 
-	delete(c.handlers, pointer)
+	if len(subscribers) == 0 {
+		return
+	}
 
-	return len(c.handlers)
+	subscriber := subscribers[c.counter.Add(1)%uint64(len(subscribers))]
+
+	if err = subscriber.Handle(event); err != nil {
+		log.Warnf("handle group event failed, topic: %s group: %s err: %v", c.topic, c.group, err)
+	}
 }
 
 // 分发数据
@@ -53,12 +112,14 @@ func (c *consumer) dispatch(data []byte) {
 	}
 
 	c.rw.RLock()
-	defer c.rw.RUnlock()
+	subscribers := c.subscribers
+	c.rw.RUnlock()
 
-	for _, handlers := range c.handlers {
-		for i := range handlers {
-			handler := handlers[i]
-			task.AddTask(func() { handler(event) })
-		}
+	if len(subscribers) == 0 {
+		return
+	}
+
+	for _, s := range subscribers {
+		s.Deliver(event)
 	}
 }
@@ -3,8 +3,11 @@ package kafka
 import (
 	"context"
 	"github.com/IBM/sarama"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/eventbus"
+	"github.com/dobyte/due/v2/log"
 	"sync"
+	"time"
 )
 
 type Eventbus struct {
@@ -15,6 +18,7 @@ type Eventbus struct {
 	err      error
 	err1     error
 	err2     error
+	config   *sarama.Config
 	consumer sarama.Consumer
 	producer sarama.AsyncProducer
 	builtin  bool
@@ -52,6 +56,7 @@ func NewEventbus(opts ...Option) *Eventbus {
 		}
 
 		if eb.err == nil {
+			eb.config = config
 			eb.consumer, eb.err1 = sarama.NewConsumer(o.addrs, config)
 			eb.producer, eb.err2 = sarama.NewAsyncProducer(o.addrs, config)
 		}
@@ -91,34 +96,60 @@ func (eb *Eventbus) Publish(ctx context.Context, topic string, payload interface
 }
 
 // Subscribe 订阅事件
-func (eb *Eventbus) Subscribe(_ context.Context, topic string, handler eventbus.EventHandler) error {
+// 设置消费组时将以Kafka消费组的方式进行消费，同一消费组中的事件仅会被其中一个订阅者处理，
+// 消费组中的事件将被同步处理并在处理完毕后提交位移，订阅选项中的投递模式将被忽略
+// Kafka需预先指定消费的主题，订阅通配主题时将返回errors.ErrNotSupportWildcard
+func (eb *Eventbus) Subscribe(_ context.Context, topic string, handler eventbus.EventHandler, opts ...eventbus.SubscribeOption) (string, error) {
 	if eb.err != nil {
-		return eb.err
+		return "", eb.err
 	}
 
 	if eb.err1 != nil {
-		return eb.err1
+		return "", eb.err1
+	}
+
+	if eventbus.IsWildcard(topic) {
+		return "", errors.ErrNotSupportWildcard
 	}
 
+	subscriber := eventbus.NewSubscriber(handler, opts...)
+	key := buildConsumerKey(topic, subscriber.Group())
+
 	eb.rw.Lock()
-	c, ok := eb.consumers[topic]
+	c, ok := eb.consumers[key]
 	if !ok {
-		c = &consumer{handlers: make(map[uintptr][]eventbus.EventHandler)}
+		c = &consumer{topic: topic, group: subscriber.Group()}
 		c.ctx, c.cancel = context.WithCancel(eb.ctx)
-		eb.consumers[topic] = c
+		eb.consumers[key] = c
 	}
-	c.addHandler(handler)
+	c.addSubscriber(subscriber)
 	eb.rw.Unlock()
 
-	if !ok {
-		return eb.watch(c, topic)
+	if ok {
+		return subscriber.ID(), nil
 	}
 
-	return nil
+	var err error
+	if c.group == "" {
+		err = eb.watch(c, topic)
+	} else {
+		err = eb.watchGroup(c)
+	}
+	if err != nil {
+		eb.rw.Lock()
+		c.cancel()
+		c.close()
+		delete(eb.consumers, key)
+		eb.rw.Unlock()
+
+		return "", err
+	}
+
+	return subscriber.ID(), nil
 }
 
 // Unsubscribe 取消订阅
-func (eb *Eventbus) Unsubscribe(_ context.Context, topic string, handler eventbus.EventHandler) error {
+func (eb *Eventbus) Unsubscribe(_ context.Context, topic string, id string) error {
 	if eb.err != nil {
 		return eb.err
 	}
@@ -130,12 +161,24 @@ func (eb *Eventbus) Unsubscribe(_ context.Context, topic string, handler eventbu
 	eb.rw.Lock()
 	defer eb.rw.Unlock()
 
-	if c, ok := eb.consumers[topic]; ok {
-		if c.remHandler(handler) != 0 {
-			return nil
+	for key, c := range eb.consumers {
+		if c.topic != topic {
+			continue
 		}
-		c.cancel()
-		delete(eb.consumers, topic)
+
+		n := len(c.subscribers)
+		m := c.remSubscriber(id)
+
+		if m == n {
+			continue
+		}
+
+		if m == 0 {
+			c.cancel()
+			delete(eb.consumers, key)
+		}
+
+		break
 	}
 
 	return nil
@@ -157,6 +200,12 @@ func (eb *Eventbus) Close() error {
 
 	eb.cancel()
 
+	eb.rw.Lock()
+	for _, c := range eb.consumers {
+		c.close()
+	}
+	eb.rw.Unlock()
+
 	if !eb.builtin {
 		return nil
 	}
@@ -201,3 +250,68 @@ func (eb *Eventbus) watch(c *consumer, topic string) error {
 
 	return nil
 }
+
+// 以消费组的方式消费事件
+func (eb *Eventbus) watchGroup(c *consumer) error {
+	var (
+		err error
+		cg  sarama.ConsumerGroup
+	)
+
+	if eb.builtin {
+		cg, err = sarama.NewConsumerGroup(eb.opts.addrs, c.group, eb.config)
+	} else {
+		cg, err = sarama.NewConsumerGroupFromClient(c.group, eb.opts.client)
+	}
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case err, ok := <-cg.Errors():
+				if !ok {
+					return
+				}
+				log.Warnf("consume group event failed, topic: %s group: %s err: %v", c.topic, c.group, err)
+			}
+		}
+	}()
+
+	go func() {
+		defer cg.Close()
+
+		for {
+			if err := cg.Consume(c.ctx, []string{c.topic}, c); err != nil {
+				if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+					return
+				}
+
+				log.Warnf("consume group event failed, topic: %s group: %s err: %v", c.topic, c.group, err)
+
+				select {
+				case <-c.ctx.Done():
+				case <-time.After(time.Second):
+				}
+			}
+
+			if c.ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// 构建消费者键
+func buildConsumerKey(topic, group string) string {
+	if group == "" {
+		return topic
+	}
+
+	return topic + "@" + group
+}
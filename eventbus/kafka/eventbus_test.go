@@ -31,12 +31,12 @@ func TestEventbus_Client1_Subscribe(t *testing.T) {
 
 	defer eb.Close()
 
-	err = eb.Subscribe(ctx, loginTopic, loginEventHandler)
+	_, err = eb.Subscribe(ctx, loginTopic, loginEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
+	_, err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -55,17 +55,17 @@ func TestEventbus_Client2_Subscribe(t *testing.T) {
 
 	defer eb.Close()
 
-	err = eb.Subscribe(ctx, loginTopic, loginEventHandler)
+	id, err := eb.Subscribe(ctx, loginTopic, loginEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
+	_, err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = eb.Unsubscribe(ctx, loginTopic, loginEventHandler)
+	err = eb.Unsubscribe(ctx, loginTopic, id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,39 @@
+package eventbus
+
+import "strings"
+
+const (
+	topicSeparator = "."  // 主题分隔符
+	singleWildcard = "*"  // 匹配一级主题
+	multiWildcard  = "**" // 匹配零或多级主题，仅可位于末尾
+)
+
+// IsWildcard 是否为通配主题
+func IsWildcard(topic string) bool {
+	return strings.Contains(topic, singleWildcard)
+}
+
+// MatchTopic 检测主题是否与通配主题匹配
+// 例如：player.* 匹配 player.login，不匹配 player 与 player.login.success；player.** 匹配以上所有主题
+func MatchTopic(pattern, topic string) bool {
+	var (
+		patterns = strings.Split(pattern, topicSeparator)
+		segments = strings.Split(topic, topicSeparator)
+	)
+
+	for i, p := range patterns {
+		if p == multiWildcard && i == len(patterns)-1 {
+			return true
+		}
+
+		if i >= len(segments) {
+			return false
+		}
+
+		if p != singleWildcard && p != segments[i] {
+			return false
+		}
+	}
+
+	return len(patterns) == len(segments)
+}
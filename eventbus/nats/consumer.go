@@ -3,44 +3,61 @@ package nats
 import (
 	"github.com/dobyte/due/v2/eventbus"
 	"github.com/dobyte/due/v2/log"
-	"github.com/dobyte/due/v2/task"
 	"github.com/nats-io/nats.go"
-	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 type consumer struct {
-	sub      *nats.Subscription
-	rw       sync.RWMutex
-	handlers map[uintptr][]eventbus.EventHandler
+	subs        []*nats.Subscription
+	topic       string        // 主题
+	group       string        // 消费组，同一消费组中的事件仅会投递给其中一个订阅者
+	counter     atomic.Uint64 // 事件计数，用于在消费组中轮询选择订阅者
+	rw          sync.RWMutex
+	subscribers []*eventbus.Subscriber
 }
 
-// 添加处理器
-func (c *consumer) addHandler(handler eventbus.EventHandler) int {
-	pointer := reflect.ValueOf(handler).Pointer()
-
+// 添加订阅者
+func (c *consumer) addSubscriber(subscriber *eventbus.Subscriber) int {
 	c.rw.Lock()
 	defer c.rw.Unlock()
 
-	if _, ok := c.handlers[pointer]; !ok {
-		c.handlers[pointer] = make([]eventbus.EventHandler, 0, 1)
-	}
+	c.subscribers = append(c.subscribers[:len(c.subscribers):len(c.subscribers)], subscriber)
+
+	return len(c.subscribers)
+}
+
+// 移除订阅者
+func (c *consumer) remSubscriber(id string) int {
+	c.rw.Lock()
+	defer c.rw.Unlock()
 
-	c.handlers[pointer] = append(c.handlers[pointer], handler)
+	c.subscribers = eventbus.RemoveSubscriber(c.subscribers, id)
 
-	return len(c.handlers[pointer])
+	return len(c.subscribers)
 }
 
-// 移除处理器
-func (c *consumer) remHandler(handler eventbus.EventHandler) int {
-	pointer := reflect.ValueOf(handler).Pointer()
+// 取消所有NATS订阅
+func (c *consumer) unsubscribe() error {
+	for _, sub := range c.subs {
+		if err := sub.Unsubscribe(); err != nil {
+			return err
+		}
+	}
 
+	return nil
+}
+
+// 关闭所有处理器
+func (c *consumer) close() {
 	c.rw.Lock()
 	defer c.rw.Unlock()
 
-	delete(c.handlers, pointer)
+	for _, s := range c.subscribers {
+		s.Close()
+	}
 
-	return len(c.handlers)
+	c.subscribers = nil
 }
 
 // 分发数据
@@ -52,12 +69,19 @@ func (c *consumer) dispatch(data []byte) {
 	}
 
 	c.rw.RLock()
-	defer c.rw.RUnlock()
+	subscribers := c.subscribers
+	c.rw.RUnlock()
 
-	for _, handlers := range c.handlers {
-		for i := range handlers {
-			handler := handlers[i]
-			task.AddTask(func() { handler(event) })
-		}
+	if len(subscribers) == 0 {
+		return
+	}
+
+	if c.group != "" {
+		subscribers[c.counter.Add(1)%uint64(len(subscribers))].Deliver(event)
+		return
+	}
+
+	for _, s := range subscribers {
+		s.Deliver(event)
 	}
 }
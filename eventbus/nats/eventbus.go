@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/dobyte/due/v2/eventbus"
 	"github.com/nats-io/nats.go"
+	"strings"
 	"sync"
 )
 
@@ -47,47 +48,83 @@ func (eb *Eventbus) Publish(ctx context.Context, topic string, payload interface
 }
 
 // Subscribe 订阅事件
-func (eb *Eventbus) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler) error {
+// 设置消费组时将使用队列订阅，同一消费组中的事件仅会被其中一个订阅者处理
+func (eb *Eventbus) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler, opts ...eventbus.SubscribeOption) (string, error) {
 	if eb.err != nil {
-		return eb.err
+		return "", eb.err
 	}
 
+	subscriber := eventbus.NewSubscriber(handler, opts...)
+
 	eb.rw.Lock()
 	defer eb.rw.Unlock()
 
-	c, ok := eb.consumers[topic]
+	key := buildConsumerKey(topic, subscriber.Group())
+
+	c, ok := eb.consumers[key]
 	if !ok {
-		c = &consumer{handlers: make(map[uintptr][]eventbus.EventHandler)}
-		sub, err := eb.opts.conn.Subscribe(topic, func(msg *nats.Msg) {
-			c.dispatch(msg.Data)
-		})
-		if err != nil {
-			return err
+		c = &consumer{topic: topic, group: subscriber.Group()}
+
+		for _, subject := range buildSubjects(topic) {
+			var (
+				err error
+				sub *nats.Subscription
+			)
+
+			if c.group == "" {
+				sub, err = eb.opts.conn.Subscribe(subject, func(msg *nats.Msg) {
+					c.dispatch(msg.Data)
+				})
+			} else {
+				sub, err = eb.opts.conn.QueueSubscribe(subject, c.group, func(msg *nats.Msg) {
+					c.dispatch(msg.Data)
+				})
+			}
+			if err != nil {
+				c.unsubscribe()
+				subscriber.Close()
+				return "", err
+			}
+
+			c.subs = append(c.subs, sub)
 		}
-		c.sub = sub
-		eb.consumers[topic] = c
+
+		eb.consumers[key] = c
 	}
 
-	c.addHandler(handler)
+	c.addSubscriber(subscriber)
 
-	return nil
+	return subscriber.ID(), nil
 }
 
 // Unsubscribe 取消订阅
-func (eb *Eventbus) Unsubscribe(ctx context.Context, topic string, handler eventbus.EventHandler) error {
+func (eb *Eventbus) Unsubscribe(ctx context.Context, topic string, id string) error {
 	eb.rw.Lock()
 	defer eb.rw.Unlock()
 
-	if c, ok := eb.consumers[topic]; ok {
-		if c.remHandler(handler) != 0 {
+	for key, c := range eb.consumers {
+		if c.topic != topic {
+			continue
+		}
+
+		n := len(c.subscribers)
+		m := c.remSubscriber(id)
+
+		if m == n {
+			continue
+		}
+
+		if m != 0 {
 			return nil
 		}
 
-		if err := c.sub.Unsubscribe(); err != nil {
+		if err := c.unsubscribe(); err != nil {
 			return err
 		}
 
-		delete(eb.consumers, topic)
+		delete(eb.consumers, key)
+
+		return nil
 	}
 
 	return nil
@@ -96,5 +133,34 @@ func (eb *Eventbus) Unsubscribe(ctx context.Context, topic string, handler event
 // Close 停止监听
 func (eb *Eventbus) Close() error {
 	eb.opts.conn.Close()
+
+	eb.rw.Lock()
+	for _, c := range eb.consumers {
+		c.close()
+	}
+	eb.rw.Unlock()
+
 	return nil
 }
+
+// 构建消费者键
+// 将主题转换为NATS主题，NATS中的>需匹配一级及以上主题，因此末尾的**需额外订阅其前缀主题
+func buildSubjects(topic string) []string {
+	switch {
+	case topic == "**":
+		return []string{">"}
+	case strings.HasSuffix(topic, ".**"):
+		prefix := strings.TrimSuffix(topic, ".**")
+		return []string{prefix, prefix + ".>"}
+	default:
+		return []string{topic}
+	}
+}
+
+func buildConsumerKey(topic, group string) string {
+	if group == "" {
+		return topic
+	}
+
+	return topic + "@" + group
+}
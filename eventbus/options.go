@@ -0,0 +1,48 @@
+package eventbus
+
+const defaultOrderedQueueSize = 1024
+
+// DeliveryMode 事件投递模式
+type DeliveryMode int
+
+const (
+	AsyncDelivery   DeliveryMode = iota // 异步投递，每个事件均通过任务池并发处理，不保证处理顺序
+	SyncDelivery                        // 同步投递，在分发事件的协程中直接处理，处理完成后才会继续分发后续事件
+	OrderedDelivery                     // 顺序投递，每个订阅者拥有独立的处理协程，按照事件到达的顺序依次处理
+)
+
+type subscribeOptions struct {
+	mode      DeliveryMode // 投递模式，默认异步投递
+	queueSize int          // 顺序投递时的事件队列长度
+	group     string       // 消费组，同一消费组中的订阅者仅有一个会收到事件
+}
+
+type SubscribeOption func(o *subscribeOptions)
+
+func defaultSubscribeOptions() *subscribeOptions {
+	return &subscribeOptions{
+		mode:      AsyncDelivery,
+		queueSize: defaultOrderedQueueSize,
+	}
+}
+
+// WithSync 设置同步投递
+func WithSync() SubscribeOption {
+	return func(o *subscribeOptions) { o.mode = SyncDelivery }
+}
+
+// WithOrdered 设置顺序投递，队列已满时分发事件将会阻塞
+func WithOrdered(queueSize ...int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.mode = OrderedDelivery
+		if len(queueSize) > 0 && queueSize[0] > 0 {
+			o.queueSize = queueSize[0]
+		}
+	}
+}
+
+// WithGroup 设置消费组，同一消费组中的订阅者（包括其他节点实例中的订阅者）仅有一个会收到事件
+// 不同的消费组以及未设置消费组的订阅者之间互不影响
+func WithGroup(group string) SubscribeOption {
+	return func(o *subscribeOptions) { o.group = group }
+}
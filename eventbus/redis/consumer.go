@@ -3,42 +3,44 @@ package redis
 import (
 	"github.com/dobyte/due/v2/eventbus"
 	"github.com/dobyte/due/v2/log"
-	"github.com/dobyte/due/v2/task"
-	"reflect"
 	"sync"
 )
 
 type consumer struct {
-	rw       sync.RWMutex
-	handlers map[uintptr][]eventbus.EventHandler
+	rw          sync.RWMutex
+	subscribers []*eventbus.Subscriber
 }
 
-// 添加处理器
-func (c *consumer) addHandler(handler eventbus.EventHandler) int {
-	pointer := reflect.ValueOf(handler).Pointer()
-
+// 添加订阅者
+func (c *consumer) addSubscriber(subscriber *eventbus.Subscriber) int {
 	c.rw.Lock()
 	defer c.rw.Unlock()
 
-	if _, ok := c.handlers[pointer]; !ok {
-		c.handlers[pointer] = make([]eventbus.EventHandler, 0, 1)
-	}
-
-	c.handlers[pointer] = append(c.handlers[pointer], handler)
+	c.subscribers = append(c.subscribers[:len(c.subscribers):len(c.subscribers)], subscriber)
 
-	return len(c.handlers[pointer])
+	return len(c.subscribers)
 }
 
-// 移除处理器
-func (c *consumer) remHandler(handler eventbus.EventHandler) int {
-	pointer := reflect.ValueOf(handler).Pointer()
+// 移除订阅者
+func (c *consumer) remSubscriber(id string) int {
+	c.rw.Lock()
+	defer c.rw.Unlock()
 
+	c.subscribers = eventbus.RemoveSubscriber(c.subscribers, id)
+
+	return len(c.subscribers)
+}
+
+// 关闭所有处理器
+func (c *consumer) close() {
 	c.rw.Lock()
 	defer c.rw.Unlock()
 
-	delete(c.handlers, pointer)
+	for _, s := range c.subscribers {
+		s.Close()
+	}
 
-	return len(c.handlers)
+	c.subscribers = nil
 }
 
 // 分发数据
@@ -50,12 +52,10 @@ func (c *consumer) dispatch(data []byte) {
 	}
 
 	c.rw.RLock()
-	defer c.rw.RUnlock()
+	subscribers := c.subscribers
+	c.rw.RUnlock()
 
-	for _, handlers := range c.handlers {
-		for i := range handlers {
-			handler := handlers[i]
-			task.AddTask(func() { handler(event) })
-		}
+	for _, s := range subscribers {
+		s.Deliver(event)
 	}
 }
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/eventbus"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/utils/xconv"
@@ -10,6 +11,9 @@ import (
 	"sync"
 )
 
+// 转义redis通配模式中的特殊字符，保留*用于通配
+var globEscaper = strings.NewReplacer("?", "\\?", "[", "\\[", "]", "\\]", "\\", "\\\\")
+
 type Eventbus struct {
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -17,7 +21,8 @@ type Eventbus struct {
 	sub    *redis.PubSub
 
 	rw        sync.RWMutex
-	consumers map[string]*consumer
+	consumers map[string]*consumer // 精确主题的消费者
+	wildcards map[string]*consumer // 通配主题的消费者
 }
 
 func NewEventbus(opts ...Option) *Eventbus {
@@ -45,6 +50,7 @@ func NewEventbus(opts ...Option) *Eventbus {
 	eb.opts = o
 	eb.sub = eb.opts.client.Subscribe(eb.ctx)
 	eb.consumers = make(map[string]*consumer)
+	eb.wildcards = make(map[string]*consumer)
 	go eb.watch()
 
 	return eb
@@ -61,45 +67,82 @@ func (eb *Eventbus) Publish(ctx context.Context, topic string, payload interface
 }
 
 // Subscribe 订阅事件
-func (eb *Eventbus) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler) error {
-	err := eb.sub.Subscribe(ctx, eb.opts.prefix+":"+topic)
+// 基于发布订阅实现的事件总线不支持消费组，如需使用消费组请使用NewStreamEventbus创建的事件总线
+// 通配主题将通过PSUBSCRIBE进行订阅，收到事件后再按照通配规则进行精确匹配
+func (eb *Eventbus) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler, opts ...eventbus.SubscribeOption) (string, error) {
+	subscriber := eventbus.NewSubscriber(handler, opts...)
+	if subscriber.Group() != "" {
+		subscriber.Close()
+		return "", errors.ErrNotSupportGroup
+	}
+
+	var err error
+	if eventbus.IsWildcard(topic) {
+		err = eb.sub.PSubscribe(ctx, eb.buildPatternKey(topic))
+	} else {
+		err = eb.sub.Subscribe(ctx, eb.buildChannelKey(topic))
+	}
 	if err != nil {
-		return err
+		subscriber.Close()
+		return "", err
 	}
 
 	eb.rw.Lock()
 	defer eb.rw.Unlock()
 
-	c, ok := eb.consumers[topic]
+	consumers := eb.consumers
+	if eventbus.IsWildcard(topic) {
+		consumers = eb.wildcards
+	}
+
+	c, ok := consumers[topic]
 	if !ok {
-		c = &consumer{handlers: make(map[uintptr][]eventbus.EventHandler, 1)}
-		eb.consumers[topic] = c
+		c = &consumer{}
+		consumers[topic] = c
 	}
 
-	c.addHandler(handler)
+	c.addSubscriber(subscriber)
 
-	return nil
+	return subscriber.ID(), nil
 }
 
 // Unsubscribe 取消订阅
-func (eb *Eventbus) Unsubscribe(ctx context.Context, topic string, handler eventbus.EventHandler) error {
+func (eb *Eventbus) Unsubscribe(ctx context.Context, topic string, id string) error {
 	eb.rw.Lock()
 	defer eb.rw.Unlock()
 
-	if c, ok := eb.consumers[topic]; ok {
-		if c.remHandler(handler) != 0 {
-			return nil
-		}
+	if !eventbus.IsWildcard(topic) {
+		if c, ok := eb.consumers[topic]; ok {
+			if c.remSubscriber(id) != 0 {
+				return nil
+			}
 
-		err := eb.sub.Unsubscribe(ctx, eb.buildChannelKey(topic))
-		if err != nil {
-			return err
+			if err := eb.sub.Unsubscribe(ctx, eb.buildChannelKey(topic)); err != nil {
+				return err
+			}
+
+			delete(eb.consumers, topic)
 		}
 
-		delete(eb.consumers, topic)
+		return nil
+	}
+
+	c, ok := eb.wildcards[topic]
+	if !ok || c.remSubscriber(id) != 0 {
+		return nil
+	}
+
+	delete(eb.wildcards, topic)
+
+	// 多个通配主题可能对应同一个订阅模式，如：*与**
+	pattern := eb.buildPatternKey(topic)
+	for t := range eb.wildcards {
+		if eb.buildPatternKey(t) == pattern {
+			return nil
+		}
 	}
 
-	return nil
+	return eb.sub.PUnsubscribe(ctx, pattern)
 }
 
 // watch 监听事件
@@ -116,10 +159,27 @@ func (eb *Eventbus) watch() {
 		case *redis.Message:
 			topic := eb.parseChannelKey(v.Channel)
 
+			if v.Pattern == "" {
+				eb.rw.RLock()
+				c, ok := eb.consumers[topic]
+				eb.rw.RUnlock()
+				if ok {
+					c.dispatch(xconv.Bytes(v.Payload))
+				}
+				continue
+			}
+
+			// 同一事件会按照每个匹配的订阅模式各投递一次，因此仅分发给对应订阅模式下的通配主题
 			eb.rw.RLock()
-			c, ok := eb.consumers[topic]
+			consumers := make([]*consumer, 0, 1)
+			for pattern, c := range eb.wildcards {
+				if eb.buildPatternKey(pattern) == v.Pattern && eventbus.MatchTopic(pattern, topic) {
+					consumers = append(consumers, c)
+				}
+			}
 			eb.rw.RUnlock()
-			if ok {
+
+			for _, c := range consumers {
 				c.dispatch(xconv.Bytes(v.Payload))
 			}
 		}
@@ -129,6 +189,16 @@ func (eb *Eventbus) watch() {
 // Close 停止监听
 func (eb *Eventbus) Close() error {
 	eb.cancel()
+
+	eb.rw.Lock()
+	for _, c := range eb.consumers {
+		c.close()
+	}
+	for _, c := range eb.wildcards {
+		c.close()
+	}
+	eb.rw.Unlock()
+
 	return eb.sub.Close()
 }
 
@@ -141,6 +211,19 @@ func (eb *Eventbus) buildChannelKey(topic string) string {
 	}
 }
 
+// build pattern key pass by wildcard topic
+// redis的通配符*可匹配任意字符（包括主题分隔符），收到事件后需再次按照通配规则进行匹配
+// 末尾的.**可匹配零级主题，因此需连同分隔符一起替换，如：player.**将转换为player*
+func (eb *Eventbus) buildPatternKey(topic string) string {
+	pattern := globEscaper.Replace(topic)
+	if strings.HasSuffix(pattern, ".**") {
+		pattern = strings.TrimSuffix(pattern, ".**") + "*"
+	}
+	pattern = strings.ReplaceAll(pattern, "**", "*")
+
+	return eb.buildChannelKey(pattern)
+}
+
 // parse to topic from channel key
 func (eb *Eventbus) parseChannelKey(channel string) string {
 	if eb.opts.prefix == "" {
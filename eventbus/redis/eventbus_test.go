@@ -2,9 +2,11 @@ package redis_test
 
 import (
 	"context"
+	"fmt"
 	"github.com/dobyte/due/eventbus/redis/v2"
 	"github.com/dobyte/due/v2/eventbus"
 	"log"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -31,12 +33,12 @@ func TestEventbus_Client1_Subscribe(t *testing.T) {
 
 	defer eb.Close()
 
-	err = eb.Subscribe(ctx, loginTopic, loginEventHandler)
+	_, err = eb.Subscribe(ctx, loginTopic, loginEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
+	_, err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -55,17 +57,17 @@ func TestEventbus_Client2_Subscribe(t *testing.T) {
 
 	defer eb.Close()
 
-	err = eb.Subscribe(ctx, loginTopic, loginEventHandler)
+	id, err := eb.Subscribe(ctx, loginTopic, loginEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
+	_, err = eb.Subscribe(ctx, paidTopic, paidEventHandler)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = eb.Unsubscribe(ctx, loginTopic, loginEventHandler)
+	err = eb.Unsubscribe(ctx, loginTopic, id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -96,3 +98,50 @@ func TestEventbus_Publish(t *testing.T) {
 
 	t.Log("publish success")
 }
+
+func TestStreamEventbus_SubscribeAck(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		times   atomic.Int32
+		topic   = fmt.Sprintf("%s.%d", paidTopic, time.Now().UnixNano())
+		handled = make(chan *eventbus.Event, 1)
+		eb      = redis.NewStreamEventbus(redis.WithRetryInterval(time.Second))
+	)
+
+	defer eb.Close()
+
+	_, err := eb.SubscribeAck(ctx, topic, func(event *eventbus.Event) error {
+		if n := times.Add(1); n < 3 {
+			return fmt.Errorf("handle failed %d times", n)
+		}
+
+		handled <- event
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = eb.Publish(ctx, topic, "paid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-handled:
+		if event.Topic != topic {
+			t.Fatalf("invalid event topic: %s", event.Topic)
+		}
+
+		if n := times.Load(); n != 3 {
+			t.Fatalf("the event should be handled 3 times, got %d", n)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("the event was not redelivered, handled %d times", times.Load())
+	}
+
+	if _, err = eb.SubscribeAck(ctx, "player.*", func(event *eventbus.Event) error { return nil }); err == nil {
+		t.Fatal("stream eventbus should not support wildcard topic")
+	}
+}
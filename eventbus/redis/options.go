@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/dobyte/due/v2/etc"
 	"github.com/go-redis/redis/v8"
+	"time"
 )
 
 const (
@@ -13,6 +14,13 @@ const (
 	defaultPrefix     = "due"
 )
 
+const (
+	defaultGroup         = "due"
+	defaultStreamMaxLen  = 100000
+	defaultRetryTimes    = 3
+	defaultRetryInterval = 30 * time.Second
+)
+
 const (
 	defaultAddrsKey      = "etc.eventbus.redis.addrs"
 	defaultDBKey         = "etc.eventbus.redis.db"
@@ -22,6 +30,14 @@ const (
 	defaultPasswordKey   = "etc.eventbus.redis.password"
 )
 
+const (
+	defaultGroupKey         = "etc.eventbus.redis.group"
+	defaultConsumerKey      = "etc.eventbus.redis.consumer"
+	defaultStreamMaxLenKey  = "etc.eventbus.redis.streamMaxLen"
+	defaultRetryTimesKey    = "etc.eventbus.redis.retryTimes"
+	defaultRetryIntervalKey = "etc.eventbus.redis.retryInterval"
+)
+
 type Option func(o *options)
 
 type options struct {
@@ -54,6 +70,26 @@ type options struct {
 	// 前缀
 	// key前缀，默认为due
 	prefix string
+
+	// 消费组
+	// 仅Stream事件总线有效，同一消费组中的事件仅会被其中一个消费者处理，默认为due
+	group string
+
+	// 消费者名称
+	// 仅Stream事件总线有效，默认为随机生成的UUID
+	consumer string
+
+	// Stream最大长度
+	// 仅Stream事件总线有效，超出长度后将近似裁剪旧的事件，为0时不裁剪，默认为100000
+	streamMaxLen int64
+
+	// 事件处理失败后的最大重试次数
+	// 仅Stream事件总线有效，超出重试次数后事件将被转移至死信队列，默认为3次
+	retryTimes int
+
+	// 事件处理失败后的重试间隔
+	// 仅Stream事件总线有效，事件超出重试间隔仍未被确认时将被重新投递，默认为30s
+	retryInterval time.Duration
 }
 
 func defaultOptions() *options {
@@ -65,6 +101,12 @@ func defaultOptions() *options {
 		prefix:     etc.Get(defaultPrefixKey, defaultPrefix).String(),
 		username:   etc.Get(defaultUsernameKey).String(),
 		password:   etc.Get(defaultPasswordKey).String(),
+
+		group:         etc.Get(defaultGroupKey, defaultGroup).String(),
+		consumer:      etc.Get(defaultConsumerKey).String(),
+		streamMaxLen:  etc.Get(defaultStreamMaxLenKey, defaultStreamMaxLen).Int64(),
+		retryTimes:    etc.Get(defaultRetryTimesKey, defaultRetryTimes).Int(),
+		retryInterval: etc.Get(defaultRetryIntervalKey, defaultRetryInterval).Duration(),
 	}
 }
 
@@ -107,3 +149,28 @@ func WithClient(client redis.UniversalClient) Option {
 func WithPrefix(prefix string) Option {
 	return func(o *options) { o.prefix = prefix }
 }
+
+// WithGroup 设置消费组，仅Stream事件总线有效
+func WithGroup(group string) Option {
+	return func(o *options) { o.group = group }
+}
+
+// WithConsumer 设置消费者名称，仅Stream事件总线有效
+func WithConsumer(consumer string) Option {
+	return func(o *options) { o.consumer = consumer }
+}
+
+// WithStreamMaxLen 设置Stream最大长度，超出后仅裁剪已被所有消费组确认的事件，小于等于0时不进行裁剪，仅Stream事件总线有效
+func WithStreamMaxLen(maxLen int64) Option {
+	return func(o *options) { o.streamMaxLen = maxLen }
+}
+
+// WithRetryTimes 设置事件处理失败后的最大重试次数，仅Stream事件总线有效
+func WithRetryTimes(times int) Option {
+	return func(o *options) { o.retryTimes = times }
+}
+
+// WithRetryInterval 设置事件处理失败后的重试间隔，仅Stream事件总线有效
+func WithRetryInterval(interval time.Duration) Option {
+	return func(o *options) { o.retryInterval = interval }
+}
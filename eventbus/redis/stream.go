@@ -0,0 +1,550 @@
+package redis
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/eventbus"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/utils/xconv"
+	"github.com/dobyte/due/v2/utils/xuuid"
+	"github.com/go-redis/redis/v8"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	streamDataField  = "data"                 // 事件数据字段
+	streamErrorField = "error"                // 死信事件的错误字段
+	streamReadCount  = 16                     // 单次读取的最大事件数
+	streamBlockTime  = 2 * time.Second        // 读取事件的最大阻塞时间
+	streamRetryDelay = 500 * time.Millisecond // 读取事件失败后的重试延迟
+	streamIdleExpire = 10 * time.Minute       // 消费者无待确认事件且空闲超出该时长后将被移出消费组
+)
+
+var _ eventbus.AckEventbus = &StreamEventbus{}
+
+// StreamEventbus 基于Redis Stream的事件总线
+// 事件在处理器处理成功后才会被确认，未确认的事件超出重试间隔后将被重新投递，超出重试次数后将被转移至死信队列，
+// 死信队列同样是一个Stream，其主题为原主题加上.dead后缀，可通过订阅该主题对死信事件进行处理
+// Stream超出最大长度后仅会裁剪已被所有消费组确认的事件，未被订阅的死信队列将直接按照最大长度进行裁剪
+// 同一主题的新事件在当前消费者中按照顺序同步处理，重新投递的事件可能与新事件并发处理，订阅选项中的投递模式将被忽略
+// 未通过eventbus.WithGroup指定消费组的订阅将使用WithGroup配置的默认消费组，同一消费组中的事件仅会被其中一个消费者处理，
+// 当前消费者中同一消费组的多个订阅者将轮询处理事件，事件在被选中的订阅者处理成功后确认
+type StreamEventbus struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   *options
+
+	rw      sync.RWMutex
+	streams map[string]*stream
+}
+
+func NewStreamEventbus(opts ...Option) *StreamEventbus {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.prefix == "" {
+		o.prefix = defaultPrefix
+	}
+
+	if o.group == "" {
+		o.group = defaultGroup
+	}
+
+	if o.consumer == "" {
+		o.consumer = xuuid.UUID()
+	}
+
+	if o.retryInterval <= 0 {
+		o.retryInterval = defaultRetryInterval
+	}
+
+	if o.client == nil {
+		o.client = redis.NewUniversalClient(&redis.UniversalOptions{
+			Addrs:      o.addrs,
+			DB:         o.db,
+			Username:   o.username,
+			Password:   o.password,
+			MaxRetries: o.maxRetries,
+		})
+	}
+
+	eb := &StreamEventbus{}
+	eb.ctx, eb.cancel = context.WithCancel(o.ctx)
+	eb.opts = o
+	eb.streams = make(map[string]*stream)
+
+	return eb
+}
+
+// Publish 发布事件
+func (eb *StreamEventbus) Publish(ctx context.Context, topic string, payload interface{}) error {
+	buf, err := serialize(topic, payload)
+	if err != nil {
+		return err
+	}
+
+	return eb.opts.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: eb.buildStreamKey(topic),
+		Values: map[string]interface{}{streamDataField: buf},
+	}).Err()
+}
+
+// Subscribe 订阅事件，处理器执行完毕即视为处理成功
+// Stream无法按照通配规则读取多个主题，订阅通配主题时将返回errors.ErrNotSupportWildcard
+func (eb *StreamEventbus) Subscribe(ctx context.Context, topic string, handler eventbus.EventHandler, opts ...eventbus.SubscribeOption) (string, error) {
+	return eb.subscribe(ctx, topic, eventbus.NewSubscriber(handler, append(opts, eventbus.WithSync())...))
+}
+
+// SubscribeAck 订阅需确认的事件
+func (eb *StreamEventbus) SubscribeAck(ctx context.Context, topic string, handler eventbus.AckHandler, opts ...eventbus.SubscribeOption) (string, error) {
+	return eb.subscribe(ctx, topic, eventbus.NewAckSubscriber(handler, opts...))
+}
+
+// Close 停止监听
+func (eb *StreamEventbus) Close() error {
+	eb.cancel()
+
+	eb.rw.Lock()
+	for _, s := range eb.streams {
+		s.close()
+	}
+	eb.streams = make(map[string]*stream)
+	eb.rw.Unlock()
+
+	return nil
+}
+
+// 订阅事件
+func (eb *StreamEventbus) subscribe(ctx context.Context, topic string, subscriber *eventbus.Subscriber) (string, error) {
+	if eventbus.IsWildcard(topic) {
+		return "", errors.ErrNotSupportWildcard
+	}
+
+	group := subscriber.Group()
+	if group == "" {
+		group = eb.opts.group
+	}
+
+	eb.rw.Lock()
+	defer eb.rw.Unlock()
+
+	name := topic + "@" + group
+
+	s, ok := eb.streams[name]
+	if !ok {
+		s = &stream{eb: eb, topic: topic, group: group, key: eb.buildStreamKey(topic)}
+
+		if err := s.createGroup(ctx); err != nil {
+			return "", err
+		}
+
+		s.ctx, s.cancel = context.WithCancel(eb.ctx)
+		eb.streams[name] = s
+
+		go s.read()
+		go s.retry()
+	}
+
+	s.rw.Lock()
+	s.subscribers = append(s.subscribers[:len(s.subscribers):len(s.subscribers)], subscriber)
+	s.rw.Unlock()
+
+	return subscriber.ID(), nil
+}
+
+// Unsubscribe 取消订阅
+func (eb *StreamEventbus) Unsubscribe(_ context.Context, topic string, id string) error {
+	eb.rw.Lock()
+	defer eb.rw.Unlock()
+
+	for name, s := range eb.streams {
+		if s.topic != topic {
+			continue
+		}
+
+		s.rw.Lock()
+		n := len(s.subscribers)
+		s.subscribers = eventbus.RemoveSubscriber(s.subscribers, id)
+		m := len(s.subscribers)
+		s.rw.Unlock()
+
+		if m == n {
+			continue
+		}
+
+		if m == 0 {
+			s.close()
+			delete(eb.streams, name)
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// build stream key pass by topic
+func (eb *StreamEventbus) buildStreamKey(topic string) string {
+	return eb.opts.prefix + ":stream:" + topic
+}
+
+type stream struct {
+	eb     *StreamEventbus
+	ctx    context.Context
+	cancel context.CancelFunc
+	topic  string
+	group  string
+	key    string
+
+	counter     atomic.Uint64 // 事件计数，用于在消费组中轮询选择订阅者
+	rw          sync.RWMutex
+	subscribers []*eventbus.Subscriber
+}
+
+// 创建消费组，消费组已存在时忽略
+// 消费组从Stream的起始位置开始读取，避免丢失消费组创建前已发布的事件
+func (s *stream) createGroup(ctx context.Context) error {
+	err := s.eb.opts.client.XGroupCreateMkStream(ctx, s.key, s.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	return nil
+}
+
+// 读取新事件
+func (s *stream) read() {
+	for {
+		streams, err := s.eb.opts.client.XReadGroup(s.ctx, &redis.XReadGroupArgs{
+			Group:    s.group,
+			Consumer: s.eb.opts.consumer,
+			Streams:  []string{s.key, ">"},
+			Count:    streamReadCount,
+			Block:    streamBlockTime,
+		}).Result()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+
+			if err != redis.Nil {
+				log.Warnf("read stream event failed, topic: %s err: %v", s.topic, err)
+
+				if strings.HasPrefix(err.Error(), "NOGROUP") {
+					_ = s.createGroup(s.ctx)
+				}
+
+				select {
+				case <-s.ctx.Done():
+					return
+				case <-time.After(streamRetryDelay):
+				}
+			}
+
+			continue
+		}
+
+		for _, xs := range streams {
+			for _, msg := range xs.Messages {
+				s.handle(msg)
+			}
+		}
+	}
+}
+
+// 定时重新投递超时未确认的事件并清理Stream
+func (s *stream) retry() {
+	ticker := time.NewTicker(s.eb.opts.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.redeliver()
+			s.trim(s.key)
+			s.trim(s.eb.buildStreamKey(s.topic + ".dead"))
+			s.purge()
+		}
+	}
+}
+
+// 重新投递超时未确认的事件，直至没有超时未确认的事件为止
+// 认领后的事件空闲时间将被重置，因此每次均从头读取待确认列表
+func (s *stream) redeliver() {
+	for {
+		pendings, err := s.eb.opts.client.XPendingExt(s.ctx, &redis.XPendingExtArgs{
+			Stream: s.key,
+			Group:  s.group,
+			Idle:   s.eb.opts.retryInterval,
+			Start:  "-",
+			End:    "+",
+			Count:  streamReadCount,
+		}).Result()
+		if err != nil {
+			if s.ctx.Err() == nil {
+				log.Warnf("read stream pending event failed, topic: %s err: %v", s.topic, err)
+			}
+			return
+		}
+
+		if len(pendings) == 0 {
+			return
+		}
+
+		ids := make([]string, 0, len(pendings))
+		retries := make(map[string]int64, len(pendings))
+		for _, pending := range pendings {
+			ids = append(ids, pending.ID)
+			retries[pending.ID] = pending.RetryCount
+		}
+
+		msgs, err := s.eb.opts.client.XClaim(s.ctx, &redis.XClaimArgs{
+			Stream:   s.key,
+			Group:    s.group,
+			Consumer: s.eb.opts.consumer,
+			MinIdle:  s.eb.opts.retryInterval,
+			Messages: ids,
+		}).Result()
+		if err != nil {
+			if s.ctx.Err() == nil {
+				log.Warnf("claim stream event failed, topic: %s err: %v", s.topic, err)
+			}
+			return
+		}
+
+		for _, msg := range msgs {
+			count := retries[msg.ID]
+			delete(retries, msg.ID)
+
+			// 首次投递计为一次，认领时计数再次递增
+			if count > int64(s.eb.opts.retryTimes) {
+				s.deadLetter(msg, "exceeded the maximum number of retries")
+			} else {
+				s.handle(msg)
+			}
+		}
+
+		// 未认领到的事件已从Stream中删除，直接确认以将其移出待确认列表
+		for id := range retries {
+			s.ack(id)
+		}
+
+		if len(pendings) < streamReadCount {
+			return
+		}
+	}
+}
+
+// 裁剪Stream，仅裁剪已被所有消费组确认的事件，Stream不存在消费组时按照最大长度进行裁剪
+func (s *stream) trim(key string) {
+	if s.eb.opts.streamMaxLen <= 0 {
+		return
+	}
+
+	n, err := s.eb.opts.client.XLen(s.ctx, key).Result()
+	if err != nil || n <= s.eb.opts.streamMaxLen {
+		return
+	}
+
+	groups, err := s.info(s.ctx, "GROUPS", key)
+	if err != nil {
+		if s.ctx.Err() == nil {
+			log.Warnf("read stream groups failed, key: %s err: %v", key, err)
+		}
+		return
+	}
+
+	if len(groups) == 0 {
+		err = s.eb.opts.client.XTrimMaxLenApprox(s.ctx, key, s.eb.opts.streamMaxLen, 0).Err()
+	} else {
+		var minID string
+
+		for _, group := range groups {
+			id, _ := group["last-delivered-id"].(string)
+
+			if pending, _ := group["pending"].(int64); pending > 0 {
+				name, _ := group["name"].(string)
+
+				summary, err := s.eb.opts.client.XPending(s.ctx, key, name).Result()
+				if err != nil {
+					return
+				}
+
+				id = summary.Lower
+			}
+
+			if minID == "" || compareStreamID(id, minID) < 0 {
+				minID = id
+			}
+		}
+
+		err = s.eb.opts.client.XTrimMinIDApprox(s.ctx, key, minID, 0).Err()
+	}
+	if err != nil && s.ctx.Err() == nil {
+		log.Warnf("trim stream failed, key: %s err: %v", key, err)
+	}
+}
+
+// 移除消费组中无待确认事件且长时间空闲的消费者，其待确认事件已被其他消费者认领
+func (s *stream) purge() {
+	consumers, err := s.info(s.ctx, "CONSUMERS", s.key, s.group)
+	if err != nil {
+		if s.ctx.Err() == nil {
+			log.Warnf("read stream consumers failed, topic: %s err: %v", s.topic, err)
+		}
+		return
+	}
+
+	for _, consumer := range consumers {
+		name, _ := consumer["name"].(string)
+		pending, _ := consumer["pending"].(int64)
+		idle, _ := consumer["idle"].(int64)
+
+		if name == s.eb.opts.consumer || pending > 0 || time.Duration(idle)*time.Millisecond < streamIdleExpire {
+			continue
+		}
+
+		if err = s.eb.opts.client.XGroupDelConsumer(s.ctx, s.key, s.group, name).Err(); err != nil {
+			log.Warnf("delete stream consumer failed, topic: %s consumer: %s err: %v", s.topic, name, err)
+		}
+	}
+}
+
+// 停止读取事件，当前消费者无待确认事件时将其移出消费组，否则保留由其他消费者认领
+func (s *stream) close() {
+	s.cancel()
+
+	ctx := context.Background()
+
+	summary, err := s.eb.opts.client.XPending(ctx, s.key, s.group).Result()
+	if err != nil || summary.Consumers[s.eb.opts.consumer] > 0 {
+		return
+	}
+
+	if err = s.eb.opts.client.XGroupDelConsumer(ctx, s.key, s.group, s.eb.opts.consumer).Err(); err != nil {
+		log.Warnf("delete stream consumer failed, topic: %s consumer: %s err: %v", s.topic, s.eb.opts.consumer, err)
+	}
+}
+
+// 读取Stream信息，不同版本的Redis返回的字段不同，因此统一解析为键值对
+func (s *stream) info(ctx context.Context, args ...interface{}) ([]map[string]interface{}, error) {
+	reply, err := s.eb.opts.client.Do(ctx, append([]interface{}{"XINFO"}, args...)...).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0, len(reply))
+	for _, v := range reply {
+		fields, ok := v.([]interface{})
+		if !ok {
+			continue
+		}
+
+		item := make(map[string]interface{}, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			if key, ok := fields[i].(string); ok {
+				item[key] = fields[i+1]
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// 处理事件，轮询选择一个订阅者进行处理，处理成功后确认事件
+func (s *stream) handle(msg redis.XMessage) {
+	data, ok := msg.Values[streamDataField].(string)
+	if !ok {
+		s.deadLetter(msg, "invalid event data")
+		return
+	}
+
+	event, err := deserialize(xconv.Bytes(data))
+	if err != nil {
+		s.deadLetter(msg, "invalid event data")
+		return
+	}
+
+	s.rw.RLock()
+	subscribers := s.subscribers
+	s.rw.RUnlock()
+
+	if len(subscribers) == 0 {
+		return
+	}
+
+	subscriber := subscribers[s.counter.Add(1)%uint64(len(subscribers))]
+
+	if err = subscriber.Handle(event); err != nil {
+		log.Warnf("handle stream event failed, topic: %s id: %s err: %v", s.topic, msg.ID, err)
+		return
+	}
+
+	s.ack(msg.ID)
+}
+
+// 将事件转移至死信队列
+func (s *stream) deadLetter(msg redis.XMessage, reason string) {
+	values := make(map[string]interface{}, len(msg.Values)+1)
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+	values[streamErrorField] = reason
+
+	err := s.eb.opts.client.XAdd(s.eb.ctx, &redis.XAddArgs{
+		Stream: s.eb.buildStreamKey(s.topic + ".dead"),
+		Values: values,
+	}).Err()
+	if err != nil {
+		log.Errorf("move stream event to dead letter queue failed, topic: %s id: %s err: %v", s.topic, msg.ID, err)
+		return
+	}
+
+	log.Errorf("stream event moved to dead letter queue, topic: %s id: %s reason: %s", s.topic, msg.ID, reason)
+
+	s.ack(msg.ID)
+}
+
+// 确认事件
+func (s *stream) ack(id string) {
+	if err := s.eb.opts.client.XAck(s.eb.ctx, s.key, s.group, id).Err(); err != nil {
+		log.Warnf("ack stream event failed, topic: %s id: %s err: %v", s.topic, id, err)
+	}
+}
+
+// 比较Stream事件ID的大小
+func compareStreamID(a, b string) int {
+	ams, aseq := parseStreamID(a)
+	bms, bseq := parseStreamID(b)
+
+	switch {
+	case ams != bms:
+		if ams < bms {
+			return -1
+		}
+		return 1
+	case aseq != bseq:
+		if aseq < bseq {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
+
+// 解析Stream事件ID，格式为毫秒时间戳-序列号
+func parseStreamID(id string) (uint64, uint64) {
+	ms, seq, _ := strings.Cut(id, "-")
+
+	return xconv.Uint64(ms), xconv.Uint64(seq)
+}
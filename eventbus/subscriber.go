@@ -0,0 +1,123 @@
+package eventbus
+
+import (
+	"github.com/dobyte/due/v2/task"
+	"github.com/dobyte/due/v2/utils/xuuid"
+	"sync"
+)
+
+// Subscriber 事件订阅者
+type Subscriber struct {
+	id         string
+	handler    EventHandler
+	ackHandler AckHandler
+	mode       DeliveryMode
+	group      string
+	queue      chan *Event
+	once       sync.Once
+	done       chan struct{}
+}
+
+// NewSubscriber 创建事件订阅者
+func NewSubscriber(handler EventHandler, opts ...SubscribeOption) *Subscriber {
+	o := defaultSubscribeOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	s := &Subscriber{id: xuuid.UUID(), handler: handler, mode: o.mode, group: o.group}
+
+	if s.mode == OrderedDelivery {
+		s.queue = make(chan *Event, o.queueSize)
+		s.done = make(chan struct{})
+		go s.consume()
+	}
+
+	return s
+}
+
+// NewAckSubscriber 创建需确认的事件订阅者，需确认的事件订阅者仅支持通过Handle同步处理事件，订阅选项中的投递模式将被忽略
+func NewAckSubscriber(handler AckHandler, opts ...SubscribeOption) *Subscriber {
+	o := defaultSubscribeOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Subscriber{id: xuuid.UUID(), ackHandler: handler, mode: SyncDelivery, group: o.group}
+}
+
+// ID 获取订阅ID
+func (s *Subscriber) ID() string {
+	return s.id
+}
+
+// Group 获取订阅者所属的消费组
+func (s *Subscriber) Group() string {
+	return s.group
+}
+
+// Handle 同步处理事件，返回处理器的处理结果
+func (s *Subscriber) Handle(event *Event) error {
+	if s.ackHandler != nil {
+		return s.ackHandler(event)
+	}
+
+	s.handler(event)
+
+	return nil
+}
+
+// Deliver 投递事件
+func (s *Subscriber) Deliver(event *Event) {
+	switch s.mode {
+	case SyncDelivery:
+		_ = s.Handle(event)
+	case OrderedDelivery:
+		select {
+		case <-s.done:
+		case s.queue <- event:
+		}
+	default:
+		task.AddTask(func() { s.handler(event) })
+	}
+}
+
+// Close 关闭订阅者，顺序投递时将停止处理协程，队列中未处理的事件将被丢弃
+func (s *Subscriber) Close() {
+	if s.mode != OrderedDelivery {
+		return
+	}
+
+	s.once.Do(func() { close(s.done) })
+}
+
+// 顺序处理事件
+func (s *Subscriber) consume() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case event := <-s.queue:
+			s.handler(event)
+		}
+	}
+}
+
+// RemoveSubscriber 移除订阅ID对应的订阅者，返回移除后的订阅者列表
+func RemoveSubscriber(subscribers []*Subscriber, id string) []*Subscriber {
+	for i, s := range subscribers {
+		if s.id != id {
+			continue
+		}
+
+		s.Close()
+
+		list := make([]*Subscriber, 0, len(subscribers)-1)
+		list = append(list, subscribers[:i]...)
+		list = append(list, subscribers[i+1:]...)
+
+		return list
+	}
+
+	return subscribers
+}
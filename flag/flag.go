@@ -15,6 +15,11 @@ func init() {
 	commandLine.parse()
 }
 
+// Values 获取所有命令行参数
+func Values() map[string]string {
+	return commandLine.all()
+}
+
 func Has(key string) bool {
 	return commandLine.has(key)
 }
@@ -158,6 +163,15 @@ func (f *flagSet) parseOne() (bool, error) {
 	return true, nil
 }
 
+func (f *flagSet) all() map[string]string {
+	values := make(map[string]string, len(f.values))
+	for key, val := range f.values {
+		values[key] = val
+	}
+
+	return values
+}
+
 func (f *flagSet) has(key string) bool {
 	_, ok := f.values[key]
 	return ok
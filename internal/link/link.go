@@ -37,6 +37,7 @@ type Options struct {
 	Encryptor       crypto.Encryptor           // 加密器
 	Transporter     transport.Transporter      // 传输器
 	BalanceStrategy dispatcher.BalanceStrategy // 负载均衡策略
+	MultiConn       bool                       // 是否开启多端登录模式，开启后推送给用户的消息将发送至用户绑定的所有网关
 }
 
 func NewLink(opts *Options) *Link {
@@ -346,6 +347,12 @@ func (l *Link) Push(ctx context.Context, args *PushArgs) error {
 		} else {
 			return l.directPush(ctx, args)
 		}
+	case session.Group:
+		if args.GID == "" {
+			return l.groupPush(ctx, args)
+		} else {
+			return l.directPush(ctx, args)
+		}
 	default:
 		return errors.ErrInvalidSessionKind
 	}
@@ -378,6 +385,14 @@ func (l *Link) indirectPush(ctx context.Context, args *PushArgs) error {
 		return err
 	}
 
+	if l.opts.MultiConn {
+		return l.multiPush(ctx, args.Target, &packet.Message{
+			Seq:    args.Message.Seq,
+			Route:  args.Message.Route,
+			Buffer: buffer,
+		})
+	}
+
 	_, err = l.doGateRPC(ctx, args.Target, func(client transport.GateClient) (bool, interface{}, error) {
 		miss, err := client.Push(ctx, session.User, args.Target, &packet.Message{
 			Seq:    args.Message.Seq,
@@ -390,6 +405,120 @@ func (l *Link) indirectPush(ctx context.Context, args *PushArgs) error {
 	return err
 }
 
+// 多端登录模式下推送消息，推送至用户绑定的所有网关，已下线的网关将被跳过且不影响其他网关的推送
+func (l *Link) multiPush(ctx context.Context, uid int64, message *packet.Message) error {
+	gates, err := l.locateGates(ctx, []int64{uid})
+	if err != nil {
+		return err
+	}
+
+	if len(gates) == 0 {
+		return errors.ErrNotFoundUserLocation
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		total int64
+		errs  []error
+	)
+
+	for gid := range gates {
+		wg.Add(1)
+
+		go func(gid string) {
+			defer wg.Done()
+
+			client, err := l.getGateClientByGID(gid)
+			if err != nil {
+				if err != errors.ErrNotFoundEndpoint {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+				return
+			}
+
+			miss, err := client.Push(ctx, session.User, uid, message)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case miss:
+			case err != nil:
+				errs = append(errs, err)
+			default:
+				total++
+			}
+		}(gid)
+	}
+
+	wg.Wait()
+
+	if total > 0 {
+		return nil
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return errors.ErrNotFoundSession
+}
+
+// 推送分组消息，分组中的用户可能分布在多个网关上，因此推送至所有网关
+func (l *Link) groupPush(ctx context.Context, args *PushArgs) error {
+	buffer, err := l.toBuffer(args.Message.Data, true)
+	if err != nil {
+		return err
+	}
+
+	message := &packet.Message{
+		Seq:    args.Message.Seq,
+		Route:  args.Message.Route,
+		Buffer: buffer,
+	}
+
+	total := int64(0)
+	eg, ctx := errgroup.WithContext(ctx)
+	l.gateDispatcher.IterateEndpoint(func(_ string, ep *endpoint.Endpoint) bool {
+		eg.Go(func() error {
+			client, err := l.opts.Transporter.NewGateClient(ep)
+			if err != nil {
+				return err
+			}
+
+			miss, err := client.Push(ctx, session.Group, args.Target, message)
+			if miss {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			atomic.AddInt64(&total, 1)
+
+			return nil
+		})
+
+		return true
+	})
+
+	err = eg.Wait()
+
+	if total > 0 {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return errors.ErrNotFoundSession
+}
+
 // Multicast 推送组播消息
 func (l *Link) Multicast(ctx context.Context, args *MulticastArgs) (int64, error) {
 	switch args.Kind {
@@ -401,6 +530,12 @@ func (l *Link) Multicast(ctx context.Context, args *MulticastArgs) (int64, error
 		} else {
 			return l.directMulticast(ctx, args)
 		}
+	case session.Group:
+		if args.GID == "" {
+			return l.groupMulticast(ctx, args)
+		} else {
+			return l.directMulticast(ctx, args)
+		}
 	default:
 		return 0, errors.ErrInvalidSessionKind
 	}
@@ -429,8 +564,136 @@ func (l *Link) directMulticast(ctx context.Context, args *MulticastArgs) (int64,
 	})
 }
 
-// 间接推送组播消息
+// 间接推送组播消息，按用户所在网关对用户进行分组，每个网关仅调用一次组播
 func (l *Link) indirectMulticast(ctx context.Context, args *MulticastArgs) (int64, error) {
+	if len(args.Targets) == 0 {
+		return 0, errors.ErrReceiveTargetEmpty
+	}
+
+	buffer, err := l.toBuffer(args.Message.Data, true)
+	if err != nil {
+		return 0, err
+	}
+
+	message := &packet.Message{
+		Seq:    args.Message.Seq,
+		Route:  args.Message.Route,
+		Buffer: buffer,
+	}
+
+	gates, err := l.locateGates(ctx, args.Targets)
+	if err != nil {
+		return 0, err
+	}
+
+	total, misses, err := l.multicastGates(ctx, gates, message)
+
+	if len(misses) > 0 {
+		// 部分用户未在网关上找到或所在网关推送失败，其定位信息可能已过期，清除缓存并重新定位后重试一次
+		n, retryErr := l.retryMulticast(ctx, gates, misses, message)
+		total += n
+		err = errors.Join(err, retryErr)
+	}
+
+	if total > 0 {
+		return total, nil
+	}
+
+	return 0, err
+}
+
+// 重新定位未在原网关上找到或原网关推送失败的用户并重试组播，仍定位到原网关上的用户不再重试
+func (l *Link) retryMulticast(ctx context.Context, tried map[string][]int64, misses []int64, message *packet.Message) (int64, error) {
+	for _, uid := range misses {
+		l.gateSource.Delete(uid)
+	}
+
+	located, err := l.locateGates(ctx, misses)
+	if err != nil {
+		return 0, err
+	}
+
+	gates := make(map[string][]int64, len(located))
+	for gid, uids := range located {
+		skips := make(map[int64]struct{}, len(tried[gid]))
+		for _, uid := range tried[gid] {
+			skips[uid] = struct{}{}
+		}
+
+		for _, uid := range uids {
+			if _, ok := skips[uid]; !ok {
+				gates[gid] = append(gates[gid], uid)
+			}
+		}
+	}
+
+	if len(gates) == 0 {
+		return 0, nil
+	}
+
+	total, _, err := l.multicastGates(ctx, gates, message)
+
+	return total, err
+}
+
+// 按网关推送组播消息，单个网关推送失败不影响其他网关
+// 返回推送成功的数量、未全部推送成功或推送失败的网关上的用户以及各网关的错误
+func (l *Link) multicastGates(ctx context.Context, gates map[string][]int64, message *packet.Message) (int64, []int64, error) {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		total  int64
+		misses []int64
+		errs   []error
+	)
+
+	for gid, uids := range gates {
+		wg.Add(1)
+
+		go func(gid string, uids []int64) {
+			defer wg.Done()
+
+			n, err := l.multicastGate(ctx, gid, uids, message)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				// 网关推送失败时整组用户均视为未推送成功，以便清除其定位信息后重新定位
+				errs = append(errs, err)
+				misses = append(misses, uids...)
+				return
+			}
+
+			total += n
+
+			if n < int64(len(uids)) {
+				misses = append(misses, uids...)
+			}
+		}(gid, uids)
+	}
+
+	wg.Wait()
+
+	return total, misses, errors.Join(errs...)
+}
+
+// 推送组播消息至指定网关
+func (l *Link) multicastGate(ctx context.Context, gid string, uids []int64, message *packet.Message) (int64, error) {
+	client, err := l.getGateClientByGID(gid)
+	if err != nil {
+		return 0, err
+	}
+
+	return client.Multicast(ctx, session.User, uids, message)
+}
+
+// 推送分组组播消息，推送至所有网关
+func (l *Link) groupMulticast(ctx context.Context, args *MulticastArgs) (int64, error) {
+	if len(args.Targets) == 0 {
+		return 0, errors.ErrReceiveTargetEmpty
+	}
+
 	buffer, err := l.toBuffer(args.Message.Data, true)
 	if err != nil {
 		return 0, err
@@ -444,22 +707,25 @@ func (l *Link) indirectMulticast(ctx context.Context, args *MulticastArgs) (int6
 
 	total := int64(0)
 	eg, ctx := errgroup.WithContext(ctx)
-	for _, target := range args.Targets {
-		func(target int64) {
-			eg.Go(func() error {
-				_, err := l.doGateRPC(ctx, target, func(client transport.GateClient) (bool, interface{}, error) {
-					miss, err := client.Push(ctx, session.User, target, message)
-					return miss, nil, err
-				})
-				if err != nil {
-					return err
-				}
+	l.gateDispatcher.IterateEndpoint(func(_ string, ep *endpoint.Endpoint) bool {
+		eg.Go(func() error {
+			client, err := l.opts.Transporter.NewGateClient(ep)
+			if err != nil {
+				return err
+			}
 
-				atomic.AddInt64(&total, 1)
-				return nil
-			})
-		}(target)
-	}
+			n, err := client.Multicast(ctx, session.Group, args.Targets, message)
+			if err != nil {
+				return err
+			}
+
+			atomic.AddInt64(&total, n)
+
+			return nil
+		})
+
+		return true
+	})
 
 	err = eg.Wait()
 
@@ -467,7 +733,7 @@ func (l *Link) indirectMulticast(ctx context.Context, args *MulticastArgs) (int6
 		return total, nil
 	}
 
-	return 0, err
+	return total, err
 }
 
 // Broadcast 推送广播消息
@@ -574,8 +840,40 @@ func (l *Link) directDisconnect(ctx context.Context, gid string, kind session.Ki
 	return err
 }
 
-// 间接断开连接
+// 间接断开连接，多端登录模式下断开用户在所有网关上的连接
 func (l *Link) indirectDisconnect(ctx context.Context, uid int64, isForce bool) error {
+	if l.opts.MultiConn {
+		gates, err := l.locateGates(ctx, []int64{uid})
+		if err != nil {
+			return err
+		}
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs []error
+		)
+
+		// 已下线的网关上不存在用户连接，直接跳过
+		for gid := range gates {
+			wg.Add(1)
+
+			go func(gid string) {
+				defer wg.Done()
+
+				if err := l.directDisconnect(ctx, gid, session.User, uid, isForce); err != nil && err != errors.ErrNotFoundEndpoint {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}(gid)
+		}
+
+		wg.Wait()
+
+		return errors.Join(errs...)
+	}
+
 	_, err := l.doGateRPC(ctx, uid, func(client transport.GateClient) (bool, interface{}, error) {
 		miss, err := client.Disconnect(ctx, session.User, uid, isForce)
 		return miss, nil, err
@@ -584,6 +882,169 @@ func (l *Link) indirectDisconnect(ctx context.Context, uid int64, isForce bool)
 	return err
 }
 
+// JoinGroup 将用户加入分组，未指定网关时将用户分发至其所在的网关，返回加入分组的用户数
+func (l *Link) JoinGroup(ctx context.Context, args *GroupArgs) (int64, error) {
+	if len(args.UIDs) == 0 {
+		return 0, errors.ErrReceiveTargetEmpty
+	}
+
+	if args.GID != "" {
+		client, err := l.getGateClientByGID(args.GID)
+		if err != nil {
+			return 0, err
+		}
+
+		return client.JoinGroup(ctx, args.Group, args.UIDs)
+	}
+
+	gates, err := l.locateGates(ctx, args.UIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	total := int64(0)
+	eg, ctx := errgroup.WithContext(ctx)
+	for gid, uids := range gates {
+		func(gid string, uids []int64) {
+			eg.Go(func() error {
+				client, err := l.getGateClientByGID(gid)
+				if err != nil {
+					return err
+				}
+
+				n, err := client.JoinGroup(ctx, args.Group, uids)
+				if err != nil {
+					return err
+				}
+
+				atomic.AddInt64(&total, n)
+
+				return nil
+			})
+		}(gid, uids)
+	}
+
+	err = eg.Wait()
+
+	if total > 0 {
+		return total, nil
+	}
+
+	return total, err
+}
+
+// LeaveGroup 将用户移出分组，未指定网关时在所有网关上移出用户，返回移出分组的用户数
+func (l *Link) LeaveGroup(ctx context.Context, args *GroupArgs) (int64, error) {
+	if len(args.UIDs) == 0 {
+		return 0, errors.ErrReceiveTargetEmpty
+	}
+
+	if args.GID != "" {
+		client, err := l.getGateClientByGID(args.GID)
+		if err != nil {
+			return 0, err
+		}
+
+		return client.LeaveGroup(ctx, args.Group, args.UIDs)
+	}
+
+	total := int64(0)
+	eg, ctx := errgroup.WithContext(ctx)
+	l.gateDispatcher.IterateEndpoint(func(_ string, ep *endpoint.Endpoint) bool {
+		eg.Go(func() error {
+			client, err := l.opts.Transporter.NewGateClient(ep)
+			if err != nil {
+				return err
+			}
+
+			n, err := client.LeaveGroup(ctx, args.Group, args.UIDs)
+			if err != nil {
+				return err
+			}
+
+			atomic.AddInt64(&total, n)
+
+			return nil
+		})
+
+		return true
+	})
+
+	err := eg.Wait()
+
+	if total > 0 {
+		return total, nil
+	}
+
+	return total, err
+}
+
+// 批量定位用户所在网关并按网关对用户进行分组，未定位到网关的用户将被忽略
+// 多端登录模式下用户将出现在其绑定的所有网关分组中
+func (l *Link) locateGates(ctx context.Context, uids []int64) (map[string][]int64, error) {
+	if l.opts.Locator == nil {
+		return nil, errors.ErrNotFoundLocator
+	}
+
+	if l.opts.MultiConn {
+		return l.locateMultiGates(ctx, uids)
+	}
+
+	var (
+		gates  = make(map[string][]int64)
+		misses = make([]int64, 0, len(uids))
+	)
+
+	for _, uid := range uids {
+		if val, ok := l.gateSource.Load(uid); ok {
+			if gid := val.(string); gid != "" {
+				gates[gid] = append(gates[gid], uid)
+				continue
+			}
+		}
+
+		misses = append(misses, uid)
+	}
+
+	if len(misses) == 0 {
+		return gates, nil
+	}
+
+	located, err := l.opts.Locator.LocateGates(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+
+	for uid, gid := range located {
+		l.gateSource.Store(uid, gid)
+		gates[gid] = append(gates[gid], uid)
+	}
+
+	return gates, nil
+}
+
+// 多端登录模式下批量定位用户绑定的所有网关并按网关对用户进行分组
+func (l *Link) locateMultiGates(ctx context.Context, uids []int64) (map[string][]int64, error) {
+	locator, ok := l.opts.Locator.(locate.MultiGateLocator)
+	if !ok {
+		return nil, errors.ErrNotSupportMultiGate
+	}
+
+	located, err := locator.LocateMultiGates(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	gates := make(map[string][]int64)
+	for uid, gids := range located {
+		for _, gid := range gids {
+			gates[gid] = append(gates[gid], uid)
+		}
+	}
+
+	return gates, nil
+}
+
 // Deliver 投递消息给节点处理
 func (l *Link) Deliver(ctx context.Context, args *DeliverArgs) error {
 	arguments := &transport.DeliverArgs{
@@ -642,10 +1103,11 @@ func (l *Link) Trigger(ctx context.Context, args *TriggerArgs) error {
 	}
 
 	arguments := &transport.TriggerArgs{
-		Event: args.Event,
-		GID:   l.opts.GID,
-		CID:   args.CID,
-		UID:   args.UID,
+		Event:   args.Event,
+		GID:     l.opts.GID,
+		CID:     args.CID,
+		UID:     args.UID,
+		Devices: args.Devices,
 	}
 
 	eg, ctx := errgroup.WithContext(ctx)
@@ -845,11 +1307,27 @@ func (l *Link) watchServiceInstance(ctx context.Context, kind string) {
 				l.nodeDispatcher.ReplaceServices(services...)
 			} else {
 				l.gateDispatcher.ReplaceServices(services...)
+				l.purgeGateSource(services)
 			}
 		}
 	}()
 }
 
+// 清除已下线网关的用户来源缓存，网关异常退出时其上的用户不会被解绑，需在此处清除以便重新定位
+func (l *Link) purgeGateSource(services []*registry.ServiceInstance) {
+	alive := make(map[string]struct{}, len(services))
+	for _, service := range services {
+		alive[service.ID] = struct{}{}
+	}
+
+	l.gateSource.Range(func(uid, gid interface{}) bool {
+		if _, ok := alive[gid.(string)]; !ok {
+			l.gateSource.Delete(uid)
+		}
+		return true
+	})
+}
+
 // WatchUserLocate 监听用户定位
 func (l *Link) WatchUserLocate(ctx context.Context, kinds ...string) {
 	if l.opts.Locator == nil {
@@ -17,24 +17,30 @@ type Message struct {
 }
 
 type PushArgs struct {
-	GID     string       // 网关ID，会话类型为用户时可忽略此参数
-	Kind    session.Kind // 会话类型，session.Conn 或 session.User
-	Target  int64        // 会话目标，CID 或 UID
+	GID     string       // 网关ID，会话类型为用户时可忽略此参数；会话类型为分组时为空则推送至所有网关
+	Kind    session.Kind // 会话类型，session.Conn、session.User 或 session.Group
+	Target  int64        // 会话目标，CID、UID 或分组ID
 	Message *Message     // 消息
 }
 
 type MulticastArgs struct {
-	GID     string       // 网关ID，会话类型为用户时可忽略此参数
-	Kind    session.Kind // 会话类型，session.Conn 或 session.User
-	Targets []int64      // 会话目标，CID 或 UID
+	GID     string       // 网关ID，会话类型为用户时可忽略此参数；会话类型为分组时为空则推送至所有网关
+	Kind    session.Kind // 会话类型，session.Conn、session.User 或 session.Group
+	Targets []int64      // 会话目标，CID、UID 或分组ID
 	Message *Message     // 消息
 }
 
 type BroadcastArgs struct {
-	Kind    session.Kind // 会话类型，session.Conn 或 session.User
+	Kind    session.Kind // 会话类型，session.Conn、session.User 或 session.Group
 	Message *Message     // 消息
 }
 
+type GroupArgs struct {
+	GID   string  // 网关ID，为空时根据用户所在网关进行分发
+	Group int64   // 分组ID
+	UIDs  []int64 // 用户ID
+}
+
 type DeliverArgs struct {
 	NID     string      // 接收节点。存在接收节点时，消息会直接投递给接收节点；不存在接收节点时，系统定位用户所在节点，然后投递。
 	CID     int64       // 连接ID
@@ -43,9 +49,10 @@ type DeliverArgs struct {
 }
 
 type TriggerArgs struct {
-	Event int   // 事件
-	CID   int64 // 连接ID
-	UID   int64 // 用户ID
+	Event   int   // 事件
+	CID     int64 // 连接ID
+	UID     int64 // 用户ID
+	Devices int32 // 用户在网关上剩余的连接数，仅多端登录模式下的断开连接事件有效
 }
 
 type DisconnectArgs struct {
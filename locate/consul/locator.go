@@ -0,0 +1,394 @@
+package consul
+
+import (
+	"context"
+	"fmt"
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/locate"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/utils/xuuid"
+	"github.com/hashicorp/consul/api"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	userGateKey     = "%s:locate:user:%d:gate"     // string
+	userNodeKey     = "%s:locate:user:%d:node:%s"  // string
+	clusterEventKey = "%s:locate:cluster:%s:event" // string
+)
+
+const locateBatchSize = 64 // 批量定位时单个事务的最大操作数，不超过consul的事务操作数上限
+
+var _ locate.Locator = &Locator{}
+
+type Locator struct {
+	err      error
+	ctx      context.Context
+	cancel   context.CancelFunc
+	opts     *options
+	watchers sync.Map
+
+	rw        sync.Mutex
+	sessionID string
+}
+
+func NewLocator(opts ...Option) *Locator {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.prefix == "" {
+		o.prefix = defaultPrefix
+	}
+
+	l := &Locator{}
+	l.opts = o
+	l.ctx, l.cancel = context.WithCancel(o.ctx)
+
+	if o.client == nil {
+		config := api.DefaultConfig()
+		if o.addr != "" {
+			config.Address = o.addr
+		}
+
+		o.client, l.err = api.NewClient(config)
+	}
+
+	return l
+}
+
+// LocateGate 定位用户所在网关
+func (l *Locator) LocateGate(ctx context.Context, uid int64) (string, error) {
+	return l.get(ctx, fmt.Sprintf(userGateKey, l.opts.prefix, uid))
+}
+
+// LocateGates 批量定位用户所在网关，通过事务分批读取以减少网络往返
+func (l *Locator) LocateGates(ctx context.Context, uids []int64) (map[int64]string, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	gates := make(map[int64]string, len(uids))
+
+	for i := 0; i < len(uids); i += locateBatchSize {
+		batch := uids[i:]
+		if len(batch) > locateBatchSize {
+			batch = batch[:locateBatchSize]
+		}
+
+		keys := make(map[string]int64, len(batch))
+		ops := make(api.TxnOps, 0, len(batch))
+		for _, uid := range batch {
+			key := fmt.Sprintf(userGateKey, l.opts.prefix, uid)
+			keys[key] = uid
+			ops = append(ops, &api.TxnOp{KV: &api.KVTxnOp{Verb: api.KVGetOrEmpty, Key: key}})
+		}
+
+		ok, res, _, err := l.opts.client.Txn().Txn(ops, (&api.QueryOptions{}).WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, txnError(res)
+		}
+
+		for _, result := range res.Results {
+			if result.KV == nil || len(result.KV.Value) == 0 {
+				continue
+			}
+
+			if uid, ok := keys[result.KV.Key]; ok {
+				gates[uid] = string(result.KV.Value)
+			}
+		}
+	}
+
+	return gates, nil
+}
+
+// LocateNode 定位用户所在节点
+func (l *Locator) LocateNode(ctx context.Context, uid int64, name string) (string, error) {
+	return l.get(ctx, fmt.Sprintf(userNodeKey, l.opts.prefix, uid, name))
+}
+
+// BindGate 绑定网关
+func (l *Locator) BindGate(ctx context.Context, uid int64, gid string) error {
+	err := l.put(ctx, fmt.Sprintf(userGateKey, l.opts.prefix, uid), gid)
+	if err != nil {
+		return err
+	}
+
+	err = l.publish(ctx, locate.BindGate, uid, gid)
+	if err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// BindNode 绑定节点
+func (l *Locator) BindNode(ctx context.Context, uid int64, name, nid string) error {
+	err := l.put(ctx, fmt.Sprintf(userNodeKey, l.opts.prefix, uid, name), nid)
+	if err != nil {
+		return err
+	}
+
+	err = l.publish(ctx, locate.BindNode, uid, nid, name)
+	if err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// UnbindGate 解绑网关
+func (l *Locator) UnbindGate(ctx context.Context, uid int64, gid string) error {
+	ok, err := l.del(ctx, fmt.Sprintf(userGateKey, l.opts.prefix, uid), gid)
+	if err != nil || !ok {
+		return err
+	}
+
+	err = l.publish(ctx, locate.UnbindGate, uid, gid)
+	if err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// UnbindNode 解绑节点
+func (l *Locator) UnbindNode(ctx context.Context, uid int64, name string, nid string) error {
+	ok, err := l.del(ctx, fmt.Sprintf(userNodeKey, l.opts.prefix, uid, name), nid)
+	if err != nil || !ok {
+		return err
+	}
+
+	err = l.publish(ctx, locate.UnbindNode, uid, nid, name)
+	if err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// Watch 监听用户定位变化
+func (l *Locator) Watch(ctx context.Context, kinds ...string) (locate.Watcher, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	key := l.toUniqueKey(kinds...)
+
+	v, ok := l.watchers.Load(key)
+	if ok {
+		return v.(*watcherMgr).fork(), nil
+	}
+
+	w, err := newWatcherMgr(ctx, l, key, kinds...)
+	if err != nil {
+		return nil, err
+	}
+
+	l.watchers.Store(key, w)
+
+	return w.fork(), nil
+}
+
+// Close 关闭定位器，销毁会话以清除当前实例的所有绑定关系
+func (l *Locator) Close() error {
+	if l.err != nil {
+		return l.err
+	}
+
+	l.rw.Lock()
+	sessionID := l.sessionID
+	l.sessionID = ""
+	l.rw.Unlock()
+
+	l.cancel()
+
+	if sessionID != "" {
+		if _, err := l.opts.client.Session().Destroy(sessionID, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 读取值
+func (l *Locator) get(ctx context.Context, key string) (string, error) {
+	if l.err != nil {
+		return "", l.err
+	}
+
+	pair, _, err := l.opts.client.KV().Get(key, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	if pair == nil {
+		return "", nil
+	}
+
+	return string(pair.Value), nil
+}
+
+// 写入值，写入的值由当前实例的会话持有
+// 先删除再加锁，以便覆盖其他实例的会话持有的值
+func (l *Locator) put(ctx context.Context, key, val string) error {
+	if l.err != nil {
+		return l.err
+	}
+
+	sessionID, err := l.session(ctx)
+	if err != nil {
+		return err
+	}
+
+	ops := api.TxnOps{
+		{KV: &api.KVTxnOp{Verb: api.KVDelete, Key: key}},
+		{KV: &api.KVTxnOp{Verb: api.KVLock, Key: key, Value: []byte(val), Session: sessionID}},
+	}
+
+	ok, res, _, err := l.opts.client.Txn().Txn(ops, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return txnError(res)
+	}
+
+	return nil
+}
+
+// 删除值，仅当值与给定值一致时删除
+func (l *Locator) del(ctx context.Context, key, val string) (bool, error) {
+	if l.err != nil {
+		return false, l.err
+	}
+
+	pair, _, err := l.opts.client.KV().Get(key, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+
+	if pair == nil || string(pair.Value) != val {
+		return false, nil
+	}
+
+	ok, _, err := l.opts.client.KV().DeleteCAS(pair, (&api.WriteOptions{}).WithContext(ctx))
+	return ok, err
+}
+
+// 获取当前实例的会话，会话不存在或已失效时重新创建并保持续期
+func (l *Locator) session(ctx context.Context) (string, error) {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	if l.sessionID != "" {
+		return l.sessionID, nil
+	}
+
+	ttl := l.opts.ttl.String()
+
+	sessionID, _, err := l.opts.client.Session().Create(&api.SessionEntry{
+		Name:      fmt.Sprintf("%s:locate:%s", l.opts.prefix, xuuid.UUID()),
+		TTL:       ttl,
+		Behavior:  api.SessionBehaviorDelete,
+		LockDelay: time.Millisecond,
+	}, (&api.WriteOptions{}).WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	l.sessionID = sessionID
+
+	go func() {
+		err := l.opts.client.Session().RenewPeriodic(ttl, sessionID, (&api.WriteOptions{}).WithContext(l.ctx), l.ctx.Done())
+
+		l.rw.Lock()
+		if l.sessionID == sessionID {
+			l.sessionID = ""
+		}
+		l.rw.Unlock()
+
+		if l.ctx.Err() == nil {
+			log.Warnf("session %s renew stopped, the bindings held by it will expire: %v", sessionID, err)
+		}
+	}()
+
+	return sessionID, nil
+}
+
+// 发布事件，同类事件写入同一个键，监听方通过键的修改索引感知新事件
+func (l *Locator) publish(ctx context.Context, typ locate.EventType, uid int64, insID string, insName ...string) error {
+	var (
+		kind string
+		name string
+	)
+	switch typ {
+	case locate.BindGate, locate.UnbindGate:
+		kind = cluster.Gate.String()
+	case locate.BindNode, locate.UnbindNode:
+		kind = cluster.Node.String()
+	}
+
+	if len(insName) > 0 {
+		name = insName[0]
+	}
+
+	msg, err := marshal(&locate.Event{
+		UID:     uid,
+		Type:    typ,
+		InsID:   insID,
+		InsKind: kind,
+		InsName: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(clusterEventKey, l.opts.prefix, kind)
+
+	_, err = l.opts.client.KV().Put(&api.KVPair{Key: key, Value: msg}, (&api.WriteOptions{}).WithContext(ctx))
+
+	return err
+}
+
+func (l *Locator) toUniqueKey(kinds ...string) string {
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+
+	return strings.Join(kinds, "&")
+}
+
+// 事务执行失败
+func txnError(res *api.TxnResponse) error {
+	if res == nil || len(res.Errors) == 0 {
+		return errors.New("consul transaction rolled back")
+	}
+
+	return errors.New(res.Errors[0].What)
+}
+
+func marshal(event *locate.Event) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+func unmarshal(data []byte) (*locate.Event, error) {
+	event := &locate.Event{}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
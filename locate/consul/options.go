@@ -0,0 +1,76 @@
+package consul
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/etc"
+	"github.com/hashicorp/consul/api"
+	"time"
+)
+
+const (
+	defaultAddr   = "127.0.0.1:8500"
+	defaultPrefix = "due"
+	defaultTTL    = "15s"
+)
+
+const (
+	defaultAddrKey   = "etc.locate.consul.addr"
+	defaultPrefixKey = "etc.locate.consul.prefix"
+	defaultTTLKey    = "etc.locate.consul.ttl"
+)
+
+type Option func(o *options)
+
+type options struct {
+	ctx context.Context
+
+	// 客户端连接地址
+	// 内建客户端配置，默认为127.0.0.1:8500
+	addr string
+
+	// 外部客户端
+	// 外部客户端配置，存在外部客户端时，优先使用外部客户端，默认为nil
+	client *api.Client
+
+	// 前缀
+	// key前缀，默认为due
+	prefix string
+
+	// 会话有效期
+	// 绑定关系由当前实例的会话持有，实例异常退出后绑定关系将在会话失效后自动清除，最小为10秒，默认为15秒
+	ttl time.Duration
+}
+
+func defaultOptions() *options {
+	return &options{
+		ctx:    context.Background(),
+		addr:   etc.Get(defaultAddrKey, defaultAddr).String(),
+		prefix: etc.Get(defaultPrefixKey, defaultPrefix).String(),
+		ttl:    etc.Get(defaultTTLKey, defaultTTL).Duration(),
+	}
+}
+
+// WithContext 设置上下文
+func WithContext(ctx context.Context) Option {
+	return func(o *options) { o.ctx = ctx }
+}
+
+// WithAddr 设置客户端连接地址
+func WithAddr(addr string) Option {
+	return func(o *options) { o.addr = addr }
+}
+
+// WithClient 设置外部客户端
+func WithClient(client *api.Client) Option {
+	return func(o *options) { o.client = client }
+}
+
+// WithPrefix 设置前缀
+func WithPrefix(prefix string) Option {
+	return func(o *options) { o.prefix = prefix }
+}
+
+// WithTTL 设置会话有效期
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) { o.ttl = ttl }
+}
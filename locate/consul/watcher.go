@@ -0,0 +1,174 @@
+package consul
+
+import (
+	"context"
+	"fmt"
+	"github.com/dobyte/due/v2/locate"
+	"github.com/dobyte/due/v2/log"
+	"github.com/hashicorp/consul/api"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type watcher struct {
+	idx        int64
+	state      int32
+	ctx        context.Context
+	cancel     context.CancelFunc
+	chEvent    chan []*locate.Event
+	watcherMgr *watcherMgr
+}
+
+func newWatcher(wm *watcherMgr, idx int64) *watcher {
+	w := &watcher{}
+	w.idx = idx
+	w.watcherMgr = wm
+	w.ctx, w.cancel = context.WithCancel(wm.ctx)
+	w.chEvent = make(chan []*locate.Event, 16)
+
+	return w
+}
+
+func (w *watcher) notify(events []*locate.Event) {
+	if atomic.LoadInt32(&w.state) == 0 {
+		return
+	}
+
+	w.chEvent <- events
+}
+
+// Next 返回变动事件列表
+func (w *watcher) Next() ([]*locate.Event, error) {
+	if atomic.LoadInt32(&w.state) == 0 {
+		atomic.StoreInt32(&w.state, 1)
+	}
+
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case events, ok := <-w.chEvent:
+		if !ok {
+			if err := w.ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		return events, nil
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	close(w.chEvent)
+	return w.watcherMgr.recycle(w.idx)
+}
+
+type watcherMgr struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	locator *Locator
+	key     string
+
+	rw       sync.RWMutex
+	idx      int64
+	watchers map[int64]*watcher
+}
+
+func newWatcherMgr(ctx context.Context, l *Locator, key string, kinds ...string) (*watcherMgr, error) {
+	wm := &watcherMgr{}
+	wm.ctx, wm.cancel = context.WithCancel(l.ctx)
+	wm.locator = l
+	wm.watchers = make(map[int64]*watcher)
+	wm.key = key
+
+	for _, kind := range kinds {
+		key := fmt.Sprintf(clusterEventKey, l.opts.prefix, kind)
+
+		pair, meta, err := l.opts.client.KV().Get(key, (&api.QueryOptions{}).WithContext(ctx))
+		if err != nil {
+			wm.cancel()
+			return nil, err
+		}
+
+		var modifyIndex uint64
+		if pair != nil {
+			modifyIndex = pair.ModifyIndex
+		}
+
+		go wm.watch(key, meta.LastIndex, modifyIndex)
+	}
+
+	return wm, nil
+}
+
+// 以阻塞查询的方式监听事件键，仅处理修改索引大于已处理索引的事件
+func (wm *watcherMgr) watch(key string, waitIndex, seenIndex uint64) {
+	for {
+		pair, meta, err := wm.locator.opts.client.KV().Get(key, (&api.QueryOptions{WaitIndex: waitIndex}).WithContext(wm.ctx))
+		if err != nil {
+			if wm.ctx.Err() != nil {
+				return
+			}
+
+			log.Warnf("consul watch event failed: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		// 索引回退时重置阻塞查询索引与已处理索引
+		if meta.LastIndex < waitIndex {
+			waitIndex, seenIndex = 0, 0
+		} else {
+			waitIndex = meta.LastIndex
+		}
+
+		if pair == nil || pair.ModifyIndex <= seenIndex {
+			continue
+		}
+
+		seenIndex = pair.ModifyIndex
+
+		event, err := unmarshal(pair.Value)
+		if err != nil {
+			log.Errorf("invalid payload, %s", string(pair.Value))
+			continue
+		}
+
+		wm.broadcast(event)
+	}
+}
+
+func (wm *watcherMgr) fork() locate.Watcher {
+	wm.rw.Lock()
+	defer wm.rw.Unlock()
+
+	w := newWatcher(wm, atomic.AddInt64(&wm.idx, 1))
+	wm.watchers[w.idx] = w
+
+	return w
+}
+
+func (wm *watcherMgr) recycle(idx int64) error {
+	wm.rw.Lock()
+	defer wm.rw.Unlock()
+
+	delete(wm.watchers, idx)
+
+	if len(wm.watchers) == 0 {
+		wm.cancel()
+		wm.locator.watchers.Delete(wm.key)
+	}
+
+	return nil
+}
+
+func (wm *watcherMgr) broadcast(events ...*locate.Event) {
+	wm.rw.RLock()
+	defer wm.rw.RUnlock()
+
+	for _, w := range wm.watchers {
+		w.notify(events)
+	}
+}
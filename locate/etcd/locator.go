@@ -0,0 +1,369 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/locate"
+	"github.com/dobyte/due/v2/log"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/sync/singleflight"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	userGateKey     = "%s:locate:user:%d:gate"     // string
+	userNodeKey     = "%s:locate:user:%d:node:%s"  // string
+	clusterEventKey = "%s:locate:cluster:%s:event" // event
+)
+
+const locateBatchSize = 128 // 批量定位时单个事务的最大操作数，不超过etcd默认的事务操作数上限
+
+var _ locate.Locator = &Locator{}
+
+type Locator struct {
+	err      error
+	ctx      context.Context
+	cancel   context.CancelFunc
+	opts     *options
+	builtin  bool
+	sfg      singleflight.Group // singleFlight
+	watchers sync.Map
+
+	rw      sync.Mutex
+	leaseID clientv3.LeaseID
+}
+
+func NewLocator(opts ...Option) *Locator {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.prefix == "" {
+		o.prefix = defaultPrefix
+	}
+
+	l := &Locator{}
+	l.opts = o
+	l.ctx, l.cancel = context.WithCancel(o.ctx)
+
+	if o.client == nil {
+		l.builtin = true
+		o.client, l.err = clientv3.New(clientv3.Config{
+			Endpoints:   o.addrs,
+			DialTimeout: o.dialTimeout,
+		})
+	}
+
+	return l
+}
+
+// LocateGate 定位用户所在网关
+func (l *Locator) LocateGate(ctx context.Context, uid int64) (string, error) {
+	return l.get(ctx, fmt.Sprintf(userGateKey, l.opts.prefix, uid))
+}
+
+// LocateGates 批量定位用户所在网关，通过事务分批读取以减少网络往返
+func (l *Locator) LocateGates(ctx context.Context, uids []int64) (map[int64]string, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	gates := make(map[int64]string, len(uids))
+
+	for i := 0; i < len(uids); i += locateBatchSize {
+		batch := uids[i:]
+		if len(batch) > locateBatchSize {
+			batch = batch[:locateBatchSize]
+		}
+
+		ops := make([]clientv3.Op, len(batch))
+		for j, uid := range batch {
+			ops[j] = clientv3.OpGet(fmt.Sprintf(userGateKey, l.opts.prefix, uid))
+		}
+
+		res, err := l.opts.client.Txn(ctx).Then(ops...).Commit()
+		if err != nil {
+			return nil, err
+		}
+
+		for j, rsp := range res.Responses {
+			if kvs := rsp.GetResponseRange().Kvs; len(kvs) > 0 && len(kvs[0].Value) > 0 {
+				gates[batch[j]] = string(kvs[0].Value)
+			}
+		}
+	}
+
+	return gates, nil
+}
+
+// LocateNode 定位用户所在节点
+func (l *Locator) LocateNode(ctx context.Context, uid int64, name string) (string, error) {
+	return l.get(ctx, fmt.Sprintf(userNodeKey, l.opts.prefix, uid, name))
+}
+
+// BindGate 绑定网关
+func (l *Locator) BindGate(ctx context.Context, uid int64, gid string) error {
+	err := l.put(ctx, fmt.Sprintf(userGateKey, l.opts.prefix, uid), gid)
+	if err != nil {
+		return err
+	}
+
+	err = l.publish(ctx, locate.BindGate, uid, gid)
+	if err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// BindNode 绑定节点
+func (l *Locator) BindNode(ctx context.Context, uid int64, name, nid string) error {
+	err := l.put(ctx, fmt.Sprintf(userNodeKey, l.opts.prefix, uid, name), nid)
+	if err != nil {
+		return err
+	}
+
+	err = l.publish(ctx, locate.BindNode, uid, nid, name)
+	if err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// UnbindGate 解绑网关
+func (l *Locator) UnbindGate(ctx context.Context, uid int64, gid string) error {
+	ok, err := l.del(ctx, fmt.Sprintf(userGateKey, l.opts.prefix, uid), gid)
+	if err != nil || !ok {
+		return err
+	}
+
+	err = l.publish(ctx, locate.UnbindGate, uid, gid)
+	if err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// UnbindNode 解绑节点
+func (l *Locator) UnbindNode(ctx context.Context, uid int64, name string, nid string) error {
+	ok, err := l.del(ctx, fmt.Sprintf(userNodeKey, l.opts.prefix, uid, name), nid)
+	if err != nil || !ok {
+		return err
+	}
+
+	err = l.publish(ctx, locate.UnbindNode, uid, nid, name)
+	if err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// Watch 监听用户定位变化
+func (l *Locator) Watch(ctx context.Context, kinds ...string) (locate.Watcher, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	key := l.toUniqueKey(kinds...)
+
+	v, ok := l.watchers.Load(key)
+	if ok {
+		return v.(*watcherMgr).fork(), nil
+	}
+
+	w, err := newWatcherMgr(ctx, l, key, kinds...)
+	if err != nil {
+		return nil, err
+	}
+
+	l.watchers.Store(key, w)
+
+	return w.fork(), nil
+}
+
+// Close 关闭定位器，撤销租约以清除当前实例的所有绑定关系
+func (l *Locator) Close() error {
+	if l.err != nil {
+		return l.err
+	}
+
+	l.rw.Lock()
+	leaseID := l.leaseID
+	l.leaseID = clientv3.NoLease
+	l.rw.Unlock()
+
+	if leaseID != clientv3.NoLease {
+		if _, err := l.opts.client.Revoke(context.Background(), leaseID); err != nil {
+			log.Warnf("lease revoke failed: %v", err)
+		}
+	}
+
+	l.cancel()
+
+	if l.builtin {
+		return l.opts.client.Close()
+	}
+
+	return nil
+}
+
+// 读取值
+func (l *Locator) get(ctx context.Context, key string) (string, error) {
+	if l.err != nil {
+		return "", l.err
+	}
+
+	val, err, _ := l.sfg.Do(key, func() (interface{}, error) {
+		res, err := l.opts.client.Get(ctx, key)
+		if err != nil {
+			return "", err
+		}
+
+		if len(res.Kvs) == 0 {
+			return "", nil
+		}
+
+		return string(res.Kvs[0].Value), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return val.(string), nil
+}
+
+// 写入值，写入的值挂载在当前实例的租约上
+func (l *Locator) put(ctx context.Context, key, val string) error {
+	if l.err != nil {
+		return l.err
+	}
+
+	leaseID, err := l.lease(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = l.opts.client.Put(ctx, key, val, clientv3.WithLease(leaseID))
+	return err
+}
+
+// 删除值，仅当值与给定值一致时删除
+func (l *Locator) del(ctx context.Context, key, val string) (bool, error) {
+	if l.err != nil {
+		return false, l.err
+	}
+
+	res, err := l.opts.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.Value(key), "=", val)).
+		Then(clientv3.OpDelete(key)).
+		Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return res.Succeeded, nil
+}
+
+// 获取当前实例的租约，租约不存在或已失效时重新申请并保持续约
+func (l *Locator) lease(ctx context.Context) (clientv3.LeaseID, error) {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	if l.leaseID != clientv3.NoLease {
+		return l.leaseID, nil
+	}
+
+	res, err := l.opts.client.Grant(ctx, int64(l.opts.ttl.Seconds()))
+	if err != nil {
+		return clientv3.NoLease, err
+	}
+
+	chKA, err := l.opts.client.KeepAlive(l.ctx, res.ID)
+	if err != nil {
+		return clientv3.NoLease, err
+	}
+
+	l.leaseID = res.ID
+
+	go func() {
+		for range chKA {
+			// drain keepalive responses
+		}
+
+		l.rw.Lock()
+		if l.leaseID == res.ID {
+			l.leaseID = clientv3.NoLease
+		}
+		l.rw.Unlock()
+
+		if l.ctx.Err() == nil {
+			log.Warnf("lease %d keepalive stopped, the bindings attached to it will expire", res.ID)
+		}
+	}()
+
+	return res.ID, nil
+}
+
+// 发布事件
+func (l *Locator) publish(ctx context.Context, typ locate.EventType, uid int64, insID string, insName ...string) error {
+	var (
+		kind string
+		name string
+	)
+	switch typ {
+	case locate.BindGate, locate.UnbindGate:
+		kind = cluster.Gate.String()
+	case locate.BindNode, locate.UnbindNode:
+		kind = cluster.Node.String()
+	}
+
+	if len(insName) > 0 {
+		name = insName[0]
+	}
+
+	msg, err := marshal(&locate.Event{
+		UID:     uid,
+		Type:    typ,
+		InsID:   insID,
+		InsKind: kind,
+		InsName: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = l.opts.client.Put(ctx, fmt.Sprintf(clusterEventKey, l.opts.prefix, kind), msg)
+	return err
+}
+
+func (l *Locator) toUniqueKey(kinds ...string) string {
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+
+	return strings.Join(kinds, "&")
+}
+
+func marshal(event *locate.Event) (string, error) {
+	buf, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func unmarshal(data []byte) (*locate.Event, error) {
+	event := &locate.Event{}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
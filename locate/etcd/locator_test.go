@@ -0,0 +1,112 @@
+package etcd_test
+
+import (
+	"context"
+	"github.com/dobyte/due/locate/etcd/v2"
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/locate"
+	"github.com/dobyte/due/v2/utils/xuuid"
+	"testing"
+	"time"
+)
+
+var locator = etcd.NewLocator(
+	etcd.WithAddrs("127.0.0.1:2379"),
+)
+
+func TestLocator_BindGate(t *testing.T) {
+	ctx := context.Background()
+	gid := xuuid.UUID()
+
+	if err := locator.BindGate(ctx, 1, gid); err != nil {
+		t.Fatal(err)
+	}
+
+	located, err := locator.LocateGate(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if located != gid {
+		t.Fatalf("expected %s, got %s", gid, located)
+	}
+
+	gates, err := locator.LocateGates(ctx, []int64{1, 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(gates) != 1 || gates[1] != gid {
+		t.Fatalf("unexpected gates: %v", gates)
+	}
+
+	if err = locator.UnbindGate(ctx, 1, xuuid.UUID()); err != nil {
+		t.Fatal(err)
+	}
+
+	if located, _ = locator.LocateGate(ctx, 1); located != gid {
+		t.Fatalf("binding should not be removed by another gate")
+	}
+
+	if err = locator.UnbindGate(ctx, 1, gid); err != nil {
+		t.Fatal(err)
+	}
+
+	if located, _ = locator.LocateGate(ctx, 1); located != "" {
+		t.Fatalf("expected no gate, got %s", located)
+	}
+}
+
+func TestLocator_BindNode(t *testing.T) {
+	ctx := context.Background()
+	nid := xuuid.UUID()
+
+	if err := locator.BindNode(ctx, 1, "game", nid); err != nil {
+		t.Fatal(err)
+	}
+
+	located, err := locator.LocateNode(ctx, 1, "game")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if located != nid {
+		t.Fatalf("expected %s, got %s", nid, located)
+	}
+
+	if err = locator.UnbindNode(ctx, 1, "game", nid); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLocator_Watch(t *testing.T) {
+	watcher, err := locator.Watch(context.Background(), cluster.Gate.String(), cluster.Node.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	chEvents := make(chan []*locate.Event, 1)
+	go func() {
+		events, err := watcher.Next()
+		if err != nil {
+			return
+		}
+		chEvents <- events
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	if err = locator.BindGate(context.Background(), 2, xuuid.UUID()); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case events := <-chEvents:
+		if events[0].UID != 2 || events[0].Type != locate.BindGate {
+			t.Fatalf("unexpected event: %+v", events[0])
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("watch event timeout")
+	}
+}
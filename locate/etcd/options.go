@@ -0,0 +1,88 @@
+package etcd
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/etc"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"time"
+)
+
+const (
+	defaultAddr        = "127.0.0.1:2379"
+	defaultDialTimeout = "5s"
+	defaultPrefix      = "due"
+	defaultTTL         = "10s"
+)
+
+const (
+	defaultAddrsKey       = "etc.locate.etcd.addrs"
+	defaultDialTimeoutKey = "etc.locate.etcd.dialTimeout"
+	defaultPrefixKey      = "etc.locate.etcd.prefix"
+	defaultTTLKey         = "etc.locate.etcd.ttl"
+)
+
+type Option func(o *options)
+
+type options struct {
+	ctx context.Context
+
+	// 客户端连接地址
+	// 内建客户端配置，默认为[]string{"127.0.0.1:2379"}
+	addrs []string
+
+	// 客户端拨号超时时间
+	// 内建客户端配置，默认为5秒
+	dialTimeout time.Duration
+
+	// 外部客户端
+	// 外部客户端配置，存在外部客户端时，优先使用外部客户端，默认为nil
+	client *clientv3.Client
+
+	// 前缀
+	// key前缀，默认为due
+	prefix string
+
+	// 租约有效期
+	// 绑定关系挂载在当前实例的租约上，实例异常退出后绑定关系将在租约过期后自动清除，默认为10秒
+	ttl time.Duration
+}
+
+func defaultOptions() *options {
+	return &options{
+		ctx:         context.Background(),
+		addrs:       etc.Get(defaultAddrsKey, []string{defaultAddr}).Strings(),
+		dialTimeout: etc.Get(defaultDialTimeoutKey, defaultDialTimeout).Duration(),
+		prefix:      etc.Get(defaultPrefixKey, defaultPrefix).String(),
+		ttl:         etc.Get(defaultTTLKey, defaultTTL).Duration(),
+	}
+}
+
+// WithContext 设置上下文
+func WithContext(ctx context.Context) Option {
+	return func(o *options) { o.ctx = ctx }
+}
+
+// WithAddrs 设置连接地址
+func WithAddrs(addrs ...string) Option {
+	return func(o *options) { o.addrs = addrs }
+}
+
+// WithDialTimeout 设置拨号超时时间
+func WithDialTimeout(dialTimeout time.Duration) Option {
+	return func(o *options) { o.dialTimeout = dialTimeout }
+}
+
+// WithClient 设置外部客户端
+func WithClient(client *clientv3.Client) Option {
+	return func(o *options) { o.client = client }
+}
+
+// WithPrefix 设置前缀
+func WithPrefix(prefix string) Option {
+	return func(o *options) { o.prefix = prefix }
+}
+
+// WithTTL 设置租约有效期
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) { o.ttl = ttl }
+}
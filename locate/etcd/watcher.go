@@ -0,0 +1,155 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"github.com/dobyte/due/v2/locate"
+	"github.com/dobyte/due/v2/log"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"sync"
+	"sync/atomic"
+)
+
+type watcher struct {
+	idx        int64
+	state      int32
+	ctx        context.Context
+	cancel     context.CancelFunc
+	chEvent    chan []*locate.Event
+	watcherMgr *watcherMgr
+}
+
+func newWatcher(wm *watcherMgr, idx int64) *watcher {
+	w := &watcher{}
+	w.idx = idx
+	w.watcherMgr = wm
+	w.ctx, w.cancel = context.WithCancel(wm.ctx)
+	w.chEvent = make(chan []*locate.Event, 16)
+
+	return w
+}
+
+func (w *watcher) notify(events []*locate.Event) {
+	if atomic.LoadInt32(&w.state) == 0 {
+		return
+	}
+
+	w.chEvent <- events
+}
+
+// Next 返回变动事件列表
+func (w *watcher) Next() ([]*locate.Event, error) {
+	if atomic.LoadInt32(&w.state) == 0 {
+		atomic.StoreInt32(&w.state, 1)
+	}
+
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case events, ok := <-w.chEvent:
+		if !ok {
+			if err := w.ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		return events, nil
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	close(w.chEvent)
+	return w.watcherMgr.recycle(w.idx)
+}
+
+type watcherMgr struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	locator *Locator
+	key     string
+
+	rw       sync.RWMutex
+	idx      int64
+	watchers map[int64]*watcher
+}
+
+func newWatcherMgr(ctx context.Context, l *Locator, key string, kinds ...string) (*watcherMgr, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	wm := &watcherMgr{}
+	wm.ctx, wm.cancel = context.WithCancel(l.ctx)
+	wm.locator = l
+	wm.watchers = make(map[int64]*watcher)
+	wm.key = key
+
+	for _, kind := range kinds {
+		chWatch := l.opts.client.Watch(wm.ctx, fmt.Sprintf(clusterEventKey, l.opts.prefix, kind))
+
+		go func() {
+			for res := range chWatch {
+				if err := res.Err(); err != nil {
+					log.Warnf("etcd watch event failed: %v", err)
+					continue
+				}
+
+				events := make([]*locate.Event, 0, len(res.Events))
+				for _, ev := range res.Events {
+					if ev.Type != clientv3.EventTypePut {
+						continue
+					}
+
+					event, err := unmarshal(ev.Kv.Value)
+					if err != nil {
+						log.Errorf("invalid payload, %s", string(ev.Kv.Value))
+						continue
+					}
+
+					events = append(events, event)
+				}
+
+				if len(events) > 0 {
+					wm.broadcast(events...)
+				}
+			}
+		}()
+	}
+
+	return wm, nil
+}
+
+func (wm *watcherMgr) fork() locate.Watcher {
+	wm.rw.Lock()
+	defer wm.rw.Unlock()
+
+	w := newWatcher(wm, atomic.AddInt64(&wm.idx, 1))
+	wm.watchers[w.idx] = w
+
+	return w
+}
+
+func (wm *watcherMgr) recycle(idx int64) error {
+	wm.rw.Lock()
+	defer wm.rw.Unlock()
+
+	delete(wm.watchers, idx)
+
+	if len(wm.watchers) == 0 {
+		wm.cancel()
+		wm.locator.watchers.Delete(wm.key)
+	}
+
+	return nil
+}
+
+func (wm *watcherMgr) broadcast(events ...*locate.Event) {
+	wm.rw.RLock()
+	defer wm.rw.RUnlock()
+
+	for _, w := range wm.watchers {
+		w.notify(events)
+	}
+}
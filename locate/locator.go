@@ -9,6 +9,7 @@ package locate
 
 import (
 	"context"
+	"time"
 )
 
 type Locator interface {
@@ -24,10 +25,32 @@ type Locator interface {
 	UnbindNode(ctx context.Context, uid int64, name string, nid string) error
 	// LocateGate 定位用户所在网关
 	LocateGate(ctx context.Context, uid int64) (string, error)
+	// LocateGates 批量定位用户所在网关，未定位到网关的用户不会出现在返回结果中
+	LocateGates(ctx context.Context, uids []int64) (map[int64]string, error)
 	// LocateNode 定位用户所在节点
 	LocateNode(ctx context.Context, uid int64, name string) (string, error)
 }
 
+// MultiGateLocator 支持同一用户同时绑定多个网关的定位器，用于多端登录
+type MultiGateLocator interface {
+	Locator
+	// AddGate 添加用户绑定的网关，并将其设为用户最近绑定的网关
+	AddGate(ctx context.Context, uid int64, gid string) error
+	// RemGate 移除用户绑定的网关，移除的网关为用户最近绑定的网关时从剩余网关中重新选取
+	RemGate(ctx context.Context, uid int64, gid string) error
+	// LocateMultiGates 批量定位用户绑定的所有网关，未定位到网关的用户不会出现在返回结果中
+	LocateMultiGates(ctx context.Context, uids []int64) (map[int64][]string, error)
+}
+
+// Refresher 支持绑定关系自动过期的定位器
+// 绑定关系需由所属网关定期刷新，网关异常退出未能解绑时，其上的用户绑定关系将在过期后自动失效
+type Refresher interface {
+	// TTL 获取绑定关系的有效期，为0时绑定关系永不过期
+	TTL() time.Duration
+	// RefreshGate 刷新用户与网关间绑定关系的有效期，仅刷新仍绑定在该网关上的用户
+	RefreshGate(ctx context.Context, gid string, uids []int64) error
+}
+
 type Watcher interface {
 	// Next 返回用户位置列表
 	Next() ([]*Event, error)
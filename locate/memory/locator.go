@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/locate"
+	"sync"
+)
+
+var _ locate.MultiGateLocator = &Locator{}
+
+// Locator 进程内的用户定位器，适用于单元测试、本地开发以及网关、节点、网格运行于同一进程的单机部署
+type Locator struct {
+	rw       sync.RWMutex
+	idx      int64
+	gates    map[int64]string              // 用户ID -> 网关ID
+	multi    map[int64]map[string]struct{} // 用户ID -> 网关ID集合（多端登录）
+	nodes    map[int64]map[string]string   // 用户ID -> 节点名称 -> 节点ID
+	watchers map[int64]*watcher
+}
+
+func NewLocator() *Locator {
+	return &Locator{
+		gates:    make(map[int64]string),
+		multi:    make(map[int64]map[string]struct{}),
+		nodes:    make(map[int64]map[string]string),
+		watchers: make(map[int64]*watcher),
+	}
+}
+
+// LocateGate 定位用户所在网关
+func (l *Locator) LocateGate(ctx context.Context, uid int64) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	l.rw.RLock()
+	defer l.rw.RUnlock()
+
+	return l.gates[uid], nil
+}
+
+// LocateGates 批量定位用户所在网关
+func (l *Locator) LocateGates(ctx context.Context, uids []int64) (map[int64]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	l.rw.RLock()
+	defer l.rw.RUnlock()
+
+	gates := make(map[int64]string, len(uids))
+	for _, uid := range uids {
+		if gid, ok := l.gates[uid]; ok && gid != "" {
+			gates[uid] = gid
+		}
+	}
+
+	return gates, nil
+}
+
+// LocateNode 定位用户所在节点
+func (l *Locator) LocateNode(ctx context.Context, uid int64, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	l.rw.RLock()
+	defer l.rw.RUnlock()
+
+	return l.nodes[uid][name], nil
+}
+
+// BindGate 绑定网关
+func (l *Locator) BindGate(ctx context.Context, uid int64, gid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	l.gates[uid] = gid
+
+	l.broadcast(&locate.Event{UID: uid, Type: locate.BindGate, InsID: gid, InsKind: cluster.Gate.String()})
+
+	return nil
+}
+
+// BindNode 绑定节点
+func (l *Locator) BindNode(ctx context.Context, uid int64, name, nid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	nodes, ok := l.nodes[uid]
+	if !ok {
+		nodes = make(map[string]string)
+		l.nodes[uid] = nodes
+	}
+
+	nodes[name] = nid
+
+	l.broadcast(&locate.Event{UID: uid, Type: locate.BindNode, InsID: nid, InsKind: cluster.Node.String(), InsName: name})
+
+	return nil
+}
+
+// UnbindGate 解绑网关
+func (l *Locator) UnbindGate(ctx context.Context, uid int64, gid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	if oldGID, ok := l.gates[uid]; !ok || oldGID != gid {
+		return nil
+	}
+
+	delete(l.gates, uid)
+
+	l.broadcast(&locate.Event{UID: uid, Type: locate.UnbindGate, InsID: gid, InsKind: cluster.Gate.String()})
+
+	return nil
+}
+
+// UnbindNode 解绑节点
+func (l *Locator) UnbindNode(ctx context.Context, uid int64, name string, nid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	nodes, ok := l.nodes[uid]
+	if !ok {
+		return nil
+	}
+
+	if oldNID, ok := nodes[name]; !ok || oldNID != nid {
+		return nil
+	}
+
+	delete(nodes, name)
+
+	if len(nodes) == 0 {
+		delete(l.nodes, uid)
+	}
+
+	l.broadcast(&locate.Event{UID: uid, Type: locate.UnbindNode, InsID: nid, InsKind: cluster.Node.String(), InsName: name})
+
+	return nil
+}
+
+// AddGate 添加用户绑定的网关
+func (l *Locator) AddGate(ctx context.Context, uid int64, gid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	gates, ok := l.multi[uid]
+	if !ok {
+		gates = make(map[string]struct{})
+		l.multi[uid] = gates
+	}
+
+	gates[gid] = struct{}{}
+	l.gates[uid] = gid
+
+	l.broadcast(&locate.Event{UID: uid, Type: locate.BindGate, InsID: gid, InsKind: cluster.Gate.String()})
+
+	return nil
+}
+
+// RemGate 移除用户绑定的网关
+func (l *Locator) RemGate(ctx context.Context, uid int64, gid string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	gates, ok := l.multi[uid]
+	if !ok {
+		return nil
+	}
+
+	if _, ok = gates[gid]; !ok {
+		return nil
+	}
+
+	delete(gates, gid)
+
+	if len(gates) == 0 {
+		delete(l.multi, uid)
+	}
+
+	if l.gates[uid] == gid {
+		delete(l.gates, uid)
+
+		for other := range gates {
+			l.gates[uid] = other
+			break
+		}
+	}
+
+	l.broadcast(&locate.Event{UID: uid, Type: locate.UnbindGate, InsID: gid, InsKind: cluster.Gate.String()})
+
+	return nil
+}
+
+// LocateMultiGates 批量定位用户绑定的所有网关
+func (l *Locator) LocateMultiGates(ctx context.Context, uids []int64) (map[int64][]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	l.rw.RLock()
+	defer l.rw.RUnlock()
+
+	multi := make(map[int64][]string, len(uids))
+	for _, uid := range uids {
+		gates, ok := l.multi[uid]
+		if !ok {
+			continue
+		}
+
+		list := make([]string, 0, len(gates))
+		for gid := range gates {
+			list = append(list, gid)
+		}
+
+		multi[uid] = list
+	}
+
+	return multi, nil
+}
+
+// Watch 监听用户定位变化
+func (l *Locator) Watch(ctx context.Context, kinds ...string) (locate.Watcher, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	l.idx++
+
+	w := newWatcher(l, l.idx, kinds...)
+	l.watchers[w.idx] = w
+
+	return w, nil
+}
+
+// 通知监听器用户定位发生变化
+func (l *Locator) broadcast(event *locate.Event) {
+	for _, w := range l.watchers {
+		w.notify(event)
+	}
+}
+
+// 回收监听器
+func (l *Locator) recycle(idx int64) {
+	l.rw.Lock()
+	defer l.rw.Unlock()
+
+	delete(l.watchers, idx)
+}
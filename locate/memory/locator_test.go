@@ -0,0 +1,95 @@
+package memory_test
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/locate/memory"
+	"testing"
+)
+
+func TestLocator_Watch(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		locator = memory.NewLocator()
+	)
+
+	// 监听器不应受调用Watch时传入的上下文影响
+	watchCtx, cancel := context.WithCancel(ctx)
+	watcher, err := locator.Watch(watchCtx, cluster.Gate.String(), cluster.Node.String())
+	cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	if err = locator.BindGate(ctx, 1, "gate-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = locator.BindNode(ctx, 1, "game", "node-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := watcher.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	gid, err := locator.LocateGate(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gid != "gate-1" {
+		t.Fatalf("expected gate-1, got %s", gid)
+	}
+}
+
+func TestLocator_MultiGates(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		locator = memory.NewLocator()
+	)
+
+	if err := locator.AddGate(ctx, 1, "gate-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := locator.AddGate(ctx, 1, "gate-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	multi, err := locator.LocateMultiGates(ctx, []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(multi) != 1 || len(multi[1]) != 2 {
+		t.Fatalf("unexpected gates: %v", multi)
+	}
+
+	if err = locator.RemGate(ctx, 1, "gate-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	gid, err := locator.LocateGate(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gid != "gate-1" {
+		t.Fatalf("expected gate-1, got %s", gid)
+	}
+
+	if err = locator.RemGate(ctx, 1, "gate-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if gid, _ = locator.LocateGate(ctx, 1); gid != "" {
+		t.Fatalf("expected no gate, got %s", gid)
+	}
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/locate"
+	"sync"
+)
+
+type watcher struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	locator  *Locator
+	idx      int64
+	kinds    map[string]struct{}
+	chNotify chan struct{}
+
+	mu     sync.Mutex
+	events []*locate.Event
+}
+
+// 监听器的生命周期与定位器一致，仅在调用Stop时停止，不受调用Watch时传入的上下文影响
+func newWatcher(l *Locator, idx int64, kinds ...string) *watcher {
+	w := &watcher{}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.locator = l
+	w.idx = idx
+	w.kinds = make(map[string]struct{}, len(kinds))
+	w.chNotify = make(chan struct{}, 1)
+
+	for _, kind := range kinds {
+		w.kinds[kind] = struct{}{}
+	}
+
+	return w
+}
+
+// 通知用户定位变化事件，未被读取的事件将被累积并在下次读取时一并返回
+func (w *watcher) notify(event *locate.Event) {
+	if _, ok := w.kinds[event.InsKind]; !ok {
+		return
+	}
+
+	w.mu.Lock()
+	w.events = append(w.events, event)
+	w.mu.Unlock()
+
+	select {
+	case w.chNotify <- struct{}{}:
+	default:
+	}
+}
+
+// Next 返回用户位置列表
+func (w *watcher) Next() ([]*locate.Event, error) {
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case <-w.chNotify:
+		w.mu.Lock()
+		events := w.events
+		w.events = nil
+		w.mu.Unlock()
+
+		return events, nil
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	w.locator.recycle(w.idx)
+
+	return nil
+}
@@ -8,18 +8,55 @@ import (
 	"github.com/dobyte/due/v2/log"
 	"github.com/go-redis/redis/v8"
 	"golang.org/x/sync/singleflight"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
 	userGateKey     = "%s:locate:user:%d:gate"     // string
+	userGatesKey    = "%s:locate:user:%d:gates"    // sorted set，分值为绑定关系的过期时间（毫秒）
 	userNodeKey     = "%s:locate:user:%d:node"     // hash
 	clusterEventKey = "%s:locate:cluster:%s:event" // channel
 )
 
-var _ locate.Locator = &Locator{}
+const locateBatchSize = 1000 // 批量定位时单个管道的最大命令数
+
+// 替换用户最近绑定的网关，仅当用户最近绑定的网关为给定网关时替换，替换网关为空时删除
+// 脚本仅操作单个key，以兼容集群模式
+var replaceGateScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if ARGV[2] == '' then
+	redis.call('DEL', KEYS[1])
+else
+	redis.call('SET', KEYS[1], ARGV[2], 'KEEPTTL')
+end
+return 1
+`)
+
+// 刷新绑定关系有效期，仅当绑定关系属于给定网关时刷新，多端登录时仅刷新给定网关的过期时间
+// 脚本仅操作单个key，以兼容集群模式
+var refreshGateScript = redis.NewScript(`
+if redis.call('TYPE', KEYS[1]).ok == 'zset' then
+	if not redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+		return 0
+	end
+	redis.call('ZADD', KEYS[1], 'XX', ARGV[3], ARGV[1])
+elseif redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+`)
+
+var (
+	_ locate.MultiGateLocator = &Locator{}
+	_ locate.Refresher        = &Locator{}
+)
 
 type Locator struct {
 	ctx      context.Context
@@ -74,6 +111,42 @@ func (l *Locator) LocateGate(ctx context.Context, uid int64) (string, error) {
 	return val.(string), nil
 }
 
+// LocateGates 批量定位用户所在网关，通过管道分批读取以减少网络往返
+func (l *Locator) LocateGates(ctx context.Context, uids []int64) (map[int64]string, error) {
+	gates := make(map[int64]string, len(uids))
+
+	for i := 0; i < len(uids); i += locateBatchSize {
+		batch := uids[i:]
+		if len(batch) > locateBatchSize {
+			batch = batch[:locateBatchSize]
+		}
+
+		pipe := l.opts.client.Pipeline()
+		cmds := make([]*redis.StringCmd, len(batch))
+		for j, uid := range batch {
+			cmds[j] = pipe.Get(ctx, fmt.Sprintf(userGateKey, l.opts.prefix, uid))
+		}
+
+		_, _ = pipe.Exec(ctx)
+
+		for j, cmd := range cmds {
+			gid, err := cmd.Result()
+			if err != nil {
+				if err == redis.Nil {
+					continue
+				}
+				return nil, err
+			}
+
+			if gid != "" {
+				gates[batch[j]] = gid
+			}
+		}
+	}
+
+	return gates, nil
+}
+
 // LocateNode 定位用户所在节点
 func (l *Locator) LocateNode(ctx context.Context, uid int64, name string) (string, error) {
 	key := fmt.Sprintf(userNodeKey, l.opts.prefix, uid)
@@ -95,7 +168,7 @@ func (l *Locator) LocateNode(ctx context.Context, uid int64, name string) (strin
 // BindGate 绑定网关
 func (l *Locator) BindGate(ctx context.Context, uid int64, gid string) error {
 	key := fmt.Sprintf(userGateKey, l.opts.prefix, uid)
-	err := l.opts.client.Set(ctx, key, gid, redis.KeepTTL).Err()
+	err := l.opts.client.Set(ctx, key, gid, l.expiration()).Err()
 	if err != nil {
 		return err
 	}
@@ -124,6 +197,180 @@ func (l *Locator) BindNode(ctx context.Context, uid int64, name, nid string) err
 	return nil
 }
 
+// AddGate 添加用户绑定的网关，每个网关的绑定关系独立过期
+func (l *Locator) AddGate(ctx context.Context, uid int64, gid string) error {
+	gatesKey := fmt.Sprintf(userGatesKey, l.opts.prefix, uid)
+
+	pipe := l.opts.client.TxPipeline()
+	pipe.ZAdd(ctx, gatesKey, &redis.Z{Score: l.deadline(), Member: gid})
+	if l.opts.ttl > 0 {
+		pipe.PExpire(ctx, gatesKey, l.opts.ttl)
+	}
+	pipe.Set(ctx, fmt.Sprintf(userGateKey, l.opts.prefix, uid), gid, l.expiration())
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	if err := l.publish(ctx, locate.BindGate, uid, gid); err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// RemGate 移除用户绑定的网关
+func (l *Locator) RemGate(ctx context.Context, uid int64, gid string) error {
+	gatesKey := fmt.Sprintf(userGatesKey, l.opts.prefix, uid)
+
+	n, err := l.opts.client.ZRem(ctx, gatesKey, gid).Result()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	// 使用最近刷新且尚未过期的网关替换用户最近绑定的网关
+	others, err := l.opts.client.ZRevRangeByScore(ctx, gatesKey, &redis.ZRangeBy{
+		Min:   strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Max:   "+inf",
+		Count: 1,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	var other string
+	if len(others) > 0 {
+		other = others[0]
+	}
+
+	key := fmt.Sprintf(userGateKey, l.opts.prefix, uid)
+	if err = replaceGateScript.Run(ctx, l.opts.client, []string{key}, gid, other).Err(); err != nil && err != redis.Nil {
+		return err
+	}
+
+	if err = l.publish(ctx, locate.UnbindGate, uid, gid); err != nil {
+		log.Errorf("location event publish failed: %v", err)
+	}
+
+	return nil
+}
+
+// LocateMultiGates 批量定位用户绑定的所有网关，通过管道分批读取以减少网络往返，读取时将一并清理已过期的网关
+func (l *Locator) LocateMultiGates(ctx context.Context, uids []int64) (map[int64][]string, error) {
+	var (
+		multi = make(map[int64][]string, len(uids))
+		now   = strconv.FormatInt(time.Now().UnixMilli(), 10)
+	)
+
+	for i := 0; i < len(uids); i += locateBatchSize {
+		batch := uids[i:]
+		if len(batch) > locateBatchSize {
+			batch = batch[:locateBatchSize]
+		}
+
+		pipe := l.opts.client.Pipeline()
+		cmds := make([]*redis.StringSliceCmd, len(batch))
+		for j, uid := range batch {
+			key := fmt.Sprintf(userGatesKey, l.opts.prefix, uid)
+			pipe.ZRemRangeByScore(ctx, key, "-inf", "("+now)
+			cmds[j] = pipe.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: now, Max: "+inf"})
+		}
+
+		_, _ = pipe.Exec(ctx)
+
+		for j, cmd := range cmds {
+			gates, err := cmd.Result()
+			if err != nil {
+				if err == redis.Nil {
+					continue
+				}
+				return nil, err
+			}
+
+			if len(gates) > 0 {
+				multi[batch[j]] = gates
+			}
+		}
+	}
+
+	return multi, nil
+}
+
+// TTL 获取网关绑定关系有效期
+func (l *Locator) TTL() time.Duration {
+	return l.opts.ttl
+}
+
+// RefreshGate 刷新用户与网关间绑定关系的有效期，通过管道分批刷新以减少网络往返
+// 管道中通过EVALSHA执行脚本以避免每次传输脚本内容，脚本未被缓存时加载后重新刷新
+func (l *Locator) RefreshGate(ctx context.Context, gid string, uids []int64) error {
+	if l.opts.ttl <= 0 {
+		return nil
+	}
+
+	for i := 0; i < len(uids); i += locateBatchSize {
+		batch := uids[i:]
+		if len(batch) > locateBatchSize {
+			batch = batch[:locateBatchSize]
+		}
+
+		err := l.refreshGate(ctx, gid, batch)
+		if err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT") {
+			if err = refreshGateScript.Load(ctx, l.opts.client).Err(); err != nil {
+				return err
+			}
+
+			err = l.refreshGate(ctx, gid, batch)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// 通过管道刷新一批用户与网关间绑定关系的有效期
+func (l *Locator) refreshGate(ctx context.Context, gid string, uids []int64) error {
+	var (
+		ttl      = l.opts.ttl.Milliseconds()
+		deadline = l.deadline()
+		pipe     = l.opts.client.Pipeline()
+	)
+
+	for _, uid := range uids {
+		refreshGateScript.EvalSha(ctx, pipe, []string{fmt.Sprintf(userGateKey, l.opts.prefix, uid)}, gid, ttl, deadline)
+		refreshGateScript.EvalSha(ctx, pipe, []string{fmt.Sprintf(userGatesKey, l.opts.prefix, uid)}, gid, ttl, deadline)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return err
+	}
+
+	return nil
+}
+
+// 获取网关绑定关系的截止时间（毫秒），未设置有效期时永不过期
+func (l *Locator) deadline() float64 {
+	if l.opts.ttl > 0 {
+		return float64(time.Now().Add(l.opts.ttl).UnixMilli())
+	}
+
+	return math.Inf(1)
+}
+
+// 获取网关绑定关系的过期时间
+func (l *Locator) expiration() time.Duration {
+	if l.opts.ttl > 0 {
+		return l.opts.ttl
+	}
+
+	return redis.KeepTTL
+}
+
 // UnbindGate 解绑网关
 func (l *Locator) UnbindGate(ctx context.Context, uid int64, gid string) error {
 	oldGID, err := l.LocateGate(ctx, uid)
@@ -25,6 +25,134 @@ func TestLocator_BindGate(t *testing.T) {
 	}
 }
 
+func TestLocator_LocateGates(t *testing.T) {
+	ctx := context.Background()
+	gid := xuuid.UUID()
+
+	for i := 1; i <= 6; i++ {
+		if err := locator.BindGate(ctx, int64(i), gid); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gates, err := locator.LocateGates(ctx, []int64{1, 2, 3, 4, 5, 6, 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(gates) != 6 || gates[1] != gid {
+		t.Fatalf("unexpected gates: %v", gates)
+	}
+}
+
+func TestLocator_MultiGates(t *testing.T) {
+	ctx := context.Background()
+	gid1 := xuuid.UUID()
+	gid2 := xuuid.UUID()
+
+	if err := locator.AddGate(ctx, 1, gid1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := locator.AddGate(ctx, 1, gid2); err != nil {
+		t.Fatal(err)
+	}
+
+	multi, err := locator.LocateMultiGates(ctx, []int64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(multi[1]) != 2 {
+		t.Fatalf("unexpected gates: %v", multi)
+	}
+
+	if err = locator.RemGate(ctx, 1, gid2); err != nil {
+		t.Fatal(err)
+	}
+
+	gid, err := locator.LocateGate(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gid != gid1 {
+		t.Fatalf("expected %s, got %s", gid1, gid)
+	}
+
+	if err = locator.RemGate(ctx, 1, gid1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLocator_RefreshGate(t *testing.T) {
+	ctx := context.Background()
+	gid := xuuid.UUID()
+	locator := redis.NewLocator(
+		redis.WithAddrs("127.0.0.1:6379"),
+		redis.WithTTL(time.Second),
+	)
+
+	if err := locator.BindGate(ctx, 1, gid); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(600 * time.Millisecond)
+
+	if err := locator.RefreshGate(ctx, gid, []int64{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(600 * time.Millisecond)
+
+	if located, err := locator.LocateGate(ctx, 1); err != nil || located != gid {
+		t.Fatalf("expected %s, got %s (%v)", gid, located, err)
+	}
+
+	time.Sleep(1200 * time.Millisecond)
+
+	if located, err := locator.LocateGate(ctx, 1); err != nil || located != "" {
+		t.Fatalf("expected expired binding, got %s (%v)", located, err)
+	}
+}
+
+func TestLocator_RefreshMultiGates(t *testing.T) {
+	ctx := context.Background()
+	uid := time.Now().UnixNano()
+	gid1 := xuuid.UUID()
+	gid2 := xuuid.UUID()
+	locator := redis.NewLocator(
+		redis.WithAddrs("127.0.0.1:6379"),
+		redis.WithTTL(time.Second),
+	)
+
+	if err := locator.AddGate(ctx, uid, gid1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := locator.AddGate(ctx, uid, gid2); err != nil {
+		t.Fatal(err)
+	}
+
+	// 仅刷新其中一个网关，另一个网关的绑定关系应独立过期
+	for i := 0; i < 3; i++ {
+		time.Sleep(500 * time.Millisecond)
+
+		if err := locator.RefreshGate(ctx, gid2, []int64{uid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	multi, err := locator.LocateMultiGates(ctx, []int64{uid})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(multi[uid]) != 1 || multi[uid][0] != gid2 {
+		t.Fatalf("expected [%s], got %v", gid2, multi[uid])
+	}
+}
+
 func TestLocator_BindNode(t *testing.T) {
 	for i := 1; i <= 6; i++ {
 		nid := xuuid.UUID()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/dobyte/due/v2/etc"
 	"github.com/go-redis/redis/v8"
+	"time"
 )
 
 const (
@@ -20,6 +21,7 @@ const (
 	defaultPrefixKey     = "etc.locate.redis.prefix"
 	defaultUsernameKey   = "etc.locate.redis.username"
 	defaultPasswordKey   = "etc.locate.redis.password"
+	defaultTTLKey        = "etc.locate.redis.ttl"
 )
 
 type Option func(o *options)
@@ -54,6 +56,10 @@ type options struct {
 	// 前缀
 	// key前缀，默认为due
 	prefix string
+
+	// 网关绑定关系有效期
+	// 为0时绑定关系永不过期，大于0时需由网关定期刷新，默认为0
+	ttl time.Duration
 }
 
 func defaultOptions() *options {
@@ -65,6 +71,7 @@ func defaultOptions() *options {
 		prefix:     etc.Get(defaultPrefixKey, defaultPrefix).String(),
 		username:   etc.Get(defaultUsernameKey).String(),
 		password:   etc.Get(defaultPasswordKey).String(),
+		ttl:        etc.Get(defaultTTLKey).Duration(),
 	}
 }
 
@@ -107,3 +114,8 @@ func WithClient(client redis.UniversalClient) Option {
 func WithPrefix(prefix string) Option {
 	return func(o *options) { o.prefix = prefix }
 }
+
+// WithTTL 设置网关绑定关系有效期
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) { o.ttl = ttl }
+}
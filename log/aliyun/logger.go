@@ -9,6 +9,7 @@ package aliyun
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/aliyun/aliyun-log-go-sdk/producer"
 	"github.com/dobyte/due/v2/log"
@@ -70,7 +71,7 @@ func NewLogger(opts ...Option) *Logger {
 }
 
 func (l *Logger) print(level log.Level, isNeedStack bool, a ...interface{}) {
-	if level < l.opts.level {
+	if level < l.GetLevel() {
 		return
 	}
 
@@ -93,6 +94,12 @@ func (l *Logger) buildLogRaw(e *log.Entity) map[string]string {
 	raw[fieldKeyFile] = e.Caller
 	raw[fieldKeyMsg] = e.Message
 
+	for key, val := range e.Fields {
+		if _, ok := raw[key]; !ok {
+			raw[key] = fmt.Sprint(val)
+		}
+	}
+
 	if len(e.Frames) > 0 {
 		b := l.bufferPool.Get().(*bytes.Buffer)
 		defer func() {
@@ -130,6 +137,31 @@ func (l *Logger) Close() error {
 	return nil
 }
 
+// SetLevel 设置日志级别
+func (l *Logger) SetLevel(level log.Level) {
+	l.logger.(interface{ SetLevel(log.Level) }).SetLevel(level)
+}
+
+// GetLevel 获取日志级别
+func (l *Logger) GetLevel() log.Level {
+	return l.logger.(interface{ GetLevel() log.Level }).GetLevel()
+}
+
+// WithFields 返回附加了日志字段的日志记录器
+func (l *Logger) WithFields(fields log.Fields) log.Logger {
+	return &Logger{
+		opts:       l.opts,
+		producer:   l.producer,
+		bufferPool: sync.Pool{New: func() interface{} { return &bytes.Buffer{} }},
+		logger:     l.logger.(log.Logger).WithFields(fields),
+	}
+}
+
+// WithContext 返回附加了上下文中日志字段的日志记录器
+func (l *Logger) WithContext(ctx context.Context) log.Logger {
+	return l.WithFields(log.FromContext(ctx))
+}
+
 // Print 打印日志，不含堆栈信息
 func (l *Logger) Print(level log.Level, a ...interface{}) {
 	l.print(level, false, a...)
@@ -39,19 +39,7 @@ func newEntityPool(logger *defaultLogger) *EntityPool {
 func (p *EntityPool) build(level Level, isNeedStack bool, a ...interface{}) *Entity {
 	e := p.pool.Get().(*Entity)
 	e.pool = p
-
-	switch level {
-	case DebugLevel:
-		e.Color = gray
-	case WarnLevel:
-		e.Color = yellow
-	case ErrorLevel, FatalLevel, PanicLevel:
-		e.Color = red
-	case InfoLevel:
-		e.Color = blue
-	default:
-		e.Color = blue
-	}
+	e.Color = levelColor(level)
 
 	var msg string
 	if c := len(a); c > 0 {
@@ -75,6 +63,24 @@ func (p *EntityPool) build(level Level, isNeedStack bool, a ...interface{}) *Ent
 		e.Frames = nil
 	}
 
+	if p.logger.leveler.hasModules() {
+		e.pkg = callerPackage(3 + p.logger.opts.callerSkip)
+	}
+
+	return e
+}
+
+// 构建采样汇总日志实体
+func (p *EntityPool) summary(key sampleKey, fields Fields, message string) *Entity {
+	e := p.pool.Get().(*Entity)
+	e.pool = p
+	e.Color = levelColor(key.level)
+	e.Level = key.level
+	e.Time = xtime.Now().Format(p.logger.opts.timeFormat)
+	e.Caller = key.caller
+	e.Message = message
+	e.Fields = fields
+
 	return e
 }
 
@@ -97,7 +103,9 @@ type Entity struct {
 	Time    string
 	Caller  string
 	Message string
+	Fields  Fields
 	Frames  []runtime.Frame
+	pkg     string
 	pool    *EntityPool
 }
 
@@ -107,20 +115,32 @@ func (e *Entity) Free() {
 	e.Time = ""
 	e.Caller = ""
 	e.Message = ""
+	e.Fields = nil
 	e.Frames = nil
+	e.pkg = ""
 	e.pool.pool.Put(e)
 }
 
 func (e *Entity) Log() {
 	defer e.Free()
 
-	if e.Level < e.pool.logger.opts.level {
+	minLevel := e.pool.logger.leveler.effective(e.pkg)
+	if e.Level < minLevel {
+		return
+	}
+
+	if !e.pool.logger.sampler.allow(e, minLevel) {
 		return
 	}
 
+	e.write(minLevel)
+}
+
+// 输出日志
+func (e *Entity) write(minLevel Level) {
 	buffers := make(map[bool][]byte, 2)
 	for _, s := range e.pool.logger.syncers {
-		if !s.enabler(e.Level) {
+		if !s.enabler(e.Level, minLevel) {
 			continue
 		}
 		b, ok := buffers[s.terminal]
@@ -131,3 +151,17 @@ func (e *Entity) Log() {
 		s.writer.Write(b)
 	}
 }
+
+// 获取日志级别对应的终端颜色
+func levelColor(level Level) int {
+	switch level {
+	case DebugLevel:
+		return gray
+	case WarnLevel:
+		return yellow
+	case ErrorLevel, FatalLevel, PanicLevel:
+		return red
+	default:
+		return blue
+	}
+}
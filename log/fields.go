@@ -0,0 +1,55 @@
+package log
+
+import (
+	"context"
+	"sort"
+)
+
+// Fields 日志字段
+type Fields map[string]interface{}
+
+type fieldsCtxKey struct{}
+
+// With 合并日志字段，返回新的日志字段，不会修改原字段
+func (f Fields) With(fields Fields) Fields {
+	if len(fields) == 0 {
+		return f
+	}
+
+	merged := make(Fields, len(f)+len(fields))
+	for k, v := range f {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// Keys 获取排序后的字段名
+func (f Fields) Keys() []string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// NewContext 将日志字段附加到上下文中，上下文中已存在的日志字段将会被合并
+func NewContext(ctx context.Context, fields Fields) context.Context {
+	return context.WithValue(ctx, fieldsCtxKey{}, FromContext(ctx).With(fields))
+}
+
+// FromContext 获取上下文中附加的日志字段
+func FromContext(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+
+	fields, _ := ctx.Value(fieldsCtxKey{}).(Fields)
+
+	return fields
+}
@@ -0,0 +1,51 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type levelPayload struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules,omitempty"`
+}
+
+// LevelHandler 日志级别管理接口
+// GET请求返回当前日志级别及模块日志级别
+// PUT、POST请求通过level参数调整日志级别，同时携带module参数时调整该模块的日志级别，level为none时移除该模块的日志级别
+// 例如：curl -X PUT "http://127.0.0.1:8080/debug/log/level?module=network/tcp&level=debug"
+// 该接口未做鉴权，需由调用方挂载到仅内网可访问的路由上
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			// ignore
+		case http.MethodPut, http.MethodPost:
+			level, module := r.FormValue("level"), r.FormValue("module")
+			lvl := ParseLevel(level)
+
+			if module != "" {
+				SetModuleLevel(module, lvl)
+			} else if lvl != NoneLevel {
+				SetLevel(lvl)
+			} else {
+				http.Error(w, "invalid level: "+level, http.StatusBadRequest)
+				return
+			}
+		default:
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		payload := levelPayload{Level: GetLevel().String()}
+		if modules := ModuleLevels(); len(modules) > 0 {
+			payload.Modules = make(map[string]string, len(modules))
+			for module, level := range modules {
+				payload.Modules[module] = level.String()
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(payload)
+	})
+}
@@ -9,6 +9,7 @@ package log
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"sync"
 )
@@ -51,6 +52,14 @@ func (f *jsonFormatter) format(e *Entity, isTerminal bool) []byte {
 		fmt.Fprintf(b, `,"%s":"%s"`, fieldKeyMsg, e.Message)
 	}
 
+	for _, key := range e.Fields.Keys() {
+		if v, err := json.Marshal(e.Fields[key]); err == nil {
+			fmt.Fprintf(b, `,"%s":%s`, key, v)
+		} else {
+			fmt.Fprintf(b, `,"%s":"%v"`, key, e.Fields[key])
+		}
+	}
+
 	if len(e.Frames) > 0 {
 		fmt.Fprintf(b, `,"%s":[`, fieldKeyStack)
 
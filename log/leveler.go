@@ -0,0 +1,133 @@
+package log
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// 日志包自身的包路径
+var selfPackage = reflect.TypeOf(leveler{}).PkgPath()
+
+// 日志级别控制器，同一日志记录器派生出的日志记录器共享同一个级别控制器
+type leveler struct {
+	level   int32
+	modules atomic.Pointer[map[string]Level]
+}
+
+func newLeveler(level Level, modules map[string]Level) *leveler {
+	l := &leveler{level: int32(level)}
+	l.setModules(modules)
+
+	return l
+}
+
+// 设置日志级别
+func (l *leveler) setLevel(level Level) {
+	atomic.StoreInt32(&l.level, int32(level))
+}
+
+// 获取日志级别
+func (l *leveler) getLevel() Level {
+	return Level(atomic.LoadInt32(&l.level))
+}
+
+// 替换所有模块日志级别
+func (l *leveler) setModules(modules map[string]Level) {
+	m := make(map[string]Level, len(modules))
+	for module, level := range modules {
+		if module = strings.Trim(module, "/"); module != "" && level != NoneLevel {
+			m[module] = level
+		}
+	}
+
+	l.modules.Store(&m)
+}
+
+// 设置模块日志级别，level为NoneLevel时移除模块日志级别
+func (l *leveler) setModuleLevel(module string, level Level) {
+	for {
+		old := l.modules.Load()
+		m := make(map[string]Level, len(*old)+1)
+		for k, v := range *old {
+			m[k] = v
+		}
+
+		if module = strings.Trim(module, "/"); level == NoneLevel {
+			delete(m, module)
+		} else {
+			m[module] = level
+		}
+
+		if l.modules.CompareAndSwap(old, &m) {
+			return
+		}
+	}
+}
+
+// 获取所有模块日志级别
+func (l *leveler) moduleLevels() map[string]Level {
+	modules := *l.modules.Load()
+
+	m := make(map[string]Level, len(modules))
+	for k, v := range modules {
+		m[k] = v
+	}
+
+	return m
+}
+
+// 是否设置了模块日志级别
+func (l *leveler) hasModules() bool {
+	return len(*l.modules.Load()) > 0
+}
+
+// 获取包的有效日志级别，存在多个模块匹配时以最长的模块为准
+func (l *leveler) effective(pkg string) Level {
+	modules := *l.modules.Load()
+	if len(modules) == 0 || pkg == "" {
+		return l.getLevel()
+	}
+
+	var (
+		matched string
+		level   = l.getLevel()
+		path    = "/" + pkg + "/"
+	)
+
+	for module, lvl := range modules {
+		if len(module) > len(matched) && strings.Contains(path, "/"+module+"/") {
+			matched, level = module, lvl
+		}
+	}
+
+	return level
+}
+
+// 获取调用方所在的包路径，日志包自身的调用栈帧将被跳过
+func callerPackage(skip int) string {
+	var pcs [8]uintptr
+
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip+2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if pkg := funcPackage(frame.Function); pkg != selfPackage {
+			return pkg
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// 获取函数所在的包路径
+func funcPackage(fn string) string {
+	slash := strings.LastIndex(fn, "/")
+	if dot := strings.Index(fn[slash+1:], "."); dot >= 0 {
+		return fn[:slash+1+dot]
+	}
+
+	return fn
+}
@@ -1,5 +1,7 @@
 package log
 
+import "context"
+
 var globalLogger Logger
 
 func init() {
@@ -118,6 +120,58 @@ func Panicf(format string, a ...interface{}) {
 	}
 }
 
+// SetLevel 设置日志级别
+func SetLevel(level Level) {
+	if l, ok := globalLogger.(interface{ SetLevel(Level) }); ok {
+		l.SetLevel(level)
+	}
+}
+
+// GetLevel 获取日志级别
+func GetLevel() Level {
+	if l, ok := globalLogger.(interface{ GetLevel() Level }); ok {
+		return l.GetLevel()
+	}
+
+	return NoneLevel
+}
+
+// Enabled 检测调用方所在的包是否输出该级别的日志，可用于避免构建不会被输出的日志内容
+func Enabled(level Level) bool {
+	if l, ok := globalLogger.(interface{ Enabled(Level) bool }); ok {
+		return l.Enabled(level)
+	}
+
+	return level >= GetLevel()
+}
+
+// SetModuleLevel 设置模块日志级别，level为NoneLevel时移除该模块的日志级别
+// 模块以包路径进行匹配，如：network/tcp 将匹配 github.com/dobyte/due/network/tcp/v2 包下输出的日志
+func SetModuleLevel(module string, level Level) {
+	if l, ok := globalLogger.(interface{ SetModuleLevel(string, Level) }); ok {
+		l.SetModuleLevel(module, level)
+	}
+}
+
+// ModuleLevels 获取所有模块日志级别
+func ModuleLevels() map[string]Level {
+	if l, ok := globalLogger.(interface{ ModuleLevels() map[string]Level }); ok {
+		return l.ModuleLevels()
+	}
+
+	return nil
+}
+
+// WithFields 返回附加了日志字段的日志记录器
+func WithFields(fields Fields) Logger {
+	return &wrapper{logger: globalLogger.WithFields(fields)}
+}
+
+// WithContext 返回附加了上下文中日志字段的日志记录器
+func WithContext(ctx context.Context) Logger {
+	return &wrapper{logger: globalLogger.WithContext(ctx)}
+}
+
 // Close 关闭日志
 func Close() {
 	if globalLogger != nil {
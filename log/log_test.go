@@ -2,9 +2,117 @@ package log_test
 
 import (
 	"github.com/dobyte/due/v2/log"
+	"io"
+	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLog(t *testing.T) {
 	log.Info("welcome to due-framework")
 }
+
+func TestWithFields(t *testing.T) {
+	logger, output := captureLogger(t, log.WithFile(""))
+
+	derived := logger.WithFields(log.Fields{"uid": 1, "route": 2})
+	derived.Info("deliver message")
+
+	// 关闭派生的日志记录器不影响原日志记录器
+	if err := derived.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("parent message")
+
+	out := output()
+
+	for _, field := range []string{"uid=1", "route=2", "deliver message", "parent message"} {
+		if !strings.Contains(out, field) {
+			t.Fatalf("%q not found in output:\n%s", field, out)
+		}
+	}
+}
+
+func TestSetModuleLevel(t *testing.T) {
+	defer log.SetLevel(log.GetLevel())
+
+	log.SetLevel(log.WarnLevel)
+	log.SetModuleLevel("due/v2/log_test", log.DebugLevel)
+
+	log.Debug("debug message of module")
+
+	if level := log.GetLevel(); level != log.WarnLevel {
+		t.Fatalf("unexpected level: %s", level)
+	}
+
+	if level, ok := log.ModuleLevels()["due/v2/log_test"]; !ok || level != log.DebugLevel {
+		t.Fatalf("unexpected module levels: %v", log.ModuleLevels())
+	}
+
+	if !log.Enabled(log.DebugLevel) {
+		t.Fatal("debug level should be enabled for the module")
+	}
+
+	log.SetModuleLevel("due/v2/log_test", log.NoneLevel)
+
+	log.Debug("debug message will be ignored")
+
+	if _, ok := log.ModuleLevels()["due/v2/log_test"]; ok {
+		t.Fatalf("module level is not removed: %v", log.ModuleLevels())
+	}
+
+	if log.Enabled(log.DebugLevel) {
+		t.Fatal("debug level should be disabled after removing the module level")
+	}
+}
+
+func TestSampling(t *testing.T) {
+	logger, output := captureLogger(t,
+		log.WithFile(""),
+		log.WithSampling(log.ErrorLevel, log.Sampling{Window: 100 * time.Millisecond, First: 2, Thereafter: 5}),
+	)
+
+	for i := 0; i < 20; i++ {
+		logger.Error("deliver message failed")
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	out := output()
+
+	// 前2条正常输出，之后第7、12、17条各输出一条，其余15条被抑制，窗口结束时输出一条汇总日志
+	if n := strings.Count(out, "deliver message failed"); n != 6 {
+		t.Fatalf("expected 6 logs, got %d:\n%s", n, out)
+	}
+
+	if !strings.Contains(out, "repeated 20 times in 100ms, 15 suppressed") {
+		t.Fatalf("summary log not found:\n%s", out)
+	}
+}
+
+// 创建输出到管道的日志记录器，返回的函数用于结束捕获并获取全部输出
+func captureLogger(t *testing.T, opts ...log.Option) (log.Logger, func() string) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 日志记录器创建时绑定终端输出，需在创建前替换标准输出以捕获日志
+	stdout := os.Stdout
+	os.Stdout = w
+	logger := log.NewLogger(opts...)
+	os.Stdout = stdout
+
+	chOutput := make(chan string, 1)
+	go func() {
+		data, _ := io.ReadAll(r)
+		chOutput <- string(data)
+	}()
+
+	return logger, func() string {
+		_ = w.Close()
+		return <-chOutput
+	}
+}
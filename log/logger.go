@@ -1,11 +1,13 @@
 package log
 
 import (
+	"context"
 	"fmt"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/mode"
 	"io"
 	"os"
-	"sync"
+	"reflect"
 )
 
 type Logger interface {
@@ -37,19 +39,28 @@ type Logger interface {
 	Panic(a ...interface{})
 	// Panicf 打印Panic模板日志
 	Panicf(format string, a ...interface{})
+	// WithFields 返回附加了日志字段的日志记录器
+	WithFields(fields Fields) Logger
+	// WithContext 返回附加了上下文中日志字段的日志记录器
+	WithContext(ctx context.Context) Logger
 	// Close 关闭日志
 	Close() error
 }
 
 type defaultLogger struct {
 	opts       *options
+	leveler    *leveler
+	sampler    *sampler
+	configured *options
 	formatter  formatter
 	syncers    []syncer
-	bufferPool sync.Pool
 	entityPool *EntityPool
+	fields     Fields
+	unwatch    func()
+	derived    bool // 是否为通过WithFields派生的日志记录器
 }
 
-type enabler func(level Level) bool
+type enabler func(level, minLevel Level) bool
 
 type formatter interface {
 	format(e *Entity, isTerminal bool) []byte
@@ -71,6 +82,9 @@ func NewLogger(opts ...Option) *defaultLogger {
 
 	l := &defaultLogger{}
 	l.opts = o
+	l.leveler = newLeveler(o.level, o.modules)
+	l.sampler = newSampler(o.sampling, l.summarize)
+	l.configured = o
 	l.syncers = make([]syncer, 0, 7)
 	l.entityPool = newEntityPool(l)
 
@@ -118,9 +132,65 @@ func NewLogger(opts ...Option) *defaultLogger {
 		})
 	}
 
+	l.unwatch = etc.Watch(func(names ...string) { l.reload(opts...) })
+
 	return l
 }
 
+// 重新加载配置，目前仅日志级别及采样规则支持热更新
+// 仅在配置中的日志级别发生变化时才会生效，以免覆盖运行时通过SetLevel、SetModuleLevel调整的日志级别
+func (l *defaultLogger) reload(opts ...Option) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.level != l.configured.level {
+		l.leveler.setLevel(o.level)
+	}
+
+	if !reflect.DeepEqual(o.modules, l.configured.modules) {
+		l.leveler.setModules(o.modules)
+	}
+
+	if !reflect.DeepEqual(o.sampling, l.configured.sampling) {
+		l.sampler.setRules(o.sampling)
+	}
+
+	l.configured = o
+}
+
+// SetLevel 设置日志级别
+func (l *defaultLogger) SetLevel(level Level) {
+	l.leveler.setLevel(level)
+}
+
+// GetLevel 获取日志级别
+func (l *defaultLogger) GetLevel() Level {
+	return l.leveler.getLevel()
+}
+
+// Enabled 检测调用方所在的包是否输出该级别的日志
+func (l *defaultLogger) Enabled(level Level) bool {
+	var pkg string
+	if l.leveler.hasModules() {
+		pkg = callerPackage(1)
+	}
+
+	return level >= l.leveler.effective(pkg)
+}
+
+// SetModuleLevel 设置模块日志级别，level为NoneLevel时移除该模块的日志级别
+// 模块以包路径进行匹配，如：network/tcp 将匹配 github.com/dobyte/due/network/tcp/v2 包下输出的日志
+func (l *defaultLogger) SetModuleLevel(module string, level Level) {
+	l.leveler.setModuleLevel(module, level)
+}
+
+// ModuleLevels 获取所有模块日志级别
+func (l *defaultLogger) ModuleLevels() map[string]Level {
+	return l.leveler.moduleLevels()
+}
+
 func (l *defaultLogger) buildWriter(level Level) io.Writer {
 	w, err := NewWriter(WriterOptions{
 		Path:    l.opts.file,
@@ -137,14 +207,25 @@ func (l *defaultLogger) buildWriter(level Level) io.Writer {
 }
 
 func (l *defaultLogger) buildEnabler(level Level) enabler {
-	return func(lvl Level) bool {
-		return lvl >= l.opts.level && (level == NoneLevel || (lvl >= level && level >= l.opts.level))
+	return func(lvl, minLevel Level) bool {
+		return lvl >= minLevel && (level == NoneLevel || (lvl >= level && level >= minLevel))
 	}
 }
 
+// 输出采样汇总日志
+func (l *defaultLogger) summarize(key sampleKey, minLevel Level, fields Fields, message string) {
+	e := l.entityPool.summary(key, fields, message)
+	defer e.Free()
+
+	e.write(minLevel)
+}
+
 // BuildEntity 构建日志实体
 func (l *defaultLogger) BuildEntity(level Level, isNeedStack bool, a ...interface{}) *Entity {
-	return l.entityPool.build(level, isNeedStack, a)
+	e := l.entityPool.build(level, isNeedStack, a)
+	e.Fields = l.fields
+
+	return e
 }
 
 // 打印日志
@@ -224,8 +305,35 @@ func (l *defaultLogger) Panicf(format string, a ...interface{}) {
 	l.print(PanicLevel, true, fmt.Sprintf(format, a...))
 }
 
-// Close 关闭日志
+// WithFields 返回附加了日志字段的日志记录器
+// 返回的日志记录器与原日志记录器共享输出及日志级别，关闭派生的日志记录器不会影响原日志记录器
+func (l *defaultLogger) WithFields(fields Fields) Logger {
+	return &defaultLogger{
+		opts:       l.opts,
+		leveler:    l.leveler,
+		sampler:    l.sampler,
+		configured: l.configured,
+		formatter:  l.formatter,
+		syncers:    l.syncers,
+		entityPool: l.entityPool,
+		fields:     l.fields.With(fields),
+		derived:    true,
+	}
+}
+
+// WithContext 返回附加了上下文中日志字段的日志记录器
+func (l *defaultLogger) WithContext(ctx context.Context) Logger {
+	return l.WithFields(FromContext(ctx))
+}
+
+// Close 关闭日志，派生的日志记录器不持有输出，关闭时不做任何处理
 func (l *defaultLogger) Close() (err error) {
+	if l.derived {
+		return
+	}
+
+	l.unwatch()
+
 	for _, s := range l.syncers {
 		w, ok := s.writer.(interface{ Close() error })
 		if !ok {
@@ -0,0 +1,22 @@
+package formatter
+
+import (
+	"github.com/dobyte/due/log/logrus/v2/internal/define"
+	"github.com/sirupsen/logrus"
+	"sort"
+)
+
+// 获取排序后的业务日志字段名，忽略内部标识字段
+func fieldKeys(data logrus.Fields) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		switch key {
+		case define.StackOutFlagField, define.StackFramesFlagField, define.FileOutFlagField:
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
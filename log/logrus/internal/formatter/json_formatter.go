@@ -9,6 +9,7 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/dobyte/due/log/logrus/v2/internal/define"
 	"github.com/sirupsen/logrus"
@@ -60,6 +61,14 @@ func (f *JsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 		fmt.Fprintf(b, `,"%s":"%s"`, fieldKeyMsg, message)
 	}
 
+	for _, key := range fieldKeys(entry.Data) {
+		if v, err := json.Marshal(entry.Data[key]); err == nil {
+			fmt.Fprintf(b, `,"%s":%s`, key, v)
+		} else {
+			fmt.Fprintf(b, `,"%s":"%v"`, key, entry.Data[key])
+		}
+	}
+
 	if _, ok := entry.Data[define.StackOutFlagField]; ok && len(frames) > 0 {
 		fmt.Fprintf(b, `,"%s":[`, fieldKeyStack)
 		for i, frame := range frames {
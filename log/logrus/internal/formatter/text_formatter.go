@@ -75,6 +75,10 @@ func (f *TextFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 		fmt.Fprintf(b, " %s", message)
 	}
 
+	for _, key := range fieldKeys(entry.Data) {
+		fmt.Fprintf(b, " %s=%v", key, entry.Data[key])
+	}
+
 	if _, ok := entry.Data[define.StackOutFlagField]; ok && len(frames) > 0 {
 		fmt.Fprint(b, "\nStack:")
 		for i, frame := range frames {
@@ -8,6 +8,7 @@
 package logrus
 
 import (
+	"context"
 	"github.com/dobyte/due/log/logrus/v2/internal/define"
 	"github.com/dobyte/due/log/logrus/v2/internal/formatter"
 	"github.com/dobyte/due/log/logrus/v2/internal/hook"
@@ -28,6 +29,7 @@ type Logger struct {
 	opts    *options
 	logger  *logrus.Logger
 	writers []io.Writer
+	fields  log.Fields
 }
 
 func NewLogger(opts ...Option) *Logger {
@@ -38,20 +40,7 @@ func NewLogger(opts ...Option) *Logger {
 
 	l := &Logger{opts: o, logger: logrus.New(), writers: make([]io.Writer, 0, 6)}
 
-	switch o.level {
-	case log.DebugLevel:
-		l.logger.SetLevel(logrus.DebugLevel)
-	case log.InfoLevel:
-		l.logger.SetLevel(logrus.InfoLevel)
-	case log.WarnLevel:
-		l.logger.SetLevel(logrus.WarnLevel)
-	case log.ErrorLevel:
-		l.logger.SetLevel(logrus.ErrorLevel)
-	case log.FatalLevel:
-		l.logger.SetLevel(logrus.FatalLevel)
-	case log.PanicLevel:
-		l.logger.SetLevel(logrus.PanicLevel)
-	}
+	l.SetLevel(o.level)
 
 	switch o.format {
 	case log.JsonFormat:
@@ -117,17 +106,17 @@ func (l *Logger) buildWriter(level log.Level) io.Writer {
 func (l *Logger) Print(level log.Level, a ...interface{}) {
 	switch level {
 	case log.DebugLevel:
-		l.logger.Debug(a...)
+		l.entry().Debug(a...)
 	case log.InfoLevel:
-		l.logger.Info(a...)
+		l.entry().Info(a...)
 	case log.WarnLevel:
-		l.logger.Warn(a...)
+		l.entry().Warn(a...)
 	case log.ErrorLevel:
-		l.logger.Error(a...)
+		l.entry().Error(a...)
 	case log.FatalLevel:
-		l.logger.Fatal(a...)
+		l.entry().Fatal(a...)
 	case log.PanicLevel:
-		l.logger.Panic(a...)
+		l.entry().Panic(a...)
 	}
 }
 
@@ -135,78 +124,130 @@ func (l *Logger) Print(level log.Level, a ...interface{}) {
 func (l *Logger) Printf(level log.Level, format string, a ...interface{}) {
 	switch level {
 	case log.DebugLevel:
-		l.logger.Debugf(format, a...)
+		l.entry().Debugf(format, a...)
 	case log.InfoLevel:
-		l.logger.Infof(format, a...)
+		l.entry().Infof(format, a...)
 	case log.WarnLevel:
-		l.logger.Warnf(format, a...)
+		l.entry().Warnf(format, a...)
 	case log.ErrorLevel:
-		l.logger.Errorf(format, a...)
+		l.entry().Errorf(format, a...)
 	case log.FatalLevel:
-		l.logger.Fatalf(format, a...)
+		l.entry().Fatalf(format, a...)
 	case log.PanicLevel:
-		l.logger.Panicf(format, a...)
+		l.entry().Panicf(format, a...)
 	}
 }
 
 // Debug 打印调试日志
 func (l *Logger) Debug(a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Debug(a...)
+	l.entry().WithField(define.StackOutFlagField, true).Debug(a...)
 }
 
 // Debugf 打印调试模板日志
 func (l *Logger) Debugf(format string, a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Debugf(format, a...)
+	l.entry().WithField(define.StackOutFlagField, true).Debugf(format, a...)
 }
 
 // Info 打印信息日志
 func (l *Logger) Info(a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Info(a...)
+	l.entry().WithField(define.StackOutFlagField, true).Info(a...)
 }
 
 // Infof 打印信息模板日志
 func (l *Logger) Infof(format string, a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Infof(format, a...)
+	l.entry().WithField(define.StackOutFlagField, true).Infof(format, a...)
 }
 
 // Warn 打印警告日志
 func (l *Logger) Warn(a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Warn(a...)
+	l.entry().WithField(define.StackOutFlagField, true).Warn(a...)
 }
 
 // Warnf 打印警告模板日志
 func (l *Logger) Warnf(format string, a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Warnf(format, a...)
+	l.entry().WithField(define.StackOutFlagField, true).Warnf(format, a...)
 }
 
 // Error 打印错误日志
 func (l *Logger) Error(a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Error(a...)
+	l.entry().WithField(define.StackOutFlagField, true).Error(a...)
 }
 
 // Errorf 打印错误模板日志
 func (l *Logger) Errorf(format string, a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Errorf(format, a...)
+	l.entry().WithField(define.StackOutFlagField, true).Errorf(format, a...)
 }
 
 // Fatal 打印致命错误日志
 func (l *Logger) Fatal(a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Fatal(a...)
+	l.entry().WithField(define.StackOutFlagField, true).Fatal(a...)
 }
 
 // Fatalf 打印致命错误模板日志
 func (l *Logger) Fatalf(format string, a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Fatalf(format, a...)
+	l.entry().WithField(define.StackOutFlagField, true).Fatalf(format, a...)
 }
 
 // Panic 打印Panic日志
 func (l *Logger) Panic(a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Fatal(a...)
+	l.entry().WithField(define.StackOutFlagField, true).Fatal(a...)
 }
 
 // Panicf 打印Panic模板日志
 func (l *Logger) Panicf(format string, a ...interface{}) {
-	l.logger.WithField(define.StackOutFlagField, true).Fatalf(format, a...)
+	l.entry().WithField(define.StackOutFlagField, true).Fatalf(format, a...)
+}
+
+// WithFields 返回附加了日志字段的日志记录器
+// 派生的日志记录器不持有输出，关闭时不会影响原日志记录器
+func (l *Logger) WithFields(fields log.Fields) log.Logger {
+	return &Logger{opts: l.opts, logger: l.logger, fields: l.fields.With(fields)}
+}
+
+// WithContext 返回附加了上下文中日志字段的日志记录器
+func (l *Logger) WithContext(ctx context.Context) log.Logger {
+	return l.WithFields(log.FromContext(ctx))
+}
+
+// SetLevel 设置日志级别
+func (l *Logger) SetLevel(level log.Level) {
+	switch level {
+	case log.DebugLevel:
+		l.logger.SetLevel(logrus.DebugLevel)
+	case log.InfoLevel:
+		l.logger.SetLevel(logrus.InfoLevel)
+	case log.WarnLevel:
+		l.logger.SetLevel(logrus.WarnLevel)
+	case log.ErrorLevel:
+		l.logger.SetLevel(logrus.ErrorLevel)
+	case log.FatalLevel:
+		l.logger.SetLevel(logrus.FatalLevel)
+	case log.PanicLevel:
+		l.logger.SetLevel(logrus.PanicLevel)
+	}
+}
+
+// GetLevel 获取日志级别
+func (l *Logger) GetLevel() log.Level {
+	switch l.logger.GetLevel() {
+	case logrus.DebugLevel, logrus.TraceLevel:
+		return log.DebugLevel
+	case logrus.InfoLevel:
+		return log.InfoLevel
+	case logrus.WarnLevel:
+		return log.WarnLevel
+	case logrus.ErrorLevel:
+		return log.ErrorLevel
+	case logrus.FatalLevel:
+		return log.FatalLevel
+	default:
+		return log.PanicLevel
+	}
+}
+
+// 构建日志条目
+func (l *Logger) entry() *logrus.Entry {
+	return l.logger.WithFields(logrus.Fields(l.fields))
 }
 
 // Close 关闭日志
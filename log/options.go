@@ -2,6 +2,7 @@ package log
 
 import (
 	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/utils/xconv"
 	"strings"
 	"time"
 )
@@ -31,21 +32,25 @@ const (
 	defaultStdoutKey            = "etc.log.stdout"
 	defaultCallerFullPathKey    = "etc.log.callerFullPath"
 	defaultClassifiedStorageKey = "etc.log.classifiedStorage"
+	defaultModulesKey           = "etc.log.modules"
+	defaultSamplingKey          = "etc.log.sampling"
 )
 
 type options struct {
-	file              string        // 输出的文件路径，有文件路径才会输出到文件，否则只会输出到终端
-	level             Level         // 输出的最低日志级别，默认Info
-	format            Format        // 输出的日志格式，Text或者Json，默认Text
-	stdout            bool          // 是否输出到终端，debug模式下默认输出到终端
-	timeFormat        string        // 时间格式，标准库时间格式，默认2006/01/02 15:04:05.000000
-	stackLevel        Level         // 堆栈的最低输出级别，默认不输出堆栈
-	fileMaxAge        time.Duration // 文件最大留存时间，默认7天
-	fileMaxSize       int64         // 文件最大尺寸限制，单位（MB），默认100MB
-	fileCutRule       CutRule       // 文件切割规则，默认按照天
-	callerSkip        int           // 调用者跳过的层级深度
-	callerFullPath    bool          // 是否启用调用文件全路径，默认短路径
-	classifiedStorage bool          // 是否启用分级存储，默认不分级
+	file              string             // 输出的文件路径，有文件路径才会输出到文件，否则只会输出到终端
+	level             Level              // 输出的最低日志级别，默认Info
+	format            Format             // 输出的日志格式，Text或者Json，默认Text
+	stdout            bool               // 是否输出到终端，debug模式下默认输出到终端
+	timeFormat        string             // 时间格式，标准库时间格式，默认2006/01/02 15:04:05.000000
+	stackLevel        Level              // 堆栈的最低输出级别，默认不输出堆栈
+	fileMaxAge        time.Duration      // 文件最大留存时间，默认7天
+	fileMaxSize       int64              // 文件最大尺寸限制，单位（MB），默认100MB
+	fileCutRule       CutRule            // 文件切割规则，默认按照天
+	callerSkip        int                // 调用者跳过的层级深度
+	callerFullPath    bool               // 是否启用调用文件全路径，默认短路径
+	classifiedStorage bool               // 是否启用分级存储，默认不分级
+	modules           map[string]Level   // 模块日志级别，以包路径匹配模块，优先于全局日志级别
+	sampling          map[Level]Sampling // 日志采样规则，未配置采样规则的日志级别不进行采样
 }
 
 type Option func(o *options)
@@ -122,6 +127,37 @@ func defaultOptions() *options {
 	opts.callerFullPath = etc.Get(defaultCallerFullPathKey, defaultCallerFullPath).Bool()
 	opts.classifiedStorage = etc.Get(defaultClassifiedStorageKey, defaultClassifiedStorage).Bool()
 
+	for module, level := range etc.Get(defaultModulesKey).Map() {
+		if lvl := ParseLevel(xconv.String(level)); lvl != NoneLevel {
+			if opts.modules == nil {
+				opts.modules = make(map[string]Level)
+			}
+			opts.modules[module] = lvl
+		}
+	}
+
+	for level, rule := range etc.Get(defaultSamplingKey).Map() {
+		lvl := ParseLevel(level)
+		if lvl == NoneLevel {
+			continue
+		}
+
+		values, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if opts.sampling == nil {
+			opts.sampling = make(map[Level]Sampling)
+		}
+
+		opts.sampling[lvl] = Sampling{
+			Window:     xconv.Duration(values["window"]),
+			First:      xconv.Int(values["first"]),
+			Thereafter: xconv.Int(values["thereafter"]),
+		}
+	}
+
 	return opts
 }
 
@@ -186,3 +222,21 @@ func WithCallerFullPath(enable bool) Option {
 func WithClassifiedStorage(enable bool) Option {
 	return func(o *options) { o.classifiedStorage = enable }
 }
+
+// WithModuleLevels 设置模块日志级别
+// 模块以包路径进行匹配，如：network/tcp 将匹配 github.com/dobyte/due/network/tcp/v2 包下输出的日志
+func WithModuleLevels(modules map[string]Level) Option {
+	return func(o *options) { o.modules = modules }
+}
+
+// WithSampling 设置日志级别的采样规则
+// 例如：WithSampling(ErrorLevel, Sampling{Window: time.Second, First: 10, Thereafter: 100})
+// 表示每秒内相同的错误日志仅输出前10条，之后每100条输出一条，并在窗口结束时输出被抑制日志数量的汇总日志
+func WithSampling(level Level, sampling Sampling) Option {
+	return func(o *options) {
+		if o.sampling == nil {
+			o.sampling = make(map[Level]Sampling)
+		}
+		o.sampling[level] = sampling
+	}
+}
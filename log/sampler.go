@@ -0,0 +1,102 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampling 日志采样规则
+// 采样窗口内级别、调用位置及内容均相同的日志视为重复日志，前First条正常输出，
+// 之后每Thereafter条输出一条，Thereafter为0时不再输出；窗口结束时输出一条被抑制日志数量的汇总日志
+type Sampling struct {
+	Window     time.Duration // 采样窗口
+	First      int           // 窗口内正常输出的重复日志条数
+	Thereafter int           // 超出First条后，每Thereafter条输出一条
+}
+
+type sampleKey struct {
+	level   Level
+	caller  string
+	message string
+}
+
+type sampleCounter struct {
+	total      int    // 窗口内的日志总数
+	suppressed int    // 窗口内被抑制的日志数
+	minLevel   Level  // 最近一条日志生效的最低日志级别
+	fields     Fields // 最近一条日志的字段
+}
+
+type sampler struct {
+	rules    atomic.Pointer[map[Level]Sampling]
+	mu       sync.Mutex
+	counters map[sampleKey]*sampleCounter
+	emit     func(key sampleKey, minLevel Level, fields Fields, message string)
+}
+
+func newSampler(rules map[Level]Sampling, emit func(key sampleKey, minLevel Level, fields Fields, message string)) *sampler {
+	s := &sampler{counters: make(map[sampleKey]*sampleCounter), emit: emit}
+	s.setRules(rules)
+
+	return s
+}
+
+// 设置采样规则
+func (s *sampler) setRules(rules map[Level]Sampling) {
+	r := make(map[Level]Sampling, len(rules))
+	for level, rule := range rules {
+		if rule.Window > 0 && rule.First >= 0 && rule.Thereafter >= 0 {
+			r[level] = rule
+		}
+	}
+
+	s.rules.Store(&r)
+}
+
+// 检测日志是否允许输出
+func (s *sampler) allow(e *Entity, minLevel Level) bool {
+	rule, ok := (*s.rules.Load())[e.Level]
+	if !ok {
+		return true
+	}
+
+	key := sampleKey{level: e.Level, caller: e.Caller, message: e.Message}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok {
+		c = &sampleCounter{}
+		s.counters[key] = c
+		time.AfterFunc(rule.Window, func() { s.flush(key, rule.Window) })
+	}
+
+	c.total++
+
+	if c.total <= rule.First || (rule.Thereafter > 0 && (c.total-rule.First)%rule.Thereafter == 0) {
+		return true
+	}
+
+	c.suppressed++
+	c.minLevel = minLevel
+	c.fields = e.Fields
+
+	return false
+}
+
+// 结束采样窗口，存在被抑制的日志时输出汇总日志
+func (s *sampler) flush(key sampleKey, window time.Duration) {
+	s.mu.Lock()
+	c, ok := s.counters[key]
+	delete(s.counters, key)
+	s.mu.Unlock()
+
+	if !ok || c.suppressed == 0 {
+		return
+	}
+
+	s.emit(key, c.minLevel, c.fields, fmt.Sprintf("%s (repeated %d times in %s, %d suppressed)", key.message, c.total, window, c.suppressed))
+}
@@ -46,6 +46,10 @@ func (f *textFormatter) format(e *Entity, isTerminal bool) []byte {
 		fmt.Fprint(b, " "+e.Message)
 	}
 
+	for _, key := range e.Fields.Keys() {
+		fmt.Fprintf(b, " %s=%v", key, e.Fields[key])
+	}
+
 	if len(e.Frames) > 0 {
 		fmt.Fprint(b, "\n")
 		fmt.Fprint(b, "Stack:")
@@ -0,0 +1,96 @@
+package log
+
+import "context"
+
+// 全局日志记录器在创建时额外跳过了一层调用栈（即log包中的全局函数），
+// 通过WithFields、WithContext返回的日志记录器会被直接调用，故而使用wrapper补齐这一层调用栈，以保证调用位置的正确性
+type wrapper struct {
+	logger Logger
+}
+
+var _ Logger = &wrapper{}
+
+// Print 打印日志，不含堆栈信息
+func (w *wrapper) Print(level Level, a ...interface{}) {
+	w.logger.Print(level, a...)
+}
+
+// Printf 打印模板日志，不含堆栈信息
+func (w *wrapper) Printf(level Level, format string, a ...interface{}) {
+	w.logger.Printf(level, format, a...)
+}
+
+// Debug 打印调试日志
+func (w *wrapper) Debug(a ...interface{}) {
+	w.logger.Debug(a...)
+}
+
+// Debugf 打印调试模板日志
+func (w *wrapper) Debugf(format string, a ...interface{}) {
+	w.logger.Debugf(format, a...)
+}
+
+// Info 打印信息日志
+func (w *wrapper) Info(a ...interface{}) {
+	w.logger.Info(a...)
+}
+
+// Infof 打印信息模板日志
+func (w *wrapper) Infof(format string, a ...interface{}) {
+	w.logger.Infof(format, a...)
+}
+
+// Warn 打印警告日志
+func (w *wrapper) Warn(a ...interface{}) {
+	w.logger.Warn(a...)
+}
+
+// Warnf 打印警告模板日志
+func (w *wrapper) Warnf(format string, a ...interface{}) {
+	w.logger.Warnf(format, a...)
+}
+
+// Error 打印错误日志
+func (w *wrapper) Error(a ...interface{}) {
+	w.logger.Error(a...)
+}
+
+// Errorf 打印错误模板日志
+func (w *wrapper) Errorf(format string, a ...interface{}) {
+	w.logger.Errorf(format, a...)
+}
+
+// Fatal 打印致命错误日志
+func (w *wrapper) Fatal(a ...interface{}) {
+	w.logger.Fatal(a...)
+}
+
+// Fatalf 打印致命错误模板日志
+func (w *wrapper) Fatalf(format string, a ...interface{}) {
+	w.logger.Fatalf(format, a...)
+}
+
+// Panic 打印Panic日志
+func (w *wrapper) Panic(a ...interface{}) {
+	w.logger.Panic(a...)
+}
+
+// Panicf 打印Panic模板日志
+func (w *wrapper) Panicf(format string, a ...interface{}) {
+	w.logger.Panicf(format, a...)
+}
+
+// WithFields 返回附加了日志字段的日志记录器
+func (w *wrapper) WithFields(fields Fields) Logger {
+	return &wrapper{logger: w.logger.WithFields(fields)}
+}
+
+// WithContext 返回附加了上下文中日志字段的日志记录器
+func (w *wrapper) WithContext(ctx context.Context) Logger {
+	return &wrapper{logger: w.logger.WithContext(ctx)}
+}
+
+// Close 关闭日志
+func (w *wrapper) Close() error {
+	return w.logger.Close()
+}
@@ -0,0 +1,24 @@
+package encoder
+
+import (
+	"github.com/dobyte/due/v2/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// 解析附加的日志字段，忽略堆栈标识字段
+func decodeFields(fields []zapcore.Field) log.Fields {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		if field.Key == StackFlag {
+			continue
+		}
+
+		field.AddTo(enc)
+	}
+
+	return enc.Fields
+}
@@ -8,6 +8,7 @@
 package encoder
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/dobyte/due/log/zap/v2/internal/utils"
 	"github.com/dobyte/due/v2/utils/xconv"
@@ -80,6 +81,15 @@ func (e *JsonEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*b
 
 	line.AppendString(fmt.Sprintf(`,"%s":"%s"`, fieldKeyMsg, utils.Addslashes(strings.TrimSuffix(ent.Message, "\n"))))
 
+	extra := decodeFields(fields)
+	for _, key := range extra.Keys() {
+		if v, err := json.Marshal(extra[key]); err == nil {
+			line.AppendString(fmt.Sprintf(`,"%s":%s`, key, v))
+		} else {
+			line.AppendString(fmt.Sprintf(`,"%s":"%v"`, key, extra[key]))
+		}
+	}
+
 	if stack && ent.Stack != "" {
 		line.AppendString(fmt.Sprintf(`,"%s":[`, fieldKeyStack))
 
@@ -91,6 +91,11 @@ func (e *TextEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*b
 
 	line.AppendString(strings.TrimSuffix(ent.Message, "\n"))
 
+	extra := decodeFields(fields)
+	for _, key := range extra.Keys() {
+		line.AppendString(fmt.Sprintf(" %s=%v", key, extra[key]))
+	}
+
 	if stack && ent.Stack != "" {
 		line.AppendByte('\n')
 		line.AppendString("Stack:\n")
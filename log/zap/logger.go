@@ -8,6 +8,7 @@
 package zap
 
 import (
+	"context"
 	"fmt"
 	"github.com/dobyte/due/log/zap/v2/internal/encoder"
 	"github.com/dobyte/due/v2/log"
@@ -15,6 +16,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"os"
+	"sync/atomic"
 )
 
 var _ log.Logger = NewLogger()
@@ -33,8 +35,11 @@ func init() {
 }
 
 type Logger struct {
-	logger *zap.SugaredLogger
-	opts   *options
+	logger  *zap.SugaredLogger
+	opts    *options
+	level   *int32
+	fields  log.Fields
+	derived bool // 是否为通过WithFields派生的日志记录器
 }
 
 func NewLogger(opts ...Option) *Logger {
@@ -73,7 +78,8 @@ func NewLogger(opts ...Option) *Logger {
 		options = append(options, zap.AddStacktrace(zapcore.PanicLevel), zap.AddCallerSkip(1+o.callerSkip))
 	}
 
-	l := &Logger{opts: o}
+	level := int32(o.level)
+	l := &Logger{opts: o, level: &level}
 
 	var cores []zapcore.Core
 	if o.file != "" {
@@ -119,8 +125,8 @@ func (l *Logger) buildWriteSyncer(level log.Level) zapcore.WriteSyncer {
 
 func (l *Logger) buildLevelEnabler(level log.Level) zapcore.LevelEnabler {
 	return zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		if v := levelMap[lvl]; l.opts.level != log.NoneLevel {
-			return v >= l.opts.level && (level == log.NoneLevel || (level >= l.opts.level && v >= level))
+		if v, minLevel := levelMap[lvl], l.GetLevel(); minLevel != log.NoneLevel {
+			return v >= minLevel && (level == log.NoneLevel || (level >= minLevel && v >= level))
 		} else {
 			return level == log.NoneLevel || v >= level
 		}
@@ -144,19 +150,25 @@ func (l *Logger) print(level log.Level, stack bool, a ...interface{}) {
 		msg = fmt.Sprint(a...)
 	}
 
+	keysAndValues := make([]interface{}, 0, 2+2*len(l.fields))
+	keysAndValues = append(keysAndValues, encoder.StackFlag, stack)
+	for key, val := range l.fields {
+		keysAndValues = append(keysAndValues, key, val)
+	}
+
 	switch level {
 	case log.DebugLevel:
-		l.logger.Debugw(msg, encoder.StackFlag, stack)
+		l.logger.Debugw(msg, keysAndValues...)
 	case log.InfoLevel:
-		l.logger.Infow(msg, encoder.StackFlag, stack)
+		l.logger.Infow(msg, keysAndValues...)
 	case log.WarnLevel:
-		l.logger.Warnw(msg, encoder.StackFlag, stack)
+		l.logger.Warnw(msg, keysAndValues...)
 	case log.ErrorLevel:
-		l.logger.Errorw(msg, encoder.StackFlag, stack)
+		l.logger.Errorw(msg, keysAndValues...)
 	case log.FatalLevel:
-		l.logger.Fatalw(msg, encoder.StackFlag, stack)
+		l.logger.Fatalw(msg, keysAndValues...)
 	case log.PanicLevel:
-		l.logger.DPanicw(msg, encoder.StackFlag, stack)
+		l.logger.DPanicw(msg, keysAndValues...)
 	}
 }
 
@@ -230,12 +242,37 @@ func (l *Logger) Panicf(format string, a ...interface{}) {
 	l.print(log.PanicLevel, true, fmt.Sprintf(format, a...))
 }
 
+// WithFields 返回附加了日志字段的日志记录器
+// 派生的日志记录器与原日志记录器共享输出，关闭时不会影响原日志记录器
+func (l *Logger) WithFields(fields log.Fields) log.Logger {
+	return &Logger{logger: l.logger, opts: l.opts, level: l.level, fields: l.fields.With(fields), derived: true}
+}
+
+// WithContext 返回附加了上下文中日志字段的日志记录器
+func (l *Logger) WithContext(ctx context.Context) log.Logger {
+	return l.WithFields(log.FromContext(ctx))
+}
+
+// SetLevel 设置日志级别
+func (l *Logger) SetLevel(level log.Level) {
+	atomic.StoreInt32(l.level, int32(level))
+}
+
+// GetLevel 获取日志级别
+func (l *Logger) GetLevel() log.Level {
+	return log.Level(atomic.LoadInt32(l.level))
+}
+
 // Sync 同步缓存中的日志
 func (l *Logger) Sync() error {
 	return l.logger.Sync()
 }
 
-// Close 关闭日志
+// Close 关闭日志，派生的日志记录器关闭时不做任何处理
 func (l *Logger) Close() error {
+	if l.derived {
+		return nil
+	}
+
 	return l.logger.Sync()
 }
@@ -10,6 +10,9 @@ package zap_test
 import (
 	"github.com/dobyte/due/log/zap/v2"
 	"github.com/dobyte/due/v2/log"
+	"io"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -27,3 +30,41 @@ func TestNewLogger(t *testing.T) {
 	//logger.Fatal("fatal")
 	logger.Panic("panic")
 }
+
+func TestLogger_WithFields(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 日志记录器创建时绑定终端输出，需在创建前替换标准输出以捕获日志
+	stdout := os.Stdout
+	os.Stdout = w
+	l := zap.NewLogger(zap.WithFile(""), zap.WithFormat(log.JsonFormat))
+	os.Stdout = stdout
+
+	chOutput := make(chan string, 1)
+	go func() {
+		data, _ := io.ReadAll(r)
+		chOutput <- string(data)
+	}()
+
+	derived := l.WithFields(log.Fields{"uid": 1, "route": 2})
+	derived.Info("deliver message")
+
+	// 关闭派生的日志记录器不影响原日志记录器
+	if err = derived.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	l.Info("parent message")
+
+	_ = w.Close()
+	output := <-chOutput
+
+	for _, field := range []string{`"uid":1`, `"route":2`, "deliver message", "parent message"} {
+		if !strings.Contains(output, field) {
+			t.Fatalf("%q not found in output:\n%s", field, output)
+		}
+	}
+}
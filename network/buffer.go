@@ -0,0 +1,89 @@
+package network
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// 可回收复用的缓冲区的最大容量，超出该容量的缓冲区释放后直接交由GC回收
+const maxPooledBufferSize = 64 << 10
+
+var bufferPool = sync.Pool{New: func() interface{} { return &Buffer{} }}
+
+// Buffer 引用计数的共享消息缓冲区
+// 广播、组播等场景下同一条消息仅打包一次，所有连接共享同一个缓冲区进行写入，
+// 每个持有者在使用完毕后调用Release释放引用，所有引用释放后缓冲区将被回收复用
+type Buffer struct {
+	refs   int32
+	pooled bool
+	data   []byte
+}
+
+// NewBuffer 从缓冲池中获取长度为size的缓冲区，初始引用计数为1
+func NewBuffer(size int) *Buffer {
+	b := bufferPool.Get().(*Buffer)
+	if cap(b.data) < size {
+		b.data = make([]byte, size)
+	} else {
+		b.data = b.data[:size]
+	}
+	b.refs = 1
+	b.pooled = true
+
+	return b
+}
+
+// WrapBuffer 包装已有的字节切片，初始引用计数为1，所有引用释放后不会被回收复用
+func WrapBuffer(data []byte) *Buffer {
+	return &Buffer{refs: 1, data: data}
+}
+
+// Bytes 获取缓冲区数据，释放引用后不可再使用
+func (b *Buffer) Bytes() []byte {
+	return b.data
+}
+
+// Len 获取缓冲区数据长度
+func (b *Buffer) Len() int {
+	return len(b.data)
+}
+
+// Retain 增加引用
+func (b *Buffer) Retain() {
+	atomic.AddInt32(&b.refs, 1)
+}
+
+// Release 释放引用
+func (b *Buffer) Release() {
+	if atomic.AddInt32(&b.refs, -1) != 0 || !b.pooled {
+		return
+	}
+
+	if cap(b.data) > maxPooledBufferSize {
+		b.data = nil
+	} else {
+		b.data = b.data[:0]
+	}
+
+	bufferPool.Put(b)
+}
+
+// BufferPusher 支持推送共享缓冲区的连接
+type BufferPusher interface {
+	// PushBuffer 推送共享缓冲区（异步），连接将持有缓冲区的引用直至写入完成
+	PushBuffer(buf *Buffer) error
+}
+
+// PushBuffer 推送共享缓冲区（异步）
+// 连接不支持推送共享缓冲区时，可回收复用的缓冲区数据将被复制后再推送
+func PushBuffer(conn Conn, buf *Buffer) error {
+	if pusher, ok := conn.(BufferPusher); ok {
+		return pusher.PushBuffer(buf)
+	}
+
+	if buf.pooled {
+		return conn.Push(append([]byte(nil), buf.data...))
+	}
+
+	return conn.Push(buf.data)
+}
@@ -2,6 +2,7 @@ package netpoll
 
 import (
 	"github.com/cloudwego/netpoll"
+	"github.com/dobyte/due/v2/errors"
 	"github.com/dobyte/due/v2/network"
 	"net"
 	"sync/atomic"
@@ -29,6 +30,10 @@ func NewClient(opts ...ClientOption) network.Client {
 
 // Dial 拨号连接
 func (c *client) Dial() (network.Conn, error) {
+	if c.opts.tls {
+		return nil, errors.New("netpoll client does not support tls, please use the tcp client instead")
+	}
+
 	addr, err := net.ResolveTCPAddr("tcp", c.opts.addr)
 	if err != nil {
 		return nil, err
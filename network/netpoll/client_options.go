@@ -13,6 +13,10 @@ const (
 const (
 	defaultClientDialAddrKey          = "etc.network.tcp.client.addr"
 	defaultClientHeartbeatIntervalKey = "etc.network.tcp.client.heartbeatInterval"
+	defaultClientCAFileKey            = "etc.network.tcp.client.caFile"
+	defaultClientCertFileKey          = "etc.network.tcp.client.certFile"
+	defaultClientKeyFileKey           = "etc.network.tcp.client.keyFile"
+	defaultClientServerNameKey        = "etc.network.tcp.client.serverName"
 )
 
 type ClientOption func(o *clientOptions)
@@ -20,12 +24,17 @@ type ClientOption func(o *clientOptions)
 type clientOptions struct {
 	addr              string        // 地址
 	heartbeatInterval time.Duration // 心跳间隔时间，默认10s
+	tls               bool          // 是否配置了TLS，netpoll暂不支持TLS，仅用于拨号时检测
 }
 
 func defaultClientOptions() *clientOptions {
 	return &clientOptions{
 		addr:              etc.Get(defaultClientDialAddrKey, defaultClientDialAddr).String(),
 		heartbeatInterval: etc.Get(defaultClientHeartbeatIntervalKey, defaultClientHeartbeatInterval).Duration() * time.Second,
+		tls: etc.Get(defaultClientCAFileKey).String() != "" ||
+			etc.Get(defaultClientCertFileKey).String() != "" ||
+			etc.Get(defaultClientKeyFileKey).String() != "" ||
+			etc.Get(defaultClientServerNameKey).String() != "",
 	}
 }
 
@@ -3,6 +3,7 @@ package netpoll
 import (
 	"encoding/binary"
 	"github.com/cloudwego/netpoll"
+	"github.com/dobyte/due/v2/network"
 )
 
 const sizeBytes = 4
@@ -16,6 +17,7 @@ const (
 type chWrite struct {
 	typ int
 	msg []byte
+	buf *network.Buffer // 共享缓冲区，写入完成后释放引用
 }
 
 // 执行写入操作
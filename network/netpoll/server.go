@@ -78,6 +78,11 @@ func (s *server) Stop() (err error) {
 }
 
 func (s *server) init() error {
+	// netpoll基于零拷贝的连接无法套用crypto/tls，避免按TLS配置的服务器以明文方式对外提供服务
+	if s.opts.certFile != "" || s.opts.keyFile != "" {
+		return errors.New("netpoll server does not support tls, please use the tcp server instead")
+	}
+
 	addr, err := net.ResolveTCPAddr(s.Protocol(), s.opts.addr)
 	if err != nil {
 		return err
@@ -26,6 +26,7 @@ type serverConn struct {
 }
 
 var _ network.Conn = &serverConn{}
+var _ network.BufferPusher = &serverConn{}
 
 // ID 获取连接ID
 func (c *serverConn) ID() int64 {
@@ -73,6 +74,22 @@ func (c *serverConn) Push(msg []byte) error {
 	return nil
 }
 
+// PushBuffer 推送共享缓冲区（异步），写入完成后释放引用
+func (c *serverConn) PushBuffer(buf *network.Buffer) error {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	if err := c.checkState(); err != nil {
+		return err
+	}
+
+	buf.Retain()
+
+	c.chWrite <- chWrite{typ: dataPacket, msg: buf.Bytes(), buf: buf}
+
+	return nil
+}
+
 // State 获取连接状态
 func (c *serverConn) State() network.ConnState {
 	return network.ConnState(atomic.LoadInt32(&c.state))
@@ -288,6 +305,10 @@ func (c *serverConn) write() {
 			err := write(c.conn.Writer(), r.msg)
 			c.rw.RUnlock()
 
+			if r.buf != nil {
+				r.buf.Release()
+			}
+
 			if err != nil {
 				log.Errorf("write message error: %v", err)
 			}
@@ -15,6 +15,8 @@ const (
 	defaultServerAddrKey              = "etc.network.tcp.server.addr"
 	defaultServerMaxConnNumKey        = "etc.network.tcp.server.maxConnNum"
 	defaultServerHeartbeatIntervalKey = "etc.network.tcp.server.heartbeatInterval"
+	defaultServerCertFileKey          = "etc.network.tcp.server.certFile"
+	defaultServerKeyFileKey           = "etc.network.tcp.server.keyFile"
 )
 
 type ServerOption func(o *serverOptions)
@@ -23,6 +25,8 @@ type serverOptions struct {
 	addr              string        // 监听地址，默认0.0.0.0:3553
 	maxConnNum        int           // 最大连接数，默认5000
 	heartbeatInterval time.Duration // 心跳检测间隔时间，默认10s
+	certFile          string        // 证书文件，netpoll暂不支持TLS，仅用于启动时检测
+	keyFile           string        // 秘钥文件，netpoll暂不支持TLS，仅用于启动时检测
 }
 
 func defaultServerOptions() *serverOptions {
@@ -30,6 +34,8 @@ func defaultServerOptions() *serverOptions {
 		addr:              etc.Get(defaultServerAddrKey, defaultServerAddr).String(),
 		maxConnNum:        etc.Get(defaultServerMaxConnNumKey, defaultServerMaxConnNum).Int(),
 		heartbeatInterval: etc.Get(defaultServerHeartbeatIntervalKey, defaultServerHeartbeatInterval).Duration() * time.Second,
+		certFile:          etc.Get(defaultServerCertFileKey).String(),
+		keyFile:           etc.Get(defaultServerKeyFileKey).String(),
 	}
 }
 
@@ -35,3 +35,8 @@ type Server interface {
 	// OnDisconnect 监听连接断开
 	OnDisconnect(handler DisconnectHandler)
 }
+
+type Restarter interface {
+	// Restart 重新加载配置并重启服务器，已建立的连接不受影响
+	Restart() error
+}
@@ -1,6 +1,7 @@
 package tcp
 
 import (
+	"crypto/tls"
 	"github.com/dobyte/due/v2/network"
 	"net"
 	"sync/atomic"
@@ -39,7 +40,17 @@ func (c *client) Dial(addr ...string) (network.Conn, error) {
 		return nil, err
 	}
 
-	conn, err := net.Dial(tcpAddr.Network(), tcpAddr.String())
+	config, err := c.opts.buildTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var conn net.Conn
+	if config != nil {
+		conn, err = tls.Dial(tcpAddr.Network(), address, config)
+	} else {
+		conn, err = net.Dial(tcpAddr.Network(), tcpAddr.String())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package tcp
 
 import (
+	"crypto/tls"
 	"github.com/dobyte/due/v2/etc"
 	"time"
 )
@@ -13,6 +14,10 @@ const (
 const (
 	defaultClientDialAddrKey          = "etc.network.tcp.client.addr"
 	defaultClientHeartbeatIntervalKey = "etc.network.tcp.client.heartbeatInterval"
+	defaultClientCAFileKey            = "etc.network.tcp.client.caFile"
+	defaultClientCertFileKey          = "etc.network.tcp.client.certFile"
+	defaultClientKeyFileKey           = "etc.network.tcp.client.keyFile"
+	defaultClientServerNameKey        = "etc.network.tcp.client.serverName"
 )
 
 type ClientOption func(o *clientOptions)
@@ -20,12 +25,21 @@ type ClientOption func(o *clientOptions)
 type clientOptions struct {
 	addr              string        // 地址
 	heartbeatInterval time.Duration // 心跳间隔时间，默认10s
+	caFile            string        // CA证书文件，用于校验服务器证书，为空时使用系统根证书
+	certFile          string        // 证书文件，服务器开启双向认证时使用
+	keyFile           string        // 秘钥文件，服务器开启双向认证时使用
+	serverName        string        // 服务器名称，用于校验服务器证书
+	tlsConfig         *tls.Config   // TLS配置，设置后将忽略证书文件配置
 }
 
 func defaultClientOptions() *clientOptions {
 	return &clientOptions{
 		addr:              etc.Get(defaultClientDialAddrKey, defaultClientDialAddr).String(),
 		heartbeatInterval: etc.Get(defaultClientHeartbeatIntervalKey, defaultClientHeartbeatInterval).Duration(),
+		caFile:            etc.Get(defaultClientCAFileKey).String(),
+		certFile:          etc.Get(defaultClientCertFileKey).String(),
+		keyFile:           etc.Get(defaultClientKeyFileKey).String(),
+		serverName:        etc.Get(defaultClientServerNameKey).String(),
 	}
 }
 
@@ -38,3 +52,23 @@ func WithClientDialAddr(addr string) ClientOption {
 func WithClientHeartbeatInterval(heartbeatInterval time.Duration) ClientOption {
 	return func(o *clientOptions) { o.heartbeatInterval = heartbeatInterval }
 }
+
+// WithClientCA 设置校验服务器证书的CA证书，并以TLS方式拨号
+func WithClientCA(caFile string) ClientOption {
+	return func(o *clientOptions) { o.caFile = caFile }
+}
+
+// WithClientCredentials 设置证书和秘钥，用于服务器开启双向认证时的客户端认证
+func WithClientCredentials(certFile, keyFile string) ClientOption {
+	return func(o *clientOptions) { o.keyFile, o.certFile = keyFile, certFile }
+}
+
+// WithClientServerName 设置服务器名称，并以TLS方式拨号
+func WithClientServerName(serverName string) ClientOption {
+	return func(o *clientOptions) { o.serverName = serverName }
+}
+
+// WithClientTLSConfig 设置TLS配置
+func WithClientTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(o *clientOptions) { o.tlsConfig = tlsConfig }
+}
@@ -2,6 +2,7 @@ package tcp
 
 import (
 	"encoding/binary"
+	"github.com/dobyte/due/v2/network"
 	"github.com/dobyte/due/v2/utils/xtime"
 	"io"
 	"net"
@@ -31,6 +32,7 @@ const (
 type chWrite struct {
 	typ int
 	msg []byte
+	buf *network.Buffer // 共享缓冲区，写入完成后释放引用
 }
 
 // 打包心跳
@@ -8,25 +8,35 @@
 package tcp
 
 import (
+	"crypto/tls"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/utils/xcall"
 	"net"
+	"sync/atomic"
 	"time"
 )
 
 type server struct {
-	opts              *serverOptions            // 配置
-	listener          net.Listener              // 监听器
-	connMgr           *serverConnMgr            // 连接管理器
-	startHandler      network.StartHandler      // 服务器启动hook函数
-	stopHandler       network.CloseHandler      // 服务器关闭hook函数
-	connectHandler    network.ConnectHandler    // 连接打开hook函数
-	disconnectHandler network.DisconnectHandler // 连接关闭hook函数
-	receiveHandler    network.ReceiveHandler    // 接收消息hook函数
+	opts              atomic.Pointer[serverOptions] // 配置
+	rawOpts           []ServerOption                // 原始配置项，重新加载配置时使用
+	listener          net.Listener                  // 监听器
+	connMgr           *serverConnMgr                // 连接管理器
+	handshakes        atomic.Int64                  // 正在进行TLS握手的连接数
+	unwatch           func()                        // 取消配置监听
+	startHandler      network.StartHandler          // 服务器启动hook函数
+	stopHandler       network.CloseHandler          // 服务器关闭hook函数
+	connectHandler    network.ConnectHandler        // 连接打开hook函数
+	disconnectHandler network.DisconnectHandler     // 连接关闭hook函数
+	receiveHandler    network.ReceiveHandler        // 接收消息hook函数
 }
 
 var _ network.Server = &server{}
 
+var _ network.Restarter = &server{}
+
 func NewServer(opts ...ServerOption) network.Server {
 	o := defaultServerOptions()
 	for _, opt := range opts {
@@ -34,15 +44,39 @@ func NewServer(opts ...ServerOption) network.Server {
 	}
 
 	s := &server{}
-	s.opts = o
+	s.opts.Store(o)
+	s.rawOpts = opts
 	s.connMgr = newConnMgr(s)
 
 	return s
 }
 
+// 构建配置
+func (s *server) buildOptions() *serverOptions {
+	o := defaultServerOptions()
+	for _, opt := range s.rawOpts {
+		opt(o)
+	}
+
+	return o
+}
+
+// 重新加载配置
+// 监听地址等启动配置无法在运行期间变更，仅最大连接数与心跳配置支持热更新
+func (s *server) reload() {
+	o := s.buildOptions()
+
+	newOpts := *s.opts.Load()
+	newOpts.maxConnNum = o.maxConnNum
+	newOpts.heartbeatInterval = o.heartbeatInterval
+	newOpts.heartbeatMechanism = o.heartbeatMechanism
+	newOpts.heartbeatWithServerTime = o.heartbeatWithServerTime
+	s.opts.Store(&newOpts)
+}
+
 // Addr 监听地址
 func (s *server) Addr() string {
-	return s.opts.addr
+	return s.opts.Load().addr
 }
 
 // Start 启动服务器
@@ -51,24 +85,87 @@ func (s *server) Start() error {
 		return err
 	}
 
+	s.unwatch = etc.Watch(func(names ...string) { s.reload() })
+
 	if s.startHandler != nil {
 		s.startHandler()
 	}
 
-	go s.serve()
+	go s.serve(s.listener)
+
+	return nil
+}
+
+// Restart 重启服务器
+// 重新加载全部配置，仅当监听地址或TLS配置发生变更时重建监听器，已建立的连接不受影响
+// 新的监听器创建失败时保留原有的监听器与配置
+func (s *server) Restart() error {
+	oldOpts, newOpts := s.opts.Load(), s.buildOptions()
+
+	if newOpts.addr == oldOpts.addr &&
+		newOpts.certFile == oldOpts.certFile &&
+		newOpts.keyFile == oldOpts.keyFile &&
+		newOpts.caFile == oldOpts.caFile &&
+		newOpts.tlsConfig == oldOpts.tlsConfig {
+		s.opts.Store(newOpts)
+		return nil
+	}
+
+	var (
+		listener net.Listener
+		err      error
+	)
+
+	if newOpts.addr != oldOpts.addr {
+		// 监听地址变更时先监听新地址，成功后再关闭原监听器
+		if listener, err = s.listen(newOpts); err != nil {
+			return err
+		}
+
+		if err = s.listener.Close(); err != nil {
+			log.Warnf("tcp listener close failed: %v", err)
+		}
+	} else {
+		// 监听地址未变更时需先关闭原监听器才能重新监听，因此先校验TLS配置，重新监听失败时恢复原监听器
+		if _, err = newOpts.buildTLSConfig(); err != nil {
+			return err
+		}
+
+		if err = s.listener.Close(); err != nil {
+			return err
+		}
+
+		if listener, err = s.listen(newOpts); err != nil {
+			old, e := s.listen(oldOpts)
+			if e != nil {
+				return errors.Join(err, e)
+			}
+
+			s.listener = old
+
+			go s.serve(old)
+
+			return err
+		}
+	}
+
+	s.opts.Store(newOpts)
+	s.listener = listener
+
+	go s.serve(listener)
 
 	return nil
 }
 
 // Stop 关闭服务器
 func (s *server) Stop() error {
-	if err := s.listener.Close(); err != nil {
-		return err
-	}
+	err := s.listener.Close()
+
+	s.unwatch()
 
 	s.connMgr.close()
 
-	return nil
+	return err
 }
 
 // Protocol 协议
@@ -102,28 +199,43 @@ func (s *server) OnReceive(handler network.ReceiveHandler) {
 }
 
 // 初始化TCP服务器
-func (s *server) init() error {
-	addr, err := net.ResolveTCPAddr("tcp", s.opts.addr)
+func (s *server) init() (err error) {
+	s.listener, err = s.listen(s.opts.Load())
+
+	return
+}
+
+// 创建监听器，配置了TLS时返回TLS监听器
+func (s *server) listen(o *serverOptions) (net.Listener, error) {
+	addr, err := net.ResolveTCPAddr("tcp", o.addr)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ln, err := net.ListenTCP(addr.Network(), addr)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	config, err := o.buildTLSConfig()
+	if err != nil {
+		_ = ln.Close()
+		return nil, err
 	}
 
-	s.listener = ln
+	if config != nil {
+		return tls.NewListener(ln, config), nil
+	}
 
-	return nil
+	return ln, nil
 }
 
 // 等待连接
-func (s *server) serve() {
+func (s *server) serve(listener net.Listener) {
 	var tempDelay time.Duration
 
 	for {
-		conn, err := s.listener.Accept()
+		conn, err := listener.Accept()
 		if err != nil {
 			if e, ok := err.(net.Error); ok && e.Timeout() {
 				if tempDelay == 0 {
@@ -140,15 +252,54 @@ func (s *server) serve() {
 				continue
 			}
 
-			log.Errorf("tcp accept error: %v", err)
+			if !errors.Is(err, net.ErrClosed) {
+				log.Errorf("tcp accept error: %v", err)
+			}
 			return
 		}
 
 		tempDelay = 0
 
-		if err = s.connMgr.allocate(conn); err != nil {
-			log.Errorf("connection allocate error: %v", err)
-			_ = conn.Close()
+		if tlsConn, ok := conn.(*tls.Conn); ok {
+			// 限制同时握手的连接数，避免大量未完成握手的连接耗尽资源
+			if s.handshakes.Add(1) > int64(s.opts.Load().maxConnNum) {
+				s.handshakes.Add(-1)
+				log.Warnf("tls handshake rejected: %v", errors.ErrTooManyConnection)
+				_ = conn.Close()
+				continue
+			}
+
+			xcall.Go(func() { s.handshake(tlsConn) })
+			continue
 		}
+
+		s.allocate(conn)
+	}
+}
+
+// 执行TLS握手，握手成功后再分配连接，避免握手缓慢的连接阻塞监听
+func (s *server) handshake(conn *tls.Conn) {
+	defer s.handshakes.Add(-1)
+
+	if timeout := s.opts.Load().handshakeTimeout; timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	if err := conn.Handshake(); err != nil {
+		log.Debugf("tls handshake error: %v", err)
+		_ = conn.Close()
+		return
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	s.allocate(conn)
+}
+
+// 分配连接
+func (s *server) allocate(conn net.Conn) {
+	if err := s.connMgr.allocate(conn); err != nil {
+		log.Errorf("connection allocate error: %v", err)
+		_ = conn.Close()
 	}
 }
@@ -30,11 +30,13 @@ type serverConn struct {
 	connMgr           *serverConnMgr // 连接管理
 	chWrite           chan chWrite   // 写入队列
 	lastHeartbeatTime int64          // 上次心跳时间
+	heartbeatInterval time.Duration  // 心跳定时器的间隔时间，仅在写入协程中访问
 	done              chan struct{}  // 写入完成信号
 	close             chan struct{}  // 关闭信号
 }
 
 var _ network.Conn = &serverConn{}
+var _ network.BufferPusher = &serverConn{}
 
 // ID 获取连接ID
 func (c *serverConn) ID() int64 {
@@ -86,6 +88,22 @@ func (c *serverConn) Push(msg []byte) (err error) {
 	return
 }
 
+// PushBuffer 推送共享缓冲区（异步），写入完成后释放引用
+func (c *serverConn) PushBuffer(buf *network.Buffer) (err error) {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	if err = c.checkState(); err != nil {
+		return
+	}
+
+	buf.Retain()
+
+	c.chWrite <- chWrite{typ: dataPacket, msg: buf.Bytes(), buf: buf}
+
+	return
+}
+
 // State 获取连接状态
 func (c *serverConn) State() network.ConnState {
 	return network.ConnState(atomic.LoadInt32(&c.state))
@@ -251,9 +269,7 @@ func (c *serverConn) read() {
 				return
 			}
 
-			if c.connMgr.server.opts.heartbeatInterval > 0 {
-				atomic.StoreInt64(&c.lastHeartbeatTime, xtime.Now().UnixNano())
-			}
+			atomic.StoreInt64(&c.lastHeartbeatTime, xtime.Now().UnixNano())
 
 			switch c.State() {
 			case network.ConnHanged:
@@ -273,7 +289,7 @@ func (c *serverConn) read() {
 			// ignore heartbeat packet
 			if isHeartbeat {
 				// responsive heartbeat
-				if c.connMgr.server.opts.heartbeatMechanism == RespHeartbeat {
+				if c.connMgr.server.opts.Load().heartbeatMechanism == RespHeartbeat {
 					if heartbeat, err := packet.PackHeartbeat(); err != nil {
 						log.Errorf("pack heartbeat message error: %v", err)
 					} else {
@@ -299,17 +315,11 @@ func (c *serverConn) read() {
 
 // 写入消息
 func (c *serverConn) write() {
-	var (
-		conn   = c.conn
-		ticker *time.Ticker
-	)
-
-	if c.connMgr.server.opts.heartbeatInterval > 0 {
-		ticker = time.NewTicker(c.connMgr.server.opts.heartbeatInterval)
-		defer ticker.Stop()
-	} else {
-		ticker = &time.Ticker{C: make(chan time.Time, 1)}
-	}
+	conn := c.conn
+
+	c.heartbeatInterval = c.connMgr.server.opts.Load().heartbeatInterval
+	ticker := time.NewTicker(tickerInterval(c.heartbeatInterval))
+	defer ticker.Stop()
 
 	for {
 		select {
@@ -329,17 +339,32 @@ func (c *serverConn) write() {
 				return
 			}
 
-			if _, err := conn.Write(r.msg); err != nil {
+			_, err := conn.Write(r.msg)
+
+			if r.buf != nil {
+				r.buf.Release()
+			}
+
+			if err != nil {
 				log.Errorf("write data message error: %v", err)
 			}
 		case <-ticker.C:
-			deadline := xtime.Now().Add(-2 * c.connMgr.server.opts.heartbeatInterval).UnixNano()
+			c.resetTicker(ticker)
+
+			opts := c.connMgr.server.opts.Load()
+
+			// 心跳检测已被热更新关闭
+			if opts.heartbeatInterval <= 0 {
+				continue
+			}
+
+			deadline := xtime.Now().Add(-2 * opts.heartbeatInterval).UnixNano()
 			if atomic.LoadInt64(&c.lastHeartbeatTime) < deadline {
 				log.Debugf("connection heartbeat timeout, cid: %d", c.id)
 				_ = c.forceClose()
 				return
 			} else {
-				if c.connMgr.server.opts.heartbeatMechanism == TickHeartbeat {
+				if opts.heartbeatMechanism == TickHeartbeat {
 					if c.isClosed() {
 						return
 					}
@@ -358,6 +383,26 @@ func (c *serverConn) write() {
 	}
 }
 
+// 心跳间隔时间被热更新时重置心跳定时器
+func (c *serverConn) resetTicker(ticker *time.Ticker) {
+	interval := c.connMgr.server.opts.Load().heartbeatInterval
+	if interval == c.heartbeatInterval {
+		return
+	}
+
+	c.heartbeatInterval = interval
+	ticker.Reset(tickerInterval(interval))
+}
+
+// 计算心跳定时器的间隔时间，心跳检测关闭时以固定间隔检测配置变更
+func tickerInterval(heartbeatInterval time.Duration) time.Duration {
+	if heartbeatInterval > 0 {
+		return heartbeatInterval
+	}
+
+	return heartbeatCheckInterval
+}
+
 // 是否已关闭
 func (c *serverConn) isClosed() bool {
 	return network.ConnState(atomic.LoadInt32(&c.state)) == network.ConnClosed
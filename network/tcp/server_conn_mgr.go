@@ -38,14 +38,14 @@ func (cm *serverConnMgr) close() {
 		_ = conn.graceClose(false)
 	}
 
-	cm.conns = nil
+	cm.conns = make(map[int64]*serverConn)
 }
 
 // 分配连接
 func (cm *serverConnMgr) allocate(c net.Conn) error {
 	cm.mu.Lock()
 
-	if len(cm.conns) >= cm.server.opts.maxConnNum {
+	if len(cm.conns) >= cm.server.opts.Load().maxConnNum {
 		cm.mu.Unlock()
 		return errors.ErrTooManyConnection
 	}
@@ -1,6 +1,7 @@
 package tcp
 
 import (
+	"crypto/tls"
 	"github.com/dobyte/due/v2/etc"
 	"time"
 )
@@ -8,6 +9,7 @@ import (
 const (
 	defaultServerAddr                    = ":3553"
 	defaultServerMaxConnNum              = 5000
+	defaultServerHandshakeTimeout        = "10s"
 	defaultServerHeartbeatInterval       = "10s"
 	defaultServerHeartbeatMechanism      = RespHeartbeat
 	defaultServerHeartbeatWithServerTime = true
@@ -16,11 +18,18 @@ const (
 const (
 	defaultServerAddrKey                    = "etc.network.tcp.server.addr"
 	defaultServerMaxConnNumKey              = "etc.network.tcp.server.maxConnNum"
+	defaultServerCertFileKey                = "etc.network.tcp.server.certFile"
+	defaultServerKeyFileKey                 = "etc.network.tcp.server.keyFile"
+	defaultServerCAFileKey                  = "etc.network.tcp.server.caFile"
+	defaultServerHandshakeTimeoutKey        = "etc.network.tcp.server.handshakeTimeout"
 	defaultServerHeartbeatIntervalKey       = "etc.network.tcp.server.heartbeatInterval"
 	defaultServerHeartbeatMechanismKey      = "etc.network.tcp.server.heartbeatMechanism"
 	defaultServerHeartbeatWithServerTimeKey = "etc.network.tcp.server.heartbeatWithServerTime"
 )
 
+// 心跳检测关闭时检测心跳配置变更的间隔时间
+const heartbeatCheckInterval = 10 * time.Second
+
 const (
 	RespHeartbeat HeartbeatMechanism = "resp" // 响应式心跳
 	TickHeartbeat HeartbeatMechanism = "tick" // 主动定时心跳
@@ -33,6 +42,11 @@ type ServerOption func(o *serverOptions)
 type serverOptions struct {
 	addr                    string             // 监听地址，默认0.0.0.0:3553
 	maxConnNum              int                // 最大连接数，默认5000
+	certFile                string             // 证书文件
+	keyFile                 string             // 秘钥文件
+	caFile                  string             // CA证书文件，设置后将校验客户端证书，开启双向认证
+	tlsConfig               *tls.Config        // TLS配置，设置后将忽略证书文件配置
+	handshakeTimeout        time.Duration      // TLS握手超时时间，默认10s
 	heartbeatInterval       time.Duration      // 心跳检测间隔时间，默认10s
 	heartbeatMechanism      HeartbeatMechanism // 心跳机制，默认resp
 	heartbeatWithServerTime bool               // 下行心跳是否携带服务器时间，默认为true
@@ -42,6 +56,10 @@ func defaultServerOptions() *serverOptions {
 	return &serverOptions{
 		addr:                    etc.Get(defaultServerAddrKey, defaultServerAddr).String(),
 		maxConnNum:              etc.Get(defaultServerMaxConnNumKey, defaultServerMaxConnNum).Int(),
+		certFile:                etc.Get(defaultServerCertFileKey).String(),
+		keyFile:                 etc.Get(defaultServerKeyFileKey).String(),
+		caFile:                  etc.Get(defaultServerCAFileKey).String(),
+		handshakeTimeout:        etc.Get(defaultServerHandshakeTimeoutKey, defaultServerHandshakeTimeout).Duration(),
 		heartbeatInterval:       etc.Get(defaultServerHeartbeatIntervalKey, defaultServerHeartbeatInterval).Duration(),
 		heartbeatMechanism:      HeartbeatMechanism(etc.Get(defaultServerHeartbeatMechanismKey, defaultServerHeartbeatMechanism).String()),
 		heartbeatWithServerTime: etc.Get(defaultServerHeartbeatWithServerTimeKey, defaultServerHeartbeatWithServerTime).Bool(),
@@ -58,6 +76,26 @@ func WithServerMaxConnNum(maxConnNum int) ServerOption {
 	return func(o *serverOptions) { o.maxConnNum = maxConnNum }
 }
 
+// WithServerCredentials 设置证书和秘钥
+func WithServerCredentials(certFile, keyFile string) ServerOption {
+	return func(o *serverOptions) { o.keyFile, o.certFile = keyFile, certFile }
+}
+
+// WithServerClientCA 设置校验客户端证书的CA证书，开启双向认证
+func WithServerClientCA(caFile string) ServerOption {
+	return func(o *serverOptions) { o.caFile = caFile }
+}
+
+// WithServerTLSConfig 设置TLS配置
+func WithServerTLSConfig(tlsConfig *tls.Config) ServerOption {
+	return func(o *serverOptions) { o.tlsConfig = tlsConfig }
+}
+
+// WithServerHandshakeTimeout 设置TLS握手超时时间
+func WithServerHandshakeTimeout(handshakeTimeout time.Duration) ServerOption {
+	return func(o *serverOptions) { o.handshakeTimeout = handshakeTimeout }
+}
+
 // WithServerHeartbeatInterval 设置心跳检测间隔时间
 func WithServerHeartbeatInterval(heartbeatInterval time.Duration) ServerOption {
 	return func(o *serverOptions) { o.heartbeatInterval = heartbeatInterval }
@@ -9,12 +9,15 @@ package tcp_test
 
 import (
 	"github.com/dobyte/due/network/tcp/v2"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/network"
 	"github.com/dobyte/due/v2/packet"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"testing"
+	"time"
 )
 
 func TestServer(t *testing.T) {
@@ -106,3 +109,127 @@ func TestServer_Benchmark(t *testing.T) {
 
 	select {}
 }
+
+func TestServer_Restart(t *testing.T) {
+	if err := etc.Set("etc.network.tcp.server.addr", "127.0.0.1:3557"); err != nil {
+		t.Fatal(err)
+	}
+
+	server := tcp.NewServer()
+	server.OnReceive(func(conn network.Conn, msg []byte) {
+		if err := conn.Push(msg); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	oldConn, oldMsg := dial(t, "127.0.0.1:3557")
+	defer oldConn.Close()
+
+	if err := etc.Set("etc.network.tcp.server.addr", "127.0.0.1:3558"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := server.(network.Restarter).Restart(); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.Addr() != "127.0.0.1:3558" {
+		t.Fatalf("invalid server addr: %s", server.Addr())
+	}
+
+	newConn, newMsg := dial(t, "127.0.0.1:3558")
+	defer newConn.Close()
+
+	echo(t, oldConn, oldMsg)
+	echo(t, newConn, newMsg)
+}
+
+func TestServer_RestartFailed(t *testing.T) {
+	if err := etc.Set("etc.network.tcp.server.addr", "127.0.0.1:3559"); err != nil {
+		t.Fatal(err)
+	}
+
+	server := tcp.NewServer()
+	server.OnReceive(func(conn network.Conn, msg []byte) {
+		if err := conn.Push(msg); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	// 占用新的监听地址，使重启失败
+	occupied, err := net.Listen("tcp", "127.0.0.1:3560")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer occupied.Close()
+
+	if err = etc.Set("etc.network.tcp.server.addr", "127.0.0.1:3560"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = server.(network.Restarter).Restart(); err == nil {
+		t.Fatal("restart should fail when the new addr is in use")
+	}
+
+	if server.Addr() != "127.0.0.1:3559" {
+		t.Fatalf("invalid server addr: %s", server.Addr())
+	}
+
+	conn, msg := dial(t, "127.0.0.1:3559")
+	defer conn.Close()
+
+	echo(t, conn, msg)
+}
+
+// 拨号连接服务器
+func dial(t *testing.T, addr string) (network.Conn, chan []byte) {
+	chMsg := make(chan []byte, 1)
+
+	client := tcp.NewClient(tcp.WithClientDialAddr(addr))
+	client.OnReceive(func(conn network.Conn, msg []byte) {
+		chMsg <- msg
+	})
+
+	conn, err := client.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return conn, chMsg
+}
+
+// 发送消息并校验回显
+func echo(t *testing.T, conn network.Conn, chMsg chan []byte) {
+	msg, err := packet.PackMessage(&packet.Message{Seq: 1, Route: 1, Buffer: []byte("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = conn.Push(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case reply := <-chMsg:
+		message, err := packet.UnpackMessage(reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(message.Buffer) != "hello" {
+			t.Fatalf("invalid message: %s", string(message.Buffer))
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("receive message timeout")
+	}
+}
@@ -0,0 +1,93 @@
+package tcp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/dobyte/due/v2/errors"
+	"os"
+)
+
+// 构建服务器TLS配置，未设置证书时返回nil，设置CA证书时开启双向认证
+func (o *serverOptions) buildTLSConfig() (*tls.Config, error) {
+	if o.tlsConfig != nil {
+		return o.tlsConfig, nil
+	}
+
+	if o.certFile == "" && o.keyFile == "" {
+		if o.caFile != "" {
+			return nil, errors.New("the server certificate and key must be set when the client ca is set")
+		}
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(o.certFile, o.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if o.caFile != "" {
+		if config.ClientCAs, err = loadCertPool(o.caFile); err != nil {
+			return nil, err
+		}
+
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// 构建客户端TLS配置，未设置CA证书、客户端证书及服务器名称时返回nil，以明文方式拨号
+func (o *clientOptions) buildTLSConfig() (*tls.Config, error) {
+	if o.tlsConfig != nil {
+		return o.tlsConfig, nil
+	}
+
+	if o.caFile == "" && o.certFile == "" && o.keyFile == "" && o.serverName == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: o.serverName,
+	}
+
+	if o.caFile != "" {
+		pool, err := loadCertPool(o.caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = pool
+	}
+
+	if o.certFile != "" || o.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.certFile, o.keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// 加载CA证书池
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("invalid ca certificate file")
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,133 @@
+package tcp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/dobyte/due/network/tcp/v2"
+	"github.com/dobyte/due/v2/network"
+	"github.com/dobyte/due/v2/packet"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestServer_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	caCert, caKey := generateCert(t, dir, "ca", nil, nil)
+	generateCert(t, dir, "server", caCert, caKey)
+	generateCert(t, dir, "client", caCert, caKey)
+
+	server := tcp.NewServer(
+		tcp.WithServerListenAddr(":3555"),
+		tcp.WithServerCredentials(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")),
+		tcp.WithServerClientCA(filepath.Join(dir, "ca.crt")),
+	)
+	server.OnReceive(func(conn network.Conn, msg []byte) {
+		if err := conn.Push(msg); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	chMsg := make(chan []byte, 1)
+
+	client := tcp.NewClient(
+		tcp.WithClientDialAddr("127.0.0.1:3555"),
+		tcp.WithClientServerName("localhost"),
+		tcp.WithClientCA(filepath.Join(dir, "ca.crt")),
+		tcp.WithClientCredentials(filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")),
+	)
+	client.OnReceive(func(conn network.Conn, msg []byte) {
+		chMsg <- msg
+	})
+
+	conn, err := client.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	msg, err := packet.PackMessage(&packet.Message{Seq: 1, Route: 1, Buffer: []byte("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = conn.Push(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case reply := <-chMsg:
+		message, err := packet.UnpackMessage(reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(message.Buffer) != "hello" {
+			t.Fatalf("invalid message: %s", string(message.Buffer))
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("receive message timeout")
+	}
+}
+
+// 生成证书，parent为空时生成自签名的CA证书
+func generateCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err = os.WriteFile(filepath.Join(dir, name+".crt"), certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	if err = os.WriteFile(filepath.Join(dir, name+".key"), keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key
+}
@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"github.com/dobyte/due/v2/network"
 	"github.com/gobwas/ws"
 )
 
@@ -23,4 +24,5 @@ const (
 type chWrite struct {
 	typ int
 	msg []byte
+	buf *network.Buffer // 共享缓冲区，写入完成后释放引用
 }
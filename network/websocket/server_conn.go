@@ -35,6 +35,7 @@ type serverConn struct {
 }
 
 var _ network.Conn = &serverConn{}
+var _ network.BufferPusher = &serverConn{}
 
 // ID 获取连接ID
 func (c *serverConn) ID() int64 {
@@ -82,6 +83,22 @@ func (c *serverConn) Push(msg []byte) error {
 	return nil
 }
 
+// PushBuffer 推送共享缓冲区（异步），写入完成后释放引用
+func (c *serverConn) PushBuffer(buf *network.Buffer) error {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	if err := c.checkState(); err != nil {
+		return err
+	}
+
+	buf.Retain()
+
+	c.chWrite <- chWrite{typ: dataPacket, msg: buf.Bytes(), buf: buf}
+
+	return nil
+}
+
 // State 获取连接状态
 func (c *serverConn) State() network.ConnState {
 	return network.ConnState(atomic.LoadInt32(&c.state))
@@ -297,6 +314,10 @@ func (c *serverConn) write() {
 			err := wsutil.WriteServerMessage(c.conn, ws.OpBinary, write.msg)
 			c.rw.RUnlock()
 
+			if write.buf != nil {
+				write.buf.Release()
+			}
+
 			if err != nil {
 				log.Errorf("write message error: %v", err)
 			}
@@ -3,6 +3,7 @@ package ws
 import (
 	"encoding/binary"
 	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/network"
 	"github.com/dobyte/due/v2/utils/xtime"
 )
 
@@ -26,6 +27,7 @@ const (
 type chWrite struct {
 	typ int
 	msg []byte
+	buf *network.Buffer // 共享缓冲区，写入完成后释放引用
 }
 
 // 打包心跳
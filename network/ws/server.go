@@ -8,12 +8,18 @@
 package ws
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/log"
 	"github.com/dobyte/due/v2/network"
 	"github.com/dobyte/due/v2/utils/xcall"
 	"github.com/gorilla/websocket"
 	"net"
 	"net/http"
+	"sync"
+	"sync/atomic"
 )
 
 const protocol = "websocket"
@@ -24,22 +30,42 @@ type Server interface {
 	network.Server
 	// OnUpgrade 监听HTTP请求升级
 	OnUpgrade(handler UpgradeHandler)
+	// Handle 在同一监听地址上挂载额外的HTTP处理器，如健康检查、监控指标、静态文件等
+	Handle(pattern string, handler http.Handler)
+	// HandleFunc 在同一监听地址上挂载额外的HTTP处理函数
+	HandleFunc(pattern string, handler func(w http.ResponseWriter, r *http.Request))
+	// Shutdown 优雅关闭服务器，停止接收新的请求并等待处理中的请求完成后关闭所有连接
+	Shutdown(ctx context.Context) error
 }
 
 type server struct {
-	opts              *serverOptions            // 配置
-	listener          net.Listener              // 监听器
-	connMgr           *serverConnMgr            // 连接管理器
-	startHandler      network.StartHandler      // 服务器启动hook函数
-	stopHandler       network.CloseHandler      // 服务器关闭hook函数
-	connectHandler    network.ConnectHandler    // 连接打开hook函数
-	disconnectHandler network.DisconnectHandler // 连接关闭hook函数
-	receiveHandler    network.ReceiveHandler    // 接收消息hook函数
-	upgradeHandler    UpgradeHandler            // HTTP协议升级成WS协议hook函数
+	opts              atomic.Pointer[serverOptions] // 配置
+	rawOpts           []ServerOption                // 原始配置项，重新加载配置时使用
+	listener          net.Listener                  // 监听器
+	rw                sync.RWMutex                  // 锁
+	handlers          []*httpHandler                // 额外挂载的HTTP处理器
+	mux               *http.ServeMux                // 路由复用器，每次启动时重建
+	httpServer        *http.Server                  // HTTP服务器，每次启动时重建
+	upgrader          *websocket.Upgrader           // 协议升级器，每次启动时重建
+	connMgr           *serverConnMgr                // 连接管理器
+	unwatch           func()                        // 取消配置监听
+	startHandler      network.StartHandler          // 服务器启动hook函数
+	stopHandler       network.CloseHandler          // 服务器关闭hook函数
+	connectHandler    network.ConnectHandler        // 连接打开hook函数
+	disconnectHandler network.DisconnectHandler     // 连接关闭hook函数
+	receiveHandler    network.ReceiveHandler        // 接收消息hook函数
+	upgradeHandler    UpgradeHandler                // HTTP协议升级成WS协议hook函数
+}
+
+type httpHandler struct {
+	pattern string
+	handler http.Handler
 }
 
 var _ Server = &server{}
 
+var _ network.Restarter = &server{}
+
 func NewServer(opts ...ServerOption) Server {
 	o := defaultServerOptions()
 	for _, opt := range opts {
@@ -47,15 +73,39 @@ func NewServer(opts ...ServerOption) Server {
 	}
 
 	s := &server{}
-	s.opts = o
+	s.opts.Store(o)
+	s.rawOpts = opts
 	s.connMgr = newConnMgr(s)
 
 	return s
 }
 
+// 构建配置
+func (s *server) buildOptions() *serverOptions {
+	o := defaultServerOptions()
+	for _, opt := range s.rawOpts {
+		opt(o)
+	}
+
+	return o
+}
+
+// 重新加载配置
+// 监听地址等启动配置无法在运行期间变更，仅最大连接数与心跳配置支持热更新
+func (s *server) reload() {
+	o := s.buildOptions()
+
+	newOpts := *s.opts.Load()
+	newOpts.maxConnNum = o.maxConnNum
+	newOpts.heartbeatInterval = o.heartbeatInterval
+	newOpts.heartbeatMechanism = o.heartbeatMechanism
+	newOpts.heartbeatWithServerTime = o.heartbeatWithServerTime
+	s.opts.Store(&newOpts)
+}
+
 // Addr 监听地址
 func (s *server) Addr() string {
-	return s.opts.addr
+	return s.opts.Load().addr
 }
 
 // Protocol 协议
@@ -69,87 +119,261 @@ func (s *server) Start() error {
 		return err
 	}
 
+	s.unwatch = etc.Watch(func(names ...string) { s.reload() })
+
 	if s.startHandler != nil {
 		s.startHandler()
 	}
 
-	xcall.Go(s.serve)
+	s.rw.RLock()
+	httpServer, listener := s.httpServer, s.listener
+	s.rw.RUnlock()
+
+	xcall.Go(func() { s.serve(httpServer, listener) })
+
+	return nil
+}
+
+// Restart 重启服务器
+// 重新加载全部配置，仅当监听地址、路径或证书配置发生变更时重建HTTP服务器，已升级的websocket连接不受影响
+// 新的HTTP服务器创建失败时保留原有的HTTP服务器与配置
+func (s *server) Restart() error {
+	oldOpts, newOpts := s.opts.Load(), s.buildOptions()
+
+	if newOpts.addr == oldOpts.addr &&
+		newOpts.path == oldOpts.path &&
+		newOpts.certFile == oldOpts.certFile &&
+		newOpts.keyFile == oldOpts.keyFile {
+		s.opts.Store(newOpts)
+
+		s.rw.Lock()
+		s.upgrader = newUpgrader(newOpts)
+		s.rw.Unlock()
+
+		return nil
+	}
+
+	if newOpts.certFile != "" && newOpts.keyFile != "" {
+		if _, err := tls.LoadX509KeyPair(newOpts.certFile, newOpts.keyFile); err != nil {
+			return err
+		}
+	}
+
+	var (
+		listener net.Listener
+		err      error
+	)
+
+	if newOpts.addr != oldOpts.addr {
+		// 监听地址变更时先监听新地址，成功后再关闭原HTTP服务器
+		if listener, err = s.listen(newOpts); err != nil {
+			return err
+		}
+
+		if err = s.shutdownHTTPServer(newOpts); err != nil {
+			log.Warnf("websocket server shutdown failed: %v", err)
+		}
+	} else {
+		// 监听地址未变更时需先关闭原HTTP服务器才能重新监听，重新监听失败时恢复原HTTP服务器
+		if err = s.shutdownHTTPServer(newOpts); err != nil {
+			return err
+		}
+
+		if listener, err = s.listen(newOpts); err != nil {
+			old, e := s.listen(oldOpts)
+			if e != nil {
+				return errors.Join(err, e)
+			}
+
+			s.start(oldOpts, old)
+
+			return err
+		}
+	}
+
+	s.opts.Store(newOpts)
+
+	s.start(newOpts, listener)
 
 	return nil
 }
 
 // Stop 关闭服务器
 func (s *server) Stop() error {
-	if err := s.listener.Close(); err != nil {
-		return err
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Load().shutdownTimeout)
+	defer cancel()
+
+	return s.Shutdown(ctx)
+}
+
+// Shutdown 优雅关闭服务器
+// 已升级的websocket连接不受HTTP服务器管理，需在HTTP服务器关闭后由连接管理器统一关闭
+func (s *server) Shutdown(ctx context.Context) error {
+	s.rw.RLock()
+	httpServer := s.httpServer
+	s.rw.RUnlock()
+
+	if httpServer == nil {
+		return nil
 	}
 
+	s.unwatch()
+
+	err := httpServer.Shutdown(ctx)
+
 	s.connMgr.close()
 
-	return nil
+	return err
+}
+
+// Handle 挂载额外的HTTP处理器，可在启动前后调用，重启后依然有效
+func (s *server) Handle(pattern string, handler http.Handler) {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	s.handlers = append(s.handlers, &httpHandler{pattern: pattern, handler: handler})
+
+	if s.mux != nil {
+		s.mux.Handle(pattern, handler)
+	}
+}
+
+// HandleFunc 挂载额外的HTTP处理函数
+func (s *server) HandleFunc(pattern string, handler func(w http.ResponseWriter, r *http.Request)) {
+	s.Handle(pattern, http.HandlerFunc(handler))
 }
 
 // 初始化服务器
 func (s *server) init() error {
-	addr, err := net.ResolveTCPAddr("tcp", s.opts.addr)
+	opts := s.opts.Load()
+
+	listener, err := s.listen(opts)
 	if err != nil {
 		return err
 	}
 
-	ln, err := net.ListenTCP(addr.Network(), addr)
+	s.build(opts, listener)
+
+	return nil
+}
+
+// 创建监听器
+func (s *server) listen(opts *serverOptions) (net.Listener, error) {
+	addr, err := net.ResolveTCPAddr("tcp", opts.addr)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return net.ListenTCP(addr.Network(), addr)
+}
+
+// 构建HTTP服务器
+// http.Server关闭后无法再次启动，因此每次启动时均重建路由复用器与HTTP服务器
+func (s *server) build(opts *serverOptions, listener net.Listener) {
+	upgrader := newUpgrader(opts)
+
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(opts.path, s.handleUpgrade)
+	for _, h := range s.handlers {
+		mux.Handle(h.pattern, h.handler)
 	}
 
-	s.listener = ln
+	s.listener = listener
+	s.mux = mux
+	s.upgrader = upgrader
+	s.httpServer = &http.Server{Handler: mux}
+}
+
+// 在指定监听器上构建并启动HTTP服务器
+func (s *server) start(opts *serverOptions, listener net.Listener) {
+	s.build(opts, listener)
 
-	return nil
+	s.rw.RLock()
+	httpServer := s.httpServer
+	s.rw.RUnlock()
+
+	xcall.Go(func() { s.serve(httpServer, listener) })
 }
 
-// 启动服务器
-func (s *server) serve() {
-	upgrader := websocket.Upgrader{
+// 关闭当前的HTTP服务器，已升级的websocket连接不受影响
+func (s *server) shutdownHTTPServer(opts *serverOptions) error {
+	s.rw.RLock()
+	httpServer := s.httpServer
+	s.rw.RUnlock()
+
+	if httpServer == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.shutdownTimeout)
+	defer cancel()
+
+	return httpServer.Shutdown(ctx)
+}
+
+// 创建协议升级器
+func newUpgrader(opts *serverOptions) *websocket.Upgrader {
+	upgrader := &websocket.Upgrader{
 		ReadBufferSize:    4096,
 		WriteBufferSize:   4096,
 		EnableCompression: true,
-		CheckOrigin:       s.opts.checkOrigin,
+		CheckOrigin:       opts.checkOrigin,
 	}
 
-	http.HandleFunc(s.opts.path, func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
-			return
-		}
-
-		if s.upgradeHandler != nil && !s.upgradeHandler(w, r) {
-			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-			return
-		}
+	if opts.jsonSubprotocol != "" {
+		upgrader.Subprotocols = []string{opts.jsonSubprotocol}
+	}
 
-		conn, err := upgrader.Upgrade(w, r, nil)
-		if err != nil {
-			log.Errorf("websocket upgrade error: %v", err)
-			return
-		}
+	return upgrader
+}
 
-		if err = s.connMgr.allocate(conn); err != nil {
-			log.Errorf("connection allocate error: %v", err)
-			_ = conn.Close()
-		}
-	})
+// 启动服务器
+func (s *server) serve(httpServer *http.Server, listener net.Listener) {
+	opts := s.opts.Load()
 
 	var err error
-	if s.opts.certFile != "" && s.opts.keyFile != "" {
-		err = http.ServeTLS(s.listener, nil, s.opts.certFile, s.opts.keyFile)
+	if opts.certFile != "" && opts.keyFile != "" {
+		err = httpServer.ServeTLS(listener, opts.certFile, opts.keyFile)
 	} else {
-		err = http.Serve(s.listener, nil)
+		err = httpServer.Serve(listener)
 	}
 
-	if err != nil {
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Errorf("websocket server shutdown, err: %v", err)
 	}
 }
 
+// 处理HTTP协议升级
+func (s *server) handleUpgrade(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.upgradeHandler != nil && !s.upgradeHandler(w, r) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	s.rw.RLock()
+	upgrader := s.upgrader
+	s.rw.RUnlock()
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("websocket upgrade error: %v", err)
+		return
+	}
+
+	if err = s.connMgr.allocate(conn); err != nil {
+		log.Errorf("connection allocate error: %v", err)
+		_ = conn.Close()
+	}
+}
+
 // OnStart 监听服务器启动
 func (s *server) OnStart(handler network.StartHandler) {
 	s.startHandler = handler
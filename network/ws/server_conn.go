@@ -34,9 +34,13 @@ type serverConn struct {
 	done              chan struct{}   // 写入完成信号
 	close             chan struct{}   // 关闭信号
 	lastHeartbeatTime int64           // 上次心跳时间
+	heartbeatInterval time.Duration   // 心跳定时器的间隔时间，仅在写入协程中访问
+	jsonMode          bool            // 是否为JSON模式，由客户端通过子协议协商
+	jsonEncoding      JSONEncoding    // JSON模式下消息内容的编码方式
 }
 
 var _ network.Conn = &serverConn{}
+var _ network.BufferPusher = &serverConn{}
 
 // ID 获取连接ID
 func (c *serverConn) ID() int64 {
@@ -86,6 +90,22 @@ func (c *serverConn) Push(msg []byte) error {
 	return nil
 }
 
+// PushBuffer 推送共享缓冲区（异步），写入完成后释放引用
+func (c *serverConn) PushBuffer(buf *network.Buffer) error {
+	c.rw.RLock()
+	defer c.rw.RUnlock()
+
+	if err := c.checkState(); err != nil {
+		return err
+	}
+
+	buf.Retain()
+
+	c.chLowWrite <- chWrite{typ: dataPacket, msg: buf.Bytes(), buf: buf}
+
+	return nil
+}
+
 // State 获取连接状态
 func (c *serverConn) State() network.ConnState {
 	return network.ConnState(atomic.LoadInt32(&c.state))
@@ -154,6 +174,8 @@ func (c *serverConn) init(id int64, conn *websocket.Conn, cm *serverConnMgr) {
 	c.done = make(chan struct{})
 	c.close = make(chan struct{})
 	c.lastHeartbeatTime = xtime.Now().UnixNano()
+	c.jsonMode = cm.server.opts.Load().jsonSubprotocol != "" && conn.Subprotocol() == cm.server.opts.Load().jsonSubprotocol
+	c.jsonEncoding = cm.server.opts.Load().jsonEncoding
 	atomic.StoreInt64(&c.uid, 0)
 	atomic.StoreInt32(&c.state, int32(network.ConnOpened))
 
@@ -197,6 +219,7 @@ func (c *serverConn) graceClose(isNeedRecycle bool) (err error) {
 	atomic.StoreInt32(&c.state, int32(network.ConnClosed))
 	close(c.chLowWrite)
 	close(c.chHighWrite)
+	c.drain()
 	close(c.close)
 	close(c.done)
 	err = c.conn.Close()
@@ -225,6 +248,7 @@ func (c *serverConn) forceClose() (err error) {
 	atomic.StoreInt32(&c.state, int32(network.ConnClosed))
 	close(c.chLowWrite)
 	close(c.chHighWrite)
+	c.drain()
 	close(c.close)
 	close(c.done)
 	err = c.conn.Close()
@@ -239,6 +263,17 @@ func (c *serverConn) forceClose() (err error) {
 	return
 }
 
+// 释放写入队列中未发送的共享缓冲区
+func (c *serverConn) drain() {
+	for _, ch := range []chan chWrite{c.chHighWrite, c.chLowWrite} {
+		for r := range ch {
+			if r.buf != nil {
+				r.buf.Release()
+			}
+		}
+	}
+}
+
 // 读取消息
 func (c *serverConn) read() {
 	conn := c.conn
@@ -259,13 +294,11 @@ func (c *serverConn) read() {
 				return
 			}
 
-			if msgType != websocket.BinaryMessage {
+			if msgType != websocket.BinaryMessage && (msgType != websocket.TextMessage || !c.jsonMode) {
 				continue
 			}
 
-			if c.connMgr.server.opts.heartbeatInterval > 0 {
-				atomic.StoreInt64(&c.lastHeartbeatTime, xtime.Now().UnixNano())
-			}
+			atomic.StoreInt64(&c.lastHeartbeatTime, xtime.Now().UnixNano())
 
 			switch c.State() {
 			case network.ConnHanged:
@@ -281,17 +314,28 @@ func (c *serverConn) read() {
 				continue
 			}
 
-			// check heartbeat packet
-			isHeartbeat, err := packet.CheckHeartbeat(msg)
-			if err != nil {
-				log.Errorf("check heartbeat message error: %v", err)
-				continue
+			var isHeartbeat bool
+
+			if msgType == websocket.TextMessage {
+				// decode json text frame into data packet
+				isHeartbeat, msg, err = decodeText(msg, c.jsonEncoding)
+				if err != nil {
+					log.Errorf("decode text message error: %v", err)
+					continue
+				}
+			} else {
+				// check heartbeat packet
+				isHeartbeat, err = packet.CheckHeartbeat(msg)
+				if err != nil {
+					log.Errorf("check heartbeat message error: %v", err)
+					continue
+				}
 			}
 
 			// ignore heartbeat packet
 			if isHeartbeat {
 				// responsive heartbeat
-				if c.connMgr.server.opts.heartbeatMechanism == RespHeartbeat {
+				if c.connMgr.server.opts.Load().heartbeatMechanism == RespHeartbeat {
 					c.rw.RLock()
 					c.chHighWrite <- chWrite{typ: heartbeatPacket}
 					c.rw.RUnlock()
@@ -309,17 +353,11 @@ func (c *serverConn) read() {
 // 写入消息
 // 由于gorilla/websocket库并发写入的限制，同时为了保证心跳能够优先下发到客户端，故而实现一个优先队列
 func (c *serverConn) write() {
-	var (
-		conn   = c.conn
-		ticker *time.Ticker
-	)
+	conn := c.conn
 
-	if c.connMgr.server.opts.heartbeatInterval > 0 {
-		ticker = time.NewTicker(c.connMgr.server.opts.heartbeatInterval)
-		defer ticker.Stop()
-	} else {
-		ticker = &time.Ticker{C: make(chan time.Time, 1)}
-	}
+	c.heartbeatInterval = c.connMgr.server.opts.Load().heartbeatInterval
+	ticker := time.NewTicker(tickerInterval(c.heartbeatInterval))
+	defer ticker.Stop()
 
 	for {
 		select {
@@ -332,7 +370,7 @@ func (c *serverConn) write() {
 				return
 			}
 		case <-ticker.C:
-			if !c.doHandleHeartbeat(conn) {
+			if !c.doHandleHeartbeat(conn, ticker) {
 				return
 			}
 		default:
@@ -354,7 +392,7 @@ func (c *serverConn) write() {
 					return
 				}
 			case <-ticker.C:
-				if !c.doHandleHeartbeat(conn) {
+				if !c.doHandleHeartbeat(conn, ticker) {
 					return
 				}
 			}
@@ -371,21 +409,36 @@ func (c *serverConn) doWrite(conn *websocket.Conn, r chWrite) bool {
 		return false
 	}
 
+	if r.buf != nil {
+		defer r.buf.Release()
+	}
+
 	if c.isClosed() {
 		return false
 	}
 
+	var (
+		msgType = websocket.BinaryMessage
+		msg     = r.msg
+		err     error
+	)
+
 	if r.typ == heartbeatPacket {
-		if msg, err := packet.PackHeartbeat(); err != nil {
+		if msgType, msg, err = c.packHeartbeat(); err != nil {
 			log.Errorf("pack heartbeat message error: %v", err)
 			return true
-		} else {
-			r.msg = msg
 		}
+	} else if c.jsonMode {
+		msgType = websocket.TextMessage
+		msg, err = encodeText(r.msg, c.jsonEncoding)
 	}
 
-	if err := conn.WriteMessage(websocket.BinaryMessage, r.msg); err != nil {
-		if !errors.Is(err, net.ErrClosed) {
+	if err != nil {
+		log.Errorf("encode text message error: %v", err)
+	} else {
+		err = conn.WriteMessage(msgType, msg)
+
+		if err != nil && !errors.Is(err, net.ErrClosed) {
 			if _, ok := err.(*websocket.CloseError); !ok {
 				log.Errorf("write message error: %v", err)
 			}
@@ -396,23 +449,32 @@ func (c *serverConn) doWrite(conn *websocket.Conn, r chWrite) bool {
 }
 
 // 处理心跳
-func (c *serverConn) doHandleHeartbeat(conn *websocket.Conn) bool {
-	deadline := xtime.Now().Add(-2 * c.connMgr.server.opts.heartbeatInterval).UnixNano()
+func (c *serverConn) doHandleHeartbeat(conn *websocket.Conn, ticker *time.Ticker) bool {
+	c.resetTicker(ticker)
+
+	opts := c.connMgr.server.opts.Load()
+
+	// 心跳检测已被热更新关闭
+	if opts.heartbeatInterval <= 0 {
+		return true
+	}
+
+	deadline := xtime.Now().Add(-2 * opts.heartbeatInterval).UnixNano()
 	if atomic.LoadInt64(&c.lastHeartbeatTime) < deadline {
 		log.Debugf("connection heartbeat timeout, cid: %d", c.id)
 		_ = c.forceClose()
 		return false
 	} else {
-		if c.connMgr.server.opts.heartbeatMechanism == TickHeartbeat {
+		if opts.heartbeatMechanism == TickHeartbeat {
 			if c.isClosed() {
 				return false
 			}
 
-			if heartbeat, err := packet.PackHeartbeat(); err != nil {
+			if msgType, heartbeat, err := c.packHeartbeat(); err != nil {
 				log.Errorf("pack heartbeat message error: %v", err)
 			} else {
 				// send heartbeat packet
-				if err := conn.WriteMessage(websocket.BinaryMessage, heartbeat); err != nil {
+				if err := conn.WriteMessage(msgType, heartbeat); err != nil {
 					log.Errorf("write heartbeat message error: %v", err)
 				}
 			}
@@ -422,6 +484,37 @@ func (c *serverConn) doHandleHeartbeat(conn *websocket.Conn) bool {
 	return true
 }
 
+// 打包心跳，JSON模式下打包为文本帧
+func (c *serverConn) packHeartbeat() (int, []byte, error) {
+	if c.jsonMode {
+		heartbeat, err := packTextHeartbeat(c.connMgr.server.opts.Load().heartbeatWithServerTime)
+		return websocket.TextMessage, heartbeat, err
+	}
+
+	heartbeat, err := packet.PackHeartbeat()
+	return websocket.BinaryMessage, heartbeat, err
+}
+
+// 心跳间隔时间被热更新时重置心跳定时器
+func (c *serverConn) resetTicker(ticker *time.Ticker) {
+	interval := c.connMgr.server.opts.Load().heartbeatInterval
+	if interval == c.heartbeatInterval {
+		return
+	}
+
+	c.heartbeatInterval = interval
+	ticker.Reset(tickerInterval(interval))
+}
+
+// 计算心跳定时器的间隔时间，心跳检测关闭时以固定间隔检测配置变更
+func tickerInterval(heartbeatInterval time.Duration) time.Duration {
+	if heartbeatInterval > 0 {
+		return heartbeatInterval
+	}
+
+	return heartbeatCheckInterval
+}
+
 // 是否已关闭
 func (c *serverConn) isClosed() bool {
 	return network.ConnState(atomic.LoadInt32(&c.state)) == network.ConnClosed
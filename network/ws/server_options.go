@@ -12,7 +12,9 @@ const (
 	defaultServerMaxConnNum              = 5000
 	defaultServerCheckOrigin             = "*"
 	defaultServerHandshakeTimeout        = "10s"
+	defaultServerShutdownTimeout         = "5s"
 	defaultServerHeartbeatInterval       = "10s"
+	defaultServerJSONEncoding            = "raw"
 	defaultServerHeartbeatMechanism      = "resp"
 	defaultServerHeartbeatWithServerTime = true
 )
@@ -25,11 +27,17 @@ const (
 	defaultServerKeyFileKey                 = "etc.network.ws.server.keyFile"
 	defaultServerCertFileKey                = "etc.network.ws.server.certFile"
 	defaultServerHandshakeTimeoutKey        = "etc.network.ws.server.handshakeTimeout"
+	defaultServerShutdownTimeoutKey         = "etc.network.ws.server.shutdownTimeout"
+	defaultServerJSONSubprotocolKey         = "etc.network.ws.server.jsonSubprotocol"
+	defaultServerJSONEncodingKey            = "etc.network.ws.server.jsonEncoding"
 	defaultServerHeartbeatIntervalKey       = "etc.network.ws.server.heartbeatInterval"
 	defaultServerHeartbeatMechanismKey      = "etc.network.ws.server.heartbeatMechanism"
 	defaultServerHeartbeatWithServerTimeKey = "etc.network.ws.server.heartbeatWithServerTime"
 )
 
+// 心跳检测关闭时检测心跳配置变更的间隔时间
+const heartbeatCheckInterval = 10 * time.Second
+
 const (
 	RespHeartbeat HeartbeatMechanism = "resp" // 响应式心跳
 	TickHeartbeat HeartbeatMechanism = "tick" // 主动定时心跳
@@ -37,6 +45,13 @@ const (
 
 type HeartbeatMechanism string
 
+const (
+	RawJSONEncoding    JSONEncoding = "raw"    // 消息内容为JSON，原样作为data字段
+	Base64JSONEncoding JSONEncoding = "base64" // 消息内容为任意二进制数据，以base64字符串作为data字段
+)
+
+type JSONEncoding string
+
 type ServerOption func(o *serverOptions)
 
 type CheckOriginFunc func(r *http.Request) bool
@@ -49,6 +64,9 @@ type serverOptions struct {
 	path                    string             // 路径，默认为"/"
 	checkOrigin             CheckOriginFunc    // 跨域检测
 	handshakeTimeout        time.Duration      // 握手超时时间，默认10s
+	shutdownTimeout         time.Duration      // 优雅关闭超时时间，默认5s
+	jsonSubprotocol         string             // JSON模式的子协议名称，为空时不启用JSON模式
+	jsonEncoding            JSONEncoding       // JSON模式下消息内容的编码方式，默认raw
 	heartbeatInterval       time.Duration      // 心跳间隔时间，默认10s
 	heartbeatMechanism      HeartbeatMechanism // 心跳机制，默认resp
 	heartbeatWithServerTime bool               // 下行心跳是否携带服务器时间，默认为true
@@ -79,6 +97,9 @@ func defaultServerOptions() *serverOptions {
 		keyFile:                 etc.Get(defaultServerKeyFileKey).String(),
 		certFile:                etc.Get(defaultServerCertFileKey).String(),
 		handshakeTimeout:        etc.Get(defaultServerHandshakeTimeoutKey, defaultServerHandshakeTimeout).Duration(),
+		shutdownTimeout:         etc.Get(defaultServerShutdownTimeoutKey, defaultServerShutdownTimeout).Duration(),
+		jsonSubprotocol:         etc.Get(defaultServerJSONSubprotocolKey).String(),
+		jsonEncoding:            JSONEncoding(etc.Get(defaultServerJSONEncodingKey, defaultServerJSONEncoding).String()),
 		heartbeatInterval:       etc.Get(defaultServerHeartbeatIntervalKey, defaultServerHeartbeatInterval).Duration(),
 		heartbeatMechanism:      HeartbeatMechanism(etc.Get(defaultServerHeartbeatMechanismKey, defaultServerHeartbeatMechanism).String()),
 		heartbeatWithServerTime: etc.Get(defaultServerHeartbeatWithServerTimeKey, defaultServerHeartbeatWithServerTime).Bool(),
@@ -115,6 +136,23 @@ func WithServerHandshakeTimeout(handshakeTimeout time.Duration) ServerOption {
 	return func(o *serverOptions) { o.handshakeTimeout = handshakeTimeout }
 }
 
+// WithServerShutdownTimeout 设置优雅关闭超时时间
+func WithServerShutdownTimeout(shutdownTimeout time.Duration) ServerOption {
+	return func(o *serverOptions) { o.shutdownTimeout = shutdownTimeout }
+}
+
+// WithServerJSONSubprotocol 设置JSON模式的子协议名称
+// 客户端通过Sec-WebSocket-Protocol协商该子协议后，将以{"route":1,"seq":1,"data":{}}格式的文本帧收发消息
+func WithServerJSONSubprotocol(jsonSubprotocol string) ServerOption {
+	return func(o *serverOptions) { o.jsonSubprotocol = jsonSubprotocol }
+}
+
+// WithServerJSONEncoding 设置JSON模式下消息内容的编码方式
+// raw模式下消息内容须为合法的JSON，base64模式下消息内容以base64字符串收发
+func WithServerJSONEncoding(jsonEncoding JSONEncoding) ServerOption {
+	return func(o *serverOptions) { o.jsonEncoding = jsonEncoding }
+}
+
 // WithServerHeartbeatInterval 设置心跳检测间隔时间
 func WithServerHeartbeatInterval(heartbeatInterval time.Duration) ServerOption {
 	return func(o *serverOptions) { o.heartbeatInterval = heartbeatInterval }
@@ -13,6 +13,8 @@ import (
 	"github.com/dobyte/due/v2/network"
 	"github.com/dobyte/due/v2/packet"
 	"github.com/dobyte/due/v2/utils/xcall"
+	"github.com/gorilla/websocket"
+	"io"
 	"net/http"
 	"testing"
 )
@@ -110,3 +112,175 @@ func TestServer_Benchmark(t *testing.T) {
 
 	select {}
 }
+
+func TestServer_MultipleServers(t *testing.T) {
+	addrs := []string{"127.0.0.1:3555", "127.0.0.1:3556"}
+	servers := make([]ws.Server, 0, len(addrs))
+
+	for _, addr := range addrs {
+		addr := addr
+		server := ws.NewServer(ws.WithServerListenAddr(addr))
+		server.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(addr))
+		})
+
+		if err := server.Start(); err != nil {
+			t.Fatal(err)
+		}
+		defer server.Stop()
+
+		servers = append(servers, server)
+	}
+
+	for _, addr := range addrs {
+		if body := get(t, "http://"+addr+"/health"); body != addr {
+			t.Fatalf("invalid health response: %s", body)
+		}
+
+		conn, _, err := websocket.DefaultDialer.Dial("ws://"+addr, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = conn.Close()
+	}
+
+	// 停止后再次启动，额外挂载的处理器依然有效
+	if err := servers[0].Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := servers[0].Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if body := get(t, "http://"+addrs[0]+"/health"); body != addrs[0] {
+		t.Fatalf("invalid health response after restart: %s", body)
+	}
+}
+
+func get(t *testing.T, url string) string {
+	rsp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(body)
+}
+
+func TestServer_JSONSubprotocol(t *testing.T) {
+	server := ws.NewServer(
+		ws.WithServerListenAddr(":3554"),
+		ws.WithServerJSONSubprotocol("json"),
+	)
+	server.OnReceive(func(conn network.Conn, msg []byte) {
+		message, err := packet.UnpackMessage(msg)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		msg, err = packet.PackMessage(&packet.Message{
+			Seq:    message.Seq,
+			Route:  message.Route,
+			Buffer: message.Buffer,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err = conn.Push(msg); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"json"}}
+
+	conn, _, err := dialer.Dial("ws://127.0.0.1:3554", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if conn.Subprotocol() != "json" {
+		t.Fatalf("subprotocol not negotiated: %s", conn.Subprotocol())
+	}
+
+	if err = conn.WriteMessage(websocket.TextMessage, []byte(`{"route":1,"seq":2,"data":{"name":"fuxiao"}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	msgType, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msgType != websocket.TextMessage {
+		t.Fatalf("invalid message type: %d", msgType)
+	}
+
+	if string(msg) != `{"route":1,"seq":2,"data":{"name":"fuxiao"}}` {
+		t.Fatalf("invalid message: %s", string(msg))
+	}
+}
+
+func TestServer_JSONBase64Encoding(t *testing.T) {
+	server := ws.NewServer(
+		ws.WithServerListenAddr(":3555"),
+		ws.WithServerJSONSubprotocol("json"),
+		ws.WithServerJSONEncoding(ws.Base64JSONEncoding),
+	)
+	server.OnReceive(func(conn network.Conn, msg []byte) {
+		message, err := packet.UnpackMessage(msg)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if string(message.Buffer) != "\x00\x01{" {
+			t.Errorf("invalid message buffer: %v", message.Buffer)
+			return
+		}
+
+		if err = conn.Push(msg); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"json"}}
+
+	conn, _, err := dialer.Dial("ws://127.0.0.1:3555", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err = conn.WriteMessage(websocket.TextMessage, []byte(`{"route":1,"seq":2,"data":"AAF7"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(msg) != `{"route":1,"seq":2,"data":"AAF7"}` {
+		t.Fatalf("invalid message: %s", string(msg))
+	}
+}
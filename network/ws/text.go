@@ -0,0 +1,79 @@
+package ws
+
+import (
+	"encoding/json"
+	"github.com/dobyte/due/v2/packet"
+	"github.com/dobyte/due/v2/utils/xtime"
+)
+
+// 文本消息，格式为{"route":1,"seq":1,"data":{}}，心跳格式为{"heartbeat":true}
+type textMessage struct {
+	Route     int32           `json:"route,omitempty"`     // 路由ID
+	Seq       int32           `json:"seq,omitempty"`       // 序列号
+	Data      json.RawMessage `json:"data,omitempty"`      // 消息内容
+	Heartbeat bool            `json:"heartbeat,omitempty"` // 是否为心跳
+	Time      int64           `json:"time,omitempty"`      // 服务器时间（毫秒），仅下行心跳携带
+}
+
+// 解码文本帧，非心跳消息将被打包为与二进制帧一致的数据包
+func decodeText(data []byte, encoding JSONEncoding) (isHeartbeat bool, msg []byte, err error) {
+	message := &textMessage{}
+
+	if err = json.Unmarshal(data, message); err != nil {
+		return
+	}
+
+	if message.Heartbeat {
+		isHeartbeat = true
+		return
+	}
+
+	buffer := []byte(message.Data)
+
+	if encoding == Base64JSONEncoding && len(message.Data) > 0 {
+		if err = json.Unmarshal(message.Data, &buffer); err != nil {
+			return
+		}
+	}
+
+	msg, err = packet.PackMessage(&packet.Message{
+		Seq:    message.Seq,
+		Route:  message.Route,
+		Buffer: buffer,
+	})
+
+	return
+}
+
+// 编码文本帧，为解码文本帧的逆操作
+func encodeText(msg []byte, encoding JSONEncoding) ([]byte, error) {
+	message, err := packet.UnpackMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	text := &textMessage{Route: message.Route, Seq: message.Seq}
+
+	if len(message.Buffer) > 0 {
+		if encoding == Base64JSONEncoding {
+			if text.Data, err = json.Marshal(message.Buffer); err != nil {
+				return nil, err
+			}
+		} else {
+			text.Data = message.Buffer
+		}
+	}
+
+	return json.Marshal(text)
+}
+
+// 打包文本心跳
+func packTextHeartbeat(isWithTime bool) ([]byte, error) {
+	text := &textMessage{Heartbeat: true}
+
+	if isWithTime {
+		text.Time = xtime.Now().UnixMilli()
+	}
+
+	return json.Marshal(text)
+}
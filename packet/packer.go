@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"encoding/binary"
 	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/etc"
 	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/network"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -29,9 +32,11 @@ type Packer interface {
 }
 
 type defaultPacker struct {
-	opts      *options
-	once      sync.Once
-	heartbeat []byte
+	opts        *options
+	once        sync.Once
+	heartbeat   []byte
+	bufferBytes int64
+	unwatch     func()
 }
 
 func NewPacker(opts ...Option) Packer {
@@ -52,7 +57,7 @@ func NewPacker(opts ...Option) Packer {
 		log.Fatalf("the number of buffer bytes must be greater than or equal to 0, and give %d", o.bufferBytes)
 	}
 
-	p := &defaultPacker{opts: o}
+	p := &defaultPacker{opts: o, bufferBytes: int64(o.bufferBytes)}
 
 	if !o.heartbeatTime {
 		buf := &bytes.Buffer{}
@@ -66,9 +71,32 @@ func NewPacker(opts ...Option) Packer {
 		p.heartbeat = buf.Bytes()
 	}
 
+	p.unwatch = etc.Watch(func(names ...string) { p.reload(opts...) })
+
 	return p
 }
 
+// 重新加载配置
+// 字节序、路由字节数、序列号字节数等属于通信协议的一部分，运行期间无法变更，仅消息字节数支持热更新
+func (p *defaultPacker) reload(opts ...Option) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.bufferBytes < 0 {
+		log.Errorf("the number of buffer bytes must be greater than or equal to 0, and give %d", o.bufferBytes)
+		return
+	}
+
+	atomic.StoreInt64(&p.bufferBytes, int64(o.bufferBytes))
+}
+
+// Close 关闭打包器，取消配置监听
+func (p *defaultPacker) Close() {
+	p.unwatch()
+}
+
 // ReadMessage 读取消息
 func (p *defaultPacker) ReadMessage(reader io.Reader) ([]byte, error) {
 	buf := make([]byte, defaultSizeBytes)
@@ -96,67 +124,83 @@ func (p *defaultPacker) ReadMessage(reader io.Reader) ([]byte, error) {
 
 // PackMessage 打包消息
 func (p *defaultPacker) PackMessage(message *Message) ([]byte, error) {
+	size, err := p.sizeMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, size)
+
+	p.encodeMessage(buf, message)
+
+	return buf, nil
+}
+
+// PackBuffer 打包消息至共享缓冲区
+func (p *defaultPacker) PackBuffer(message *Message) (*network.Buffer, error) {
+	size, err := p.sizeMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := network.NewBuffer(size)
+
+	p.encodeMessage(buf.Bytes(), message)
+
+	return buf, nil
+}
+
+// 校验消息并计算打包后的消息字节数
+func (p *defaultPacker) sizeMessage(message *Message) (int, error) {
 	if message.Route > int32(1<<(8*p.opts.routeBytes-1)-1) || message.Route < int32(-1<<(8*p.opts.routeBytes-1)) {
-		return nil, errors.ErrRouteOverflow
+		return 0, errors.ErrRouteOverflow
 	}
 
 	if p.opts.seqBytes > 0 {
 		if message.Seq > int32(1<<(8*p.opts.seqBytes-1)-1) || message.Seq < int32(-1<<(8*p.opts.seqBytes-1)) {
-			return nil, errors.ErrSeqOverflow
+			return 0, errors.ErrSeqOverflow
 		}
 	}
 
-	if len(message.Buffer) > p.opts.bufferBytes {
-		return nil, errors.ErrBufferTooLarge
+	if int64(len(message.Buffer)) > atomic.LoadInt64(&p.bufferBytes) {
+		return 0, errors.ErrBufferTooLarge
 	}
 
-	var (
-		ln  = p.opts.routeBytes + p.opts.seqBytes + len(message.Buffer) + defaultHeaderBytes
-		buf = &bytes.Buffer{}
-	)
+	return defaultSizeBytes + defaultHeaderBytes + p.opts.routeBytes + p.opts.seqBytes + len(message.Buffer), nil
+}
 
-	buf.Grow(ln + defaultSizeBytes)
+// 将消息编码至缓冲区，缓冲区长度须与打包后的消息字节数一致
+func (p *defaultPacker) encodeMessage(buf []byte, message *Message) {
+	order := p.opts.byteOrder
 
-	err := binary.Write(buf, p.opts.byteOrder, int32(ln))
-	if err != nil {
-		return nil, err
-	}
+	order.PutUint32(buf, uint32(len(buf)-defaultSizeBytes))
+	buf[defaultSizeBytes] = dataBit
 
-	err = binary.Write(buf, p.opts.byteOrder, int8(dataBit))
-	if err != nil {
-		return nil, err
-	}
+	i := defaultSizeBytes + defaultHeaderBytes
 
 	switch p.opts.routeBytes {
 	case 1:
-		err = binary.Write(buf, p.opts.byteOrder, int8(message.Route))
+		buf[i] = byte(message.Route)
 	case 2:
-		err = binary.Write(buf, p.opts.byteOrder, int16(message.Route))
+		order.PutUint16(buf[i:], uint16(message.Route))
 	case 4:
-		err = binary.Write(buf, p.opts.byteOrder, message.Route)
-	}
-	if err != nil {
-		return nil, err
+		order.PutUint32(buf[i:], uint32(message.Route))
 	}
 
+	i += p.opts.routeBytes
+
 	switch p.opts.seqBytes {
 	case 1:
-		err = binary.Write(buf, p.opts.byteOrder, int8(message.Seq))
+		buf[i] = byte(message.Seq)
 	case 2:
-		err = binary.Write(buf, p.opts.byteOrder, int16(message.Seq))
+		order.PutUint16(buf[i:], uint16(message.Seq))
 	case 4:
-		err = binary.Write(buf, p.opts.byteOrder, message.Seq)
-	}
-	if err != nil {
-		return nil, err
+		order.PutUint32(buf[i:], uint32(message.Seq))
 	}
 
-	err = binary.Write(buf, p.opts.byteOrder, message.Buffer)
-	if err != nil {
-		return nil, err
-	}
+	i += p.opts.seqBytes
 
-	return buf.Bytes(), nil
+	copy(buf[i:], message.Buffer)
 }
 
 // UnpackMessage 解包消息
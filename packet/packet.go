@@ -1,6 +1,9 @@
 package packet
 
-import "io"
+import (
+	"github.com/dobyte/due/v2/network"
+	"io"
+)
 
 var globalPacker Packer
 
@@ -9,7 +12,12 @@ func init() {
 }
 
 // SetPacker 设置打包器
+// 原打包器实现了Close方法时将被关闭
 func SetPacker(packer Packer) {
+	if closer, ok := globalPacker.(interface{ Close() }); ok {
+		closer.Close()
+	}
+
 	globalPacker = packer
 }
 
@@ -28,6 +36,23 @@ func PackMessage(message *Message) ([]byte, error) {
 	return globalPacker.PackMessage(message)
 }
 
+// PackBuffer 打包消息至共享缓冲区，适用于将同一条消息推送给多个连接，使用完毕后需调用Release释放引用
+// 打包器未实现打包至共享缓冲区时，将打包后的消息包装为不可回收复用的共享缓冲区
+func PackBuffer(message *Message) (*network.Buffer, error) {
+	if packer, ok := globalPacker.(interface {
+		PackBuffer(message *Message) (*network.Buffer, error)
+	}); ok {
+		return packer.PackBuffer(message)
+	}
+
+	buf, err := globalPacker.PackMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return network.WrapBuffer(buf), nil
+}
+
 // UnpackMessage 解包消息
 func UnpackMessage(data []byte) (*Message, error) {
 	return globalPacker.UnpackMessage(data)
@@ -1,6 +1,7 @@
 package packet_test
 
 import (
+	"bytes"
 	"github.com/dobyte/due/v2/packet"
 	"testing"
 )
@@ -31,6 +32,39 @@ func TestDefaultPacker_PackMessage(t *testing.T) {
 	t.Logf("buffer: %s", string(message.Buffer))
 }
 
+func TestPackBuffer(t *testing.T) {
+	message := &packet.Message{
+		Seq:    1,
+		Route:  1,
+		Buffer: []byte("hello world"),
+	}
+
+	data, err := packet.PackMessage(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := packet.PackBuffer(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Release()
+
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Fatalf("buffer mismatch: %v != %v", buf.Bytes(), data)
+	}
+
+	// 打包结果需能被还原为原始消息，避免两种打包方式同时出错时无法发现
+	msg, err := packet.UnpackMessage(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Seq != message.Seq || msg.Route != message.Route || !bytes.Equal(msg.Buffer, message.Buffer) {
+		t.Fatalf("unpacked message mismatch: %+v != %+v", msg, message)
+	}
+}
+
 func TestPackHeartbeat(t *testing.T) {
 	data, err := packer.PackHeartbeat()
 	if err != nil {
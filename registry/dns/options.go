@@ -0,0 +1,175 @@
+package dns
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/registry"
+	"net"
+	"time"
+)
+
+const (
+	defaultDomain       = "%s.default.svc.cluster.local"
+	defaultPortName     = "meta"
+	defaultProtocol     = "tcp"
+	defaultMetadataAddr = ":3600"
+	defaultMetadataPath = "/registry/services"
+	defaultInterval     = "5s"
+	defaultTimeout      = "3s"
+)
+
+const (
+	defaultAddrKey         = "etc.registry.dns.addr"
+	defaultDomainKey       = "etc.registry.dns.domain"
+	defaultDomainsKey      = "etc.registry.dns.domains"
+	defaultPortNameKey     = "etc.registry.dns.portName"
+	defaultProtocolKey     = "etc.registry.dns.protocol"
+	defaultMetadataAddrKey = "etc.registry.dns.metadataAddr"
+	defaultMetadataPathKey = "etc.registry.dns.metadataPath"
+	defaultIntervalKey     = "etc.registry.dns.interval"
+	defaultTimeoutKey      = "etc.registry.dns.timeout"
+)
+
+// Resolver SRV记录解析器，*net.Resolver实现了该接口
+type Resolver interface {
+	// LookupSRV 解析SRV记录
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// Fetcher 服务实例元数据获取器，用于获取SRV记录指向的实例上注册的服务实例
+type Fetcher func(ctx context.Context, host string, port uint16, serviceName string) ([]*registry.ServiceInstance, error)
+
+type Option func(o *options)
+
+type options struct {
+	// 上下文
+	// 默认context.Background
+	ctx context.Context
+
+	// DNS服务器地址
+	// 默认为空，使用系统DNS配置
+	addr string
+
+	// 外部解析器
+	// 存在外部解析器时，优先使用外部解析器，默认为nil
+	resolver Resolver
+
+	// 服务域名模板，%s将被替换为服务名
+	// 默认为%s.default.svc.cluster.local，即Kubernetes默认命名空间下与服务名同名的Headless Service
+	domain string
+
+	// 服务名与服务域名的映射，存在映射时忽略服务域名模板
+	// 同一服务名可映射至多个域名，例如多个Deployment的节点服务器均注册为node服务；默认为空
+	domains map[string][]string
+
+	// SRV记录的端口名
+	// 即Headless Service中发布服务实例元数据的端口名，默认为meta
+	portName string
+
+	// SRV记录的协议
+	// 默认为tcp
+	protocol string
+
+	// 服务实例元数据的发布地址
+	// 注册服务实例后将在该地址上通过HTTP发布服务实例元数据，默认为:3600
+	metadataAddr string
+
+	// 服务实例元数据的发布路径
+	// 默认为/registry/services
+	metadataPath string
+
+	// 外部元数据获取器
+	// 默认通过HTTP获取服务实例元数据，可替换为通过传输器的RPC等方式获取
+	fetcher Fetcher
+
+	// 服务实例轮询间隔
+	// 默认为5秒
+	interval time.Duration
+
+	// 解析与获取元数据的超时时间
+	// 默认为3秒
+	timeout time.Duration
+}
+
+func defaultOptions() *options {
+	o := &options{
+		ctx:          context.Background(),
+		addr:         etc.Get(defaultAddrKey).String(),
+		domain:       etc.Get(defaultDomainKey, defaultDomain).String(),
+		portName:     etc.Get(defaultPortNameKey, defaultPortName).String(),
+		protocol:     etc.Get(defaultProtocolKey, defaultProtocol).String(),
+		metadataAddr: etc.Get(defaultMetadataAddrKey, defaultMetadataAddr).String(),
+		metadataPath: etc.Get(defaultMetadataPathKey, defaultMetadataPath).String(),
+		interval:     etc.Get(defaultIntervalKey, defaultInterval).Duration(),
+		timeout:      etc.Get(defaultTimeoutKey, defaultTimeout).Duration(),
+	}
+
+	_ = etc.Get(defaultDomainsKey).Scan(&o.domains)
+
+	return o
+}
+
+// WithContext 设置上下文
+func WithContext(ctx context.Context) Option {
+	return func(o *options) { o.ctx = ctx }
+}
+
+// WithAddr 设置DNS服务器地址
+func WithAddr(addr string) Option {
+	return func(o *options) { o.addr = addr }
+}
+
+// WithResolver 设置外部解析器
+func WithResolver(resolver Resolver) Option {
+	return func(o *options) { o.resolver = resolver }
+}
+
+// WithDomain 设置服务域名模板
+func WithDomain(domain string) Option {
+	return func(o *options) { o.domain = domain }
+}
+
+// WithDomains 设置服务名映射的服务域名
+func WithDomains(serviceName string, domains ...string) Option {
+	return func(o *options) {
+		if o.domains == nil {
+			o.domains = make(map[string][]string)
+		}
+		o.domains[serviceName] = domains
+	}
+}
+
+// WithPortName 设置SRV记录的端口名
+func WithPortName(portName string) Option {
+	return func(o *options) { o.portName = portName }
+}
+
+// WithProtocol 设置SRV记录的协议
+func WithProtocol(protocol string) Option {
+	return func(o *options) { o.protocol = protocol }
+}
+
+// WithMetadataAddr 设置服务实例元数据的发布地址
+func WithMetadataAddr(addr string) Option {
+	return func(o *options) { o.metadataAddr = addr }
+}
+
+// WithMetadataPath 设置服务实例元数据的发布路径
+func WithMetadataPath(path string) Option {
+	return func(o *options) { o.metadataPath = path }
+}
+
+// WithFetcher 设置外部元数据获取器
+func WithFetcher(fetcher Fetcher) Option {
+	return func(o *options) { o.fetcher = fetcher }
+}
+
+// WithInterval 设置服务实例轮询间隔
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) { o.interval = interval }
+}
+
+// WithTimeout 设置解析与获取元数据的超时时间
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
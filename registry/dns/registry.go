@@ -0,0 +1,318 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/registry"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var _ registry.Registry = &Registry{}
+
+// Registry 基于DNS SRV记录的服务注册发现，适用于Kubernetes的Headless Service
+// 服务发现时解析服务域名的SRV记录得到所有实例的元数据地址，再从各实例获取其注册的服务实例，服务实例的变化通过轮询感知
+// 服务注册时不会修改DNS记录，仅将服务实例通过HTTP发布至元数据地址，实例的上下线由Kubernetes的就绪检查控制
+type Registry struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	opts     *options
+	resolver Resolver
+	fetcher  Fetcher
+	client   *http.Client
+
+	rw        sync.RWMutex
+	server    *http.Server
+	instances map[string]map[string]*registry.ServiceInstance // 服务名 -> 服务实例ID -> 服务实例
+
+	mu    sync.Mutex
+	hosts map[string]map[string][]*registry.ServiceInstance // 服务名 -> 元数据地址 -> 最近一次获取成功的服务实例
+}
+
+func NewRegistry(opts ...Option) *Registry {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &Registry{}
+	r.ctx, r.cancel = context.WithCancel(o.ctx)
+	r.opts = o
+	r.instances = make(map[string]map[string]*registry.ServiceInstance)
+	r.hosts = make(map[string]map[string][]*registry.ServiceInstance)
+	r.client = &http.Client{Timeout: o.timeout}
+
+	if o.resolver != nil {
+		r.resolver = o.resolver
+	} else if o.addr != "" {
+		r.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, o.addr)
+			},
+		}
+	} else {
+		r.resolver = net.DefaultResolver
+	}
+
+	if o.fetcher != nil {
+		r.fetcher = o.fetcher
+	} else {
+		r.fetcher = r.fetch
+	}
+
+	return r
+}
+
+// Register 注册服务实例，首次注册时启动元数据发布服务器
+func (r *Registry) Register(ctx context.Context, ins *registry.ServiceInstance) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	if r.server == nil && r.opts.metadataAddr != "" {
+		if err := r.serve(); err != nil {
+			return err
+		}
+	}
+
+	instances, ok := r.instances[ins.Name]
+	if !ok {
+		instances = make(map[string]*registry.ServiceInstance)
+		r.instances[ins.Name] = instances
+	}
+
+	c := *ins
+	instances[ins.ID] = &c
+
+	return nil
+}
+
+// Deregister 解注册服务实例
+func (r *Registry) Deregister(ctx context.Context, ins *registry.ServiceInstance) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	if instances, ok := r.instances[ins.Name]; ok {
+		delete(instances, ins.ID)
+
+		if len(instances) == 0 {
+			delete(r.instances, ins.Name)
+		}
+	}
+
+	return nil
+}
+
+// Watch 监听相同服务名的服务实例变化
+func (r *Registry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
+	services, err := r.Services(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	w := newWatcher(r, serviceName)
+	w.update(services)
+
+	go w.poll()
+
+	return w, nil
+}
+
+// Services 获取服务实例列表
+// 从实例获取元数据失败时将沿用该实例最近一次获取成功的服务实例，避免因短暂的网络抖动导致服务实例被下线
+func (r *Registry) Services(ctx context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.timeout)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		instances = make(map[string]*registry.ServiceInstance)
+		hosts     = make(map[string][]*registry.ServiceInstance)
+	)
+
+	r.mu.Lock()
+	lasts := r.hosts[serviceName]
+	r.mu.Unlock()
+
+	for _, domain := range r.buildDomains(serviceName) {
+		_, srvs, err := r.resolver.LookupSRV(ctx, r.opts.portName, r.opts.protocol, domain)
+		if err != nil {
+			if e, ok := err.(*net.DNSError); ok && e.IsNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, srv := range srvs {
+			wg.Add(1)
+			go func(host string, port uint16) {
+				defer wg.Done()
+
+				addr := net.JoinHostPort(host, strconv.Itoa(int(port)))
+
+				services, err := r.fetcher(ctx, host, port, serviceName)
+				if err != nil {
+					last, ok := lasts[addr]
+					if !ok {
+						log.Warnf("fetch service metadata failed, host: %s port: %d err: %v", host, port, err)
+						return
+					}
+
+					log.Warnf("fetch service metadata failed and use the last known instances, host: %s port: %d err: %v", host, port, err)
+					services = last
+				}
+
+				mu.Lock()
+				hosts[addr] = services
+				for _, ins := range services {
+					if ins.Name == serviceName {
+						instances[ins.ID] = ins
+					}
+				}
+				mu.Unlock()
+			}(strings.TrimSuffix(srv.Target, "."), srv.Port)
+		}
+	}
+
+	wg.Wait()
+
+	// 仅保留本次解析到的实例，已从DNS记录中移除的实例不再沿用
+	r.mu.Lock()
+	r.hosts[serviceName] = hosts
+	r.mu.Unlock()
+
+	services := make([]*registry.ServiceInstance, 0, len(instances))
+	for _, ins := range instances {
+		services = append(services, ins)
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+
+	return services, nil
+}
+
+// Close 停止元数据发布服务器
+func (r *Registry) Close() error {
+	r.cancel()
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	if r.server != nil {
+		return r.server.Close()
+	}
+
+	return nil
+}
+
+// 启动元数据发布服务器
+func (r *Registry) serve() error {
+	ln, err := net.Listen("tcp", r.opts.metadataAddr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(strings.TrimSuffix(r.opts.metadataPath, "/")+"/", r.handleMetadata)
+
+	r.server = &http.Server{Handler: mux}
+
+	go func() {
+		if err := r.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Errorf("metadata server shutdown, err: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// 发布服务实例元数据
+func (r *Registry) handleMetadata(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	serviceName := strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(r.opts.metadataPath, "/")+"/")
+
+	r.rw.RLock()
+	services := make([]*registry.ServiceInstance, 0, len(r.instances[serviceName]))
+	for _, ins := range r.instances[serviceName] {
+		services = append(services, ins)
+	}
+	data, err := json.Marshal(services)
+	r.rw.RUnlock()
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// 通过HTTP获取服务实例元数据
+func (r *Registry) fetch(ctx context.Context, host string, port uint16, serviceName string) ([]*registry.ServiceInstance, error) {
+	url := fmt.Sprintf("http://%s%s/%s", net.JoinHostPort(host, strconv.Itoa(int(port))), strings.TrimSuffix(r.opts.metadataPath, "/"), serviceName)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []*registry.ServiceInstance
+
+	if err = json.Unmarshal(data, &services); err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+// 构建服务名对应的服务域名
+func (r *Registry) buildDomains(serviceName string) []string {
+	if domains, ok := r.opts.domains[serviceName]; ok {
+		return domains
+	}
+
+	if strings.Contains(r.opts.domain, "%s") {
+		return []string{fmt.Sprintf(r.opts.domain, serviceName)}
+	}
+
+	return []string{r.opts.domain}
+}
@@ -0,0 +1,209 @@
+package dns_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/registry"
+	"github.com/dobyte/due/v2/registry/dns"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+const serviceName = "node"
+
+func TestRegistry_Watch(t *testing.T) {
+	var (
+		ctx = context.Background()
+		ins = &registry.ServiceInstance{
+			ID:       "test-1",
+			Name:     serviceName,
+			Kind:     cluster.Node.String(),
+			Alias:    "login-server",
+			State:    cluster.Work.String(),
+			Endpoint: "grpc://127.0.0.1:3553",
+		}
+	)
+
+	metadataAddr := freeAddr(t)
+	_, port, _ := net.SplitHostPort(metadataAddr)
+
+	reg := dns.NewRegistry(
+		dns.WithAddr(stubDNS(t, "_meta._tcp.node.due.test.", "localhost.", port)),
+		dns.WithDomain("%s.due.test."),
+		dns.WithMetadataAddr(metadataAddr),
+		dns.WithInterval(50*time.Millisecond),
+	)
+	defer reg.Close()
+
+	// 监听器不应受调用Watch时传入的上下文影响
+	watchCtx, cancel := context.WithCancel(ctx)
+	watcher, err := reg.Watch(watchCtx, serviceName)
+	cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	services, err := watcher.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 0 {
+		t.Fatalf("expected no services, got %d", len(services))
+	}
+
+	if err = reg.Register(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	if services, err = watcher.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 || services[0].ID != ins.ID || services[0].Endpoint != ins.Endpoint {
+		t.Fatalf("unexpected services: %v", services)
+	}
+
+	if err = reg.Deregister(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	if services, err = watcher.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 0 {
+		t.Fatalf("expected no services, got %d", len(services))
+	}
+}
+
+func TestRegistry_Services(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		fails = false
+		ins   = &registry.ServiceInstance{ID: "test-1", Name: serviceName, Endpoint: "grpc://127.0.0.1:3553"}
+	)
+
+	reg := dns.NewRegistry(
+		dns.WithAddr(stubDNS(t, "_meta._tcp.node.due.test.", "localhost.", "3553")),
+		dns.WithDomain("%s.due.test."),
+		dns.WithFetcher(func(ctx context.Context, host string, port uint16, serviceName string) ([]*registry.ServiceInstance, error) {
+			if fails {
+				return nil, errors.New("connection refused")
+			}
+
+			return []*registry.ServiceInstance{ins}, nil
+		}),
+	)
+	defer reg.Close()
+
+	services, err := reg.Services(ctx, serviceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 {
+		t.Fatalf("expected 1 service, got %d", len(services))
+	}
+
+	// 获取元数据失败时应沿用最近一次获取成功的服务实例
+	fails = true
+
+	if services, err = reg.Services(ctx, serviceName); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 || services[0].ID != ins.ID {
+		t.Fatalf("expected the last known services, got %v", services)
+	}
+}
+
+// 获取空闲的本地地址
+func freeAddr(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	return ln.Addr().String()
+}
+
+// 启动仅应答单条SRV记录的本地DNS服务器
+func stubDNS(t *testing.T, name, target, port string) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	p, err := net.LookupPort("tcp", port)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		buf := make([]byte, 512)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			if n < 12 {
+				continue
+			}
+
+			// 解析问题域名
+			var (
+				labels []string
+				i      = 12
+			)
+			for i < n && buf[i] != 0 {
+				l := int(buf[i])
+				labels = append(labels, string(buf[i+1:i+1+l]))
+				i += l + 1
+			}
+			end := i + 5
+
+			header := make([]byte, 12)
+			copy(header, buf[:2])
+			binary.BigEndian.PutUint16(header[2:], 0x8180)
+			binary.BigEndian.PutUint16(header[4:], 1)
+
+			answer := []byte{}
+			if strings.EqualFold(strings.Join(labels, ".")+".", name) {
+				binary.BigEndian.PutUint16(header[6:], 1)
+
+				rdata := binary.BigEndian.AppendUint16(nil, 0)
+				rdata = binary.BigEndian.AppendUint16(rdata, 0)
+				rdata = binary.BigEndian.AppendUint16(rdata, uint16(p))
+				for _, label := range strings.Split(strings.TrimSuffix(target, "."), ".") {
+					rdata = append(append(rdata, byte(len(label))), label...)
+				}
+				rdata = append(rdata, 0)
+
+				answer = append(answer, 0xC0, 0x0C)
+				answer = binary.BigEndian.AppendUint16(answer, 33)
+				answer = binary.BigEndian.AppendUint16(answer, 1)
+				answer = binary.BigEndian.AppendUint32(answer, 0)
+				answer = binary.BigEndian.AppendUint16(answer, uint16(len(rdata)))
+				answer = append(answer, rdata...)
+			} else {
+				binary.BigEndian.PutUint16(header[2:], 0x8183)
+			}
+
+			resp := append(append(header, buf[12:end]...), answer...)
+
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
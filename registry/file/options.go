@@ -0,0 +1,70 @@
+package file
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/etc"
+	"github.com/dobyte/due/v2/registry"
+	"time"
+)
+
+const (
+	defaultTTL = "30s"
+)
+
+const (
+	defaultPathKey     = "etc.registry.file.path"
+	defaultServicesKey = "etc.registry.file.services"
+	defaultTTLKey      = "etc.registry.file.ttl"
+)
+
+type Option func(o *options)
+
+type options struct {
+	// 上下文
+	// 默认context.Background
+	ctx context.Context
+
+	// 服务实例文件或目录路径
+	// 为目录时，目录下所有.json后缀的文件均视为服务实例文件，且当前进程注册的服务实例将被写入该目录；默认为空，不监听任何文件
+	path string
+
+	// 静态服务实例列表
+	// 默认为空
+	services []*registry.ServiceInstance
+
+	// 服务实例文件的存活时间，仅路径为目录时生效
+	// 当前进程将定期刷新其写入文件的修改时间，修改时间超出存活时间的文件视为进程已异常退出，其服务实例将被忽略；设置为0则不过期，默认为30s
+	ttl time.Duration
+}
+
+func defaultOptions() *options {
+	o := &options{
+		ctx:  context.Background(),
+		path: etc.Get(defaultPathKey).String(),
+		ttl:  etc.Get(defaultTTLKey, defaultTTL).Duration(),
+	}
+
+	_ = etc.Get(defaultServicesKey).Scan(&o.services)
+
+	return o
+}
+
+// WithContext 设置上下文
+func WithContext(ctx context.Context) Option {
+	return func(o *options) { o.ctx = ctx }
+}
+
+// WithPath 设置服务实例文件或目录路径
+func WithPath(path string) Option {
+	return func(o *options) { o.path = path }
+}
+
+// WithServices 设置静态服务实例列表
+func WithServices(services ...*registry.ServiceInstance) Option {
+	return func(o *options) { o.services = services }
+}
+
+// WithTTL 设置服务实例文件的存活时间
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) { o.ttl = ttl }
+}
@@ -0,0 +1,474 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"github.com/dobyte/due/v2/encoding/json"
+	"github.com/dobyte/due/v2/log"
+	"github.com/dobyte/due/v2/registry"
+	"github.com/fsnotify/fsnotify"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const fileExt = ".json"
+
+var _ registry.Registry = &Registry{}
+
+// Registry 基于静态列表与文件的服务注册中心，适用于未部署etcd、consul等服务发现集群的物理机部署
+// 服务实例来源于静态服务实例列表、服务实例文件以及当前进程注册的服务实例，相同ID的服务实例以后者为准
+// 服务实例文件的内容为单个服务实例或服务实例数组的JSON，文件变化时将通知所有监听器
+// 路径为目录时，当前进程注册的服务实例将以ID命名写入该目录，多个进程共享同一目录即可实现相互发现
+// 目录中的服务实例文件通过修改时间维持心跳，进程异常退出后其服务实例将在存活时间过后被忽略
+type Registry struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   *options
+	isDir  bool
+
+	rw       sync.RWMutex
+	idx      int64
+	files    map[string][]*registry.ServiceInstance // 文件路径 -> 服务实例列表
+	mtimes   map[string]time.Time                   // 文件路径 -> 文件修改时间
+	locals   map[string]*registry.ServiceInstance   // 服务实例ID -> 当前进程注册的服务实例
+	watchers map[string]map[int64]*watcher          // 服务名 -> 监听器ID -> 监听器
+	notifier *fsnotify.Watcher
+}
+
+func NewRegistry(opts ...Option) *Registry {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &Registry{}
+	r.ctx, r.cancel = context.WithCancel(o.ctx)
+	r.opts = o
+	r.files = make(map[string][]*registry.ServiceInstance)
+	r.mtimes = make(map[string]time.Time)
+	r.locals = make(map[string]*registry.ServiceInstance)
+	r.watchers = make(map[string]map[int64]*watcher)
+
+	if o.path != "" {
+		o.path = filepath.Clean(o.path)
+
+		if err := r.init(); err != nil {
+			log.Warnf("init file registry failed, path: %s err: %v", o.path, err)
+		}
+	}
+
+	return r
+}
+
+// Register 注册服务实例
+func (r *Registry) Register(ctx context.Context, ins *registry.ServiceInstance) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	if r.isDir {
+		if err := r.writeFile(ins); err != nil {
+			return err
+		}
+	}
+
+	r.locals[ins.ID] = clone(ins)
+
+	r.broadcast(ins.Name)
+
+	return nil
+}
+
+// Deregister 解注册服务实例
+func (r *Registry) Deregister(ctx context.Context, ins *registry.ServiceInstance) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	if _, ok := r.locals[ins.ID]; !ok {
+		return nil
+	}
+
+	if r.isDir {
+		file := r.buildFilePath(ins.ID)
+
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		delete(r.files, file)
+		delete(r.mtimes, file)
+	}
+
+	delete(r.locals, ins.ID)
+
+	r.broadcast(ins.Name)
+
+	return nil
+}
+
+// Watch 监听相同服务名的服务实例变化
+func (r *Registry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	r.idx++
+
+	w := newWatcher(r, serviceName, r.idx)
+	w.update(r.services(serviceName))
+
+	watchers, ok := r.watchers[serviceName]
+	if !ok {
+		watchers = make(map[int64]*watcher)
+		r.watchers[serviceName] = watchers
+	}
+
+	watchers[w.idx] = w
+
+	return w, nil
+}
+
+// Services 获取服务实例列表
+func (r *Registry) Services(ctx context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.rw.RLock()
+	defer r.rw.RUnlock()
+
+	return r.services(serviceName), nil
+}
+
+// Close 停止监听服务实例文件
+func (r *Registry) Close() error {
+	r.cancel()
+
+	if r.notifier != nil {
+		return r.notifier.Close()
+	}
+
+	return nil
+}
+
+// 加载服务实例文件并监听文件变化
+// 路径为文件时监听其所在目录，以便感知编辑器或部署工具通过重命名替换文件
+func (r *Registry) init() error {
+	info, err := os.Stat(r.opts.path)
+	switch {
+	case err == nil:
+		r.isDir = info.IsDir()
+	case os.IsNotExist(err) && filepath.Ext(r.opts.path) != fileExt:
+		if err = os.MkdirAll(r.opts.path, os.ModePerm); err != nil {
+			return err
+		}
+		r.isDir = true
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	dir := r.opts.path
+	if !r.isDir {
+		dir = filepath.Dir(r.opts.path)
+	}
+
+	if r.isDir {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if file := filepath.Join(dir, entry.Name()); !entry.IsDir() && r.isServiceFile(file) {
+				r.loadFile(file)
+			}
+		}
+	} else {
+		r.loadFile(r.opts.path)
+	}
+
+	notifier, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	if err = notifier.Add(dir); err != nil {
+		_ = notifier.Close()
+		return err
+	}
+
+	r.notifier = notifier
+
+	go r.watch()
+
+	if r.isDir && r.opts.ttl > 0 {
+		go r.keepalive()
+	}
+
+	return nil
+}
+
+// 监听服务实例文件变化
+func (r *Registry) watch() {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case event, ok := <-r.notifier.Events:
+			if !ok {
+				return
+			}
+
+			file := filepath.Clean(event.Name)
+			if !r.isServiceFile(file) {
+				continue
+			}
+
+			r.rw.Lock()
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
+				r.loadFile(file)
+			} else if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				delete(r.files, file)
+				delete(r.mtimes, file)
+			}
+			r.broadcastAll()
+			r.rw.Unlock()
+		case err, ok := <-r.notifier.Errors:
+			if !ok {
+				return
+			}
+
+			log.Warnf("watch service file failed, path: %s err: %v", r.opts.path, err)
+		}
+	}
+}
+
+// 定期刷新当前进程写入的服务实例文件的修改时间，并重新读取其他文件的修改时间以剔除过期的服务实例
+// 修改文件时间不会触发文件写入事件，因此需在此处主动读取
+func (r *Registry) keepalive() {
+	ticker := time.NewTicker(r.opts.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.rw.Lock()
+			r.heartbeat()
+			r.broadcastAll()
+			r.rw.Unlock()
+		}
+	}
+}
+
+// 刷新服务实例文件的修改时间，当前进程写入的文件被删除时重新写入
+func (r *Registry) heartbeat() {
+	now := time.Now()
+
+	for _, ins := range r.locals {
+		file := r.buildFilePath(ins.ID)
+
+		err := os.Chtimes(file, now, now)
+		if os.IsNotExist(err) {
+			err = r.writeFile(ins)
+		}
+		if err != nil {
+			log.Warnf("refresh service file failed, file: %s err: %v", file, err)
+		}
+	}
+
+	for file := range r.files {
+		if info, err := os.Stat(file); err == nil {
+			r.mtimes[file] = info.ModTime()
+		}
+	}
+}
+
+// 服务实例文件是否已过期，仅路径为目录时检测
+func (r *Registry) isExpired(file string) bool {
+	if !r.isDir || r.opts.ttl <= 0 {
+		return false
+	}
+
+	return time.Since(r.mtimes[file]) > r.opts.ttl
+}
+
+// 加载服务实例文件，文件内容无法解析时保留上次加载的服务实例
+func (r *Registry) loadFile(file string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("read service file failed, file: %s err: %v", file, err)
+		}
+		delete(r.files, file)
+		delete(r.mtimes, file)
+		return
+	}
+
+	var instances []*registry.ServiceInstance
+
+	if data = bytes.TrimSpace(data); len(data) > 0 {
+		if data[0] == '[' {
+			err = json.Unmarshal(data, &instances)
+		} else {
+			ins := &registry.ServiceInstance{}
+			if err = json.Unmarshal(data, ins); err == nil {
+				instances = append(instances, ins)
+			}
+		}
+		if err != nil {
+			log.Warnf("parse service file failed, file: %s err: %v", file, err)
+			return
+		}
+	}
+
+	r.files[file] = instances
+
+	if info, err := os.Stat(file); err == nil {
+		r.mtimes[file] = info.ModTime()
+	} else {
+		r.mtimes[file] = time.Now()
+	}
+}
+
+// 将服务实例写入文件，先写入临时文件再重命名，避免其他进程读取到不完整的内容
+func (r *Registry) writeFile(ins *registry.ServiceInstance) error {
+	data, err := json.Marshal(ins)
+	if err != nil {
+		return err
+	}
+
+	file := r.buildFilePath(ins.ID)
+	temp := filepath.Join(r.opts.path, "."+filepath.Base(file)+".tmp")
+
+	if err = os.WriteFile(temp, data, 0644); err != nil {
+		return err
+	}
+
+	if err = os.Rename(temp, file); err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+
+	r.files[file] = []*registry.ServiceInstance{clone(ins)}
+	r.mtimes[file] = time.Now()
+
+	return nil
+}
+
+// 是否为服务实例文件
+func (r *Registry) isServiceFile(file string) bool {
+	if !r.isDir {
+		return file == r.opts.path
+	}
+
+	name := filepath.Base(file)
+
+	return filepath.Dir(file) == r.opts.path && filepath.Ext(name) == fileExt && !strings.HasPrefix(name, ".")
+}
+
+// 构建服务实例文件路径
+func (r *Registry) buildFilePath(id string) string {
+	return filepath.Join(r.opts.path, strings.NewReplacer("/", "_", "\\", "_").Replace(id)+fileExt)
+}
+
+// 获取服务实例列表
+func (r *Registry) services(serviceName string) []*registry.ServiceInstance {
+	instances := make(map[string]*registry.ServiceInstance)
+
+	for _, ins := range r.opts.services {
+		if ins.Name == serviceName {
+			instances[ins.ID] = ins
+		}
+	}
+
+	files := make([]string, 0, len(r.files))
+	for file := range r.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		if r.isExpired(file) {
+			continue
+		}
+
+		for _, ins := range r.files[file] {
+			if ins.Name == serviceName {
+				instances[ins.ID] = ins
+			}
+		}
+	}
+
+	for _, ins := range r.locals {
+		if ins.Name == serviceName {
+			instances[ins.ID] = ins
+		}
+	}
+
+	services := make([]*registry.ServiceInstance, 0, len(instances))
+	for _, ins := range instances {
+		services = append(services, clone(ins))
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+
+	return services
+}
+
+// 通知监听器服务实例发生变化
+func (r *Registry) broadcast(serviceName string) {
+	for _, w := range r.watchers[serviceName] {
+		w.update(r.services(serviceName))
+	}
+}
+
+// 将所有服务的最新服务实例列表推送给对应的监听器，由监听器自行忽略未发生变化的列表
+func (r *Registry) broadcastAll() {
+	for serviceName := range r.watchers {
+		r.broadcast(serviceName)
+	}
+}
+
+// 回收监听器
+func (r *Registry) recycle(serviceName string, idx int64) {
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	watchers, ok := r.watchers[serviceName]
+	if !ok {
+		return
+	}
+
+	delete(watchers, idx)
+
+	if len(watchers) == 0 {
+		delete(r.watchers, serviceName)
+	}
+}
+
+// 复制服务实例，避免外部修改影响注册中心中的数据
+func clone(ins *registry.ServiceInstance) *registry.ServiceInstance {
+	c := *ins
+	c.Events = append([]int(nil), ins.Events...)
+	c.Routes = append([]registry.Route(nil), ins.Routes...)
+
+	return &c
+}
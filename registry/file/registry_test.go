@@ -0,0 +1,126 @@
+package file_test
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/registry"
+	"github.com/dobyte/due/v2/registry/file"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const serviceName = "node"
+
+func TestRegistry_Watch(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+		ins = &registry.ServiceInstance{
+			ID:       "test-2",
+			Name:     serviceName,
+			Kind:     cluster.Node.String(),
+			Alias:    "login-server",
+			State:    cluster.Work.String(),
+			Endpoint: "grpc://127.0.0.1:3554",
+		}
+	)
+
+	reg1 := file.NewRegistry(file.WithPath(dir))
+	defer reg1.Close()
+
+	reg2 := file.NewRegistry(file.WithPath(dir), file.WithServices(&registry.ServiceInstance{
+		ID:       "test-1",
+		Name:     serviceName,
+		Kind:     cluster.Node.String(),
+		State:    cluster.Work.String(),
+		Endpoint: "grpc://127.0.0.1:3553",
+	}))
+	defer reg2.Close()
+
+	// 监听器不应受调用Watch时传入的上下文影响
+	watchCtx, cancel := context.WithCancel(ctx)
+	watcher, err := reg2.Watch(watchCtx, serviceName)
+	cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	services, err := watcher.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 || services[0].ID != "test-1" {
+		t.Fatalf("expected static service, got %v", services)
+	}
+
+	if err = reg1.Register(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	services = next(t, watcher, 2)
+
+	if services[1].ID != ins.ID || services[1].Endpoint != ins.Endpoint {
+		t.Fatalf("unexpected service: %+v", services[1])
+	}
+
+	if err = reg1.Deregister(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	next(t, watcher, 1)
+}
+
+func TestRegistry_Expire(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+	)
+
+	alive := []byte(`{"id":"test-1","name":"node","kind":"node","state":"work","endpoint":"grpc://127.0.0.1:3553"}`)
+	if err := os.WriteFile(filepath.Join(dir, "test-1.json"), alive, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expired := []byte(`{"id":"test-2","name":"node","kind":"node","state":"work","endpoint":"grpc://127.0.0.1:3554"}`)
+	if err := os.WriteFile(filepath.Join(dir, "test-2.json"), expired, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "test-2.json"), mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	reg := file.NewRegistry(file.WithPath(dir), file.WithTTL(time.Minute))
+	defer reg.Close()
+
+	services, err := reg.Services(ctx, serviceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 || services[0].ID != "test-1" {
+		t.Fatalf("expected only the alive service, got %v", services)
+	}
+}
+
+// 等待服务实例数量变为期望值
+func next(t *testing.T, watcher registry.Watcher, expected int) []*registry.ServiceInstance {
+	timer := time.AfterFunc(5*time.Second, func() { _ = watcher.Stop() })
+	defer timer.Stop()
+
+	for {
+		services, err := watcher.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(services) == expected {
+			return services
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package file
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/registry"
+	"reflect"
+	"sync"
+)
+
+type watcher struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	registry    *Registry
+	serviceName string
+	idx         int64
+	chNotify    chan struct{}
+
+	mu       sync.Mutex
+	notified bool
+	services []*registry.ServiceInstance
+}
+
+// 监听器的生命周期与注册中心一致，仅在调用Stop或关闭注册中心时停止，不受调用Watch时传入的上下文影响
+func newWatcher(r *Registry, serviceName string, idx int64) *watcher {
+	w := &watcher{}
+	w.ctx, w.cancel = context.WithCancel(r.ctx)
+	w.registry = r
+	w.serviceName = serviceName
+	w.idx = idx
+	w.chNotify = make(chan struct{}, 1)
+
+	return w
+}
+
+// 更新服务实例列表，服务实例未发生变化时忽略，未被读取的旧列表将被直接覆盖
+func (w *watcher) update(services []*registry.ServiceInstance) {
+	w.mu.Lock()
+	if w.notified && reflect.DeepEqual(w.services, services) {
+		w.mu.Unlock()
+		return
+	}
+	w.notified = true
+	w.services = services
+	w.mu.Unlock()
+
+	select {
+	case w.chNotify <- struct{}{}:
+	default:
+	}
+}
+
+// Next 返回服务实例列表
+func (w *watcher) Next() ([]*registry.ServiceInstance, error) {
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case <-w.chNotify:
+		w.mu.Lock()
+		services := w.services
+		w.mu.Unlock()
+
+		return services, nil
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	w.registry.recycle(w.serviceName, w.idx)
+
+	return nil
+}
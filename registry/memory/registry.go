@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/registry"
+	"sort"
+	"sync"
+)
+
+var _ registry.Registry = &Registry{}
+
+// Registry 进程内的服务注册中心，适用于单元测试、本地开发以及网关、节点、网格运行于同一进程的单机部署
+type Registry struct {
+	rw        sync.RWMutex
+	idx       int64
+	instances map[string]map[string]*registry.ServiceInstance // 服务名 -> 服务实例ID -> 服务实例
+	watchers  map[string]map[int64]*watcher                   // 服务名 -> 监听器ID -> 监听器
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		instances: make(map[string]map[string]*registry.ServiceInstance),
+		watchers:  make(map[string]map[int64]*watcher),
+	}
+}
+
+// Register 注册服务实例
+func (r *Registry) Register(ctx context.Context, ins *registry.ServiceInstance) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	instances, ok := r.instances[ins.Name]
+	if !ok {
+		instances = make(map[string]*registry.ServiceInstance)
+		r.instances[ins.Name] = instances
+	}
+
+	instances[ins.ID] = clone(ins)
+
+	r.broadcast(ins.Name)
+
+	return nil
+}
+
+// Deregister 解注册服务实例
+func (r *Registry) Deregister(ctx context.Context, ins *registry.ServiceInstance) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	instances, ok := r.instances[ins.Name]
+	if !ok {
+		return nil
+	}
+
+	if _, ok = instances[ins.ID]; !ok {
+		return nil
+	}
+
+	delete(instances, ins.ID)
+
+	if len(instances) == 0 {
+		delete(r.instances, ins.Name)
+	}
+
+	r.broadcast(ins.Name)
+
+	return nil
+}
+
+// Watch 监听相同服务名的服务实例变化
+func (r *Registry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	r.idx++
+
+	w := newWatcher(r, serviceName, r.idx)
+	w.update(r.services(serviceName))
+
+	watchers, ok := r.watchers[serviceName]
+	if !ok {
+		watchers = make(map[int64]*watcher)
+		r.watchers[serviceName] = watchers
+	}
+
+	watchers[w.idx] = w
+
+	return w, nil
+}
+
+// Services 获取服务实例列表
+func (r *Registry) Services(ctx context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.rw.RLock()
+	defer r.rw.RUnlock()
+
+	return r.services(serviceName), nil
+}
+
+// 获取服务实例列表
+func (r *Registry) services(serviceName string) []*registry.ServiceInstance {
+	instances := r.instances[serviceName]
+
+	services := make([]*registry.ServiceInstance, 0, len(instances))
+	for _, ins := range instances {
+		services = append(services, clone(ins))
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+
+	return services
+}
+
+// 通知监听器服务实例发生变化
+func (r *Registry) broadcast(serviceName string) {
+	for _, w := range r.watchers[serviceName] {
+		w.update(r.services(serviceName))
+	}
+}
+
+// 回收监听器
+func (r *Registry) recycle(serviceName string, idx int64) {
+	r.rw.Lock()
+	defer r.rw.Unlock()
+
+	watchers, ok := r.watchers[serviceName]
+	if !ok {
+		return
+	}
+
+	delete(watchers, idx)
+
+	if len(watchers) == 0 {
+		delete(r.watchers, serviceName)
+	}
+}
+
+// 复制服务实例，避免外部修改影响注册中心中的数据
+func clone(ins *registry.ServiceInstance) *registry.ServiceInstance {
+	c := *ins
+	c.Events = append([]int(nil), ins.Events...)
+	c.Routes = append([]registry.Route(nil), ins.Routes...)
+
+	return &c
+}
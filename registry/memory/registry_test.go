@@ -0,0 +1,66 @@
+package memory_test
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/cluster"
+	"github.com/dobyte/due/v2/registry"
+	"github.com/dobyte/due/v2/registry/memory"
+	"testing"
+)
+
+const serviceName = "node"
+
+func TestRegistry_Watch(t *testing.T) {
+	var (
+		ctx = context.Background()
+		reg = memory.NewRegistry()
+		ins = &registry.ServiceInstance{
+			ID:       "test-1",
+			Name:     serviceName,
+			Kind:     cluster.Node.String(),
+			Alias:    "login-server",
+			State:    cluster.Work.String(),
+			Endpoint: "grpc://127.0.0.1:3553",
+		}
+	)
+
+	// 监听器不应受调用Watch时传入的上下文影响
+	watchCtx, cancel := context.WithCancel(ctx)
+	watcher, err := reg.Watch(watchCtx, serviceName)
+	cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	services, err := watcher.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 0 {
+		t.Fatalf("expected no services, got %d", len(services))
+	}
+
+	if err = reg.Register(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	if services, err = watcher.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(services) != 1 || services[0].ID != ins.ID {
+		t.Fatalf("expected service %s, got %v", ins.ID, services)
+	}
+
+	if err = reg.Deregister(ctx, ins); err != nil {
+		t.Fatal(err)
+	}
+
+	if services, err = watcher.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("services after deregister: %d", len(services))
+}
@@ -0,0 +1,17 @@
+package session
+
+type Option func(o *options)
+
+type options struct {
+	multiConn bool // 是否开启多端登录模式
+}
+
+func defaultOptions() *options {
+	return &options{}
+}
+
+// WithMultiConn 设置是否开启多端登录模式
+// 开启后同一用户可同时绑定多个连接，推送给用户的消息将发送至该用户的所有连接
+func WithMultiConn(multiConn bool) Option {
+	return func(o *options) { o.multiConn = multiConn }
+}
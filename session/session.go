@@ -8,8 +8,9 @@ import (
 )
 
 const (
-	Conn Kind = iota + 1 // 连接SESSION
-	User                 // 用户SESSION
+	Conn  Kind = iota + 1 // 连接SESSION
+	User                  // 用户SESSION
+	Group                 // 分组SESSION
 )
 
 type Kind int
@@ -20,21 +21,36 @@ func (k Kind) String() string {
 		return "conn"
 	case User:
 		return "user"
+	case Group:
+		return "group"
 	}
 
 	return ""
 }
 
 type Session struct {
-	rw    sync.RWMutex           // 读写锁
-	conns map[int64]network.Conn // 连接会话（连接ID -> network.Conn）
-	users map[int64]network.Conn // 用户会话（用户ID -> network.Conn）
+	rw         sync.RWMutex                     // 读写锁
+	opts       *options                         // 配置项
+	conns      map[int64]network.Conn           // 连接会话（连接ID -> network.Conn）
+	users      map[int64]network.Conn           // 用户会话（用户ID -> network.Conn），多端登录模式下为用户最近绑定的连接
+	devices    map[int64]map[int64]network.Conn // 用户设备会话（用户ID -> 连接ID -> network.Conn），仅多端登录模式下使用
+	groups     map[int64]map[int64]struct{}     // 分组会话（分组ID -> 用户ID集合）
+	userGroups map[int64]map[int64]struct{}     // 用户所在分组（用户ID -> 分组ID集合）
 }
 
-func NewSession() *Session {
+func NewSession(opts ...Option) *Session {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &Session{
-		conns: make(map[int64]network.Conn),
-		users: make(map[int64]network.Conn),
+		opts:       o,
+		conns:      make(map[int64]network.Conn),
+		users:      make(map[int64]network.Conn),
+		devices:    make(map[int64]map[int64]network.Conn),
+		groups:     make(map[int64]map[int64]struct{}),
+		userGroups: make(map[int64]map[int64]struct{}),
 	}
 }
 
@@ -48,7 +64,7 @@ func (s *Session) AddConn(conn network.Conn) {
 	s.conns[cid] = conn
 
 	if uid != 0 {
-		s.users[uid] = conn
+		s.bindUser(uid, conn)
 	}
 }
 
@@ -62,7 +78,7 @@ func (s *Session) RemConn(conn network.Conn) {
 	delete(s.conns, cid)
 
 	if uid != 0 {
-		delete(s.users, uid)
+		s.unbindUser(uid, conn)
 	}
 }
 
@@ -76,6 +92,8 @@ func (s *Session) Has(kind Kind, target int64) (ok bool, err error) {
 		_, ok = s.conns[target]
 	case User:
 		_, ok = s.users[target]
+	case Group:
+		_, ok = s.groups[target]
 	default:
 		err = errors.ErrInvalidSessionKind
 	}
@@ -83,6 +101,22 @@ func (s *Session) Has(kind Kind, target int64) (ok bool, err error) {
 	return
 }
 
+// Devices 获取用户绑定的连接数，多端登录模式下为用户在当前会话中的所有连接数
+func (s *Session) Devices(uid int64) int {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	if devices, ok := s.devices[uid]; ok {
+		return len(devices)
+	}
+
+	if _, ok := s.users[uid]; ok {
+		return 1
+	}
+
+	return 0
+}
+
 // Bind 绑定用户ID
 func (s *Session) Bind(cid, uid int64) error {
 	s.rw.Lock()
@@ -97,20 +131,20 @@ func (s *Session) Bind(cid, uid int64) error {
 		if uid == oldUID {
 			return nil
 		}
-		delete(s.users, oldUID)
+		s.unbindUser(oldUID, conn)
 	}
 
-	if oldConn, ok := s.users[uid]; ok {
+	if oldConn, ok := s.users[uid]; ok && !s.opts.multiConn {
 		oldConn.Unbind()
 	}
 
 	conn.Bind(uid)
-	s.users[uid] = conn
+	s.bindUser(uid, conn)
 
 	return nil
 }
 
-// Unbind 解绑用户ID
+// Unbind 解绑用户ID，多端登录模式下将解绑用户的所有连接，返回用户最近绑定的连接ID
 func (s *Session) Unbind(uid int64) (int64, error) {
 	s.rw.Lock()
 	defer s.rw.Unlock()
@@ -120,8 +154,40 @@ func (s *Session) Unbind(uid int64) (int64, error) {
 		return 0, err
 	}
 
+	for _, device := range s.devices[uid] {
+		device.Unbind()
+	}
+
 	conn.Unbind()
 	delete(s.users, uid)
+	delete(s.devices, uid)
+	s.leaveGroups(uid)
+
+	return conn.ID(), nil
+}
+
+// UIDs 获取当前会话中所有已绑定的用户ID
+func (s *Session) UIDs() []int64 {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	uids := make([]int64, 0, len(s.users))
+	for uid := range s.users {
+		uids = append(uids, uid)
+	}
+
+	return uids
+}
+
+// CID 获取用户绑定的连接ID
+func (s *Session) CID(uid int64) (int64, error) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+
+	conn, err := s.conn(User, uid)
+	if err != nil {
+		return 0, err
+	}
 
 	return conn.ID(), nil
 }
@@ -178,16 +244,28 @@ func (s *Session) RemoteAddr(kind Kind, target int64) (net.Addr, error) {
 	return conn.RemoteAddr()
 }
 
-// Close 关闭会话
+// Close 关闭会话，多端登录模式下关闭用户会话时将关闭用户的所有连接
 func (s *Session) Close(kind Kind, target int64, isForce ...bool) error {
 	s.rw.RLock()
 	conn, err := s.conn(kind, target)
+	var devices []network.Conn
+	if kind == User {
+		for _, device := range s.devices[target] {
+			if device != conn {
+				devices = append(devices, device)
+			}
+		}
+	}
 	s.rw.RUnlock()
 
 	if err != nil {
 		return err
 	}
 
+	for _, device := range devices {
+		_ = device.Close(isForce...)
+	}
+
 	return conn.Close(isForce...)
 }
 
@@ -204,21 +282,74 @@ func (s *Session) Send(kind Kind, target int64, msg []byte) error {
 	return conn.Send(msg)
 }
 
-// Push 推送消息（异步）
+// Push 推送消息（异步），会话类型为分组时推送给分组中的所有用户，多端登录模式下推送给用户的所有连接
 func (s *Session) Push(kind Kind, target int64, msg []byte) error {
+	if kind == Conn || kind == User && !s.opts.multiConn {
+		s.rw.RLock()
+		defer s.rw.RUnlock()
+
+		conn, err := s.conn(kind, target)
+		if err != nil {
+			return err
+		}
+
+		return conn.Push(msg)
+	}
+
+	buf := network.WrapBuffer(msg)
+	defer buf.Release()
+
+	return s.PushBuffer(kind, target, buf)
+}
+
+// PushBuffer 推送共享缓冲区消息（异步），会话类型为分组时推送给分组中的所有用户
+// 调用方持有的缓冲区引用需由调用方自行释放
+func (s *Session) PushBuffer(kind Kind, target int64, buf *network.Buffer) error {
 	s.rw.RLock()
 	defer s.rw.RUnlock()
 
+	if kind == Group {
+		members, ok := s.groups[target]
+		if !ok {
+			return errors.ErrNotFoundSession
+		}
+
+		for uid := range members {
+			s.pushUser(uid, buf)
+		}
+
+		return nil
+	}
+
+	if kind == User && s.opts.multiConn {
+		if _, ok := s.users[target]; !ok {
+			return errors.ErrNotFoundSession
+		}
+
+		s.pushUser(target, buf)
+
+		return nil
+	}
+
 	conn, err := s.conn(kind, target)
 	if err != nil {
 		return err
 	}
 
-	return conn.Push(msg)
+	return network.PushBuffer(conn, buf)
 }
 
-// Multicast 推送组播消息（异步）
+// Multicast 推送组播消息（异步），会话类型为分组时推送给多个分组中的所有用户，同时处于多个分组中的用户仅推送一次
 func (s *Session) Multicast(kind Kind, targets []int64, msg []byte) (n int64, err error) {
+	buf := network.WrapBuffer(msg)
+	defer buf.Release()
+
+	return s.MulticastBuffer(kind, targets, buf)
+}
+
+// MulticastBuffer 推送共享缓冲区组播消息（异步），所有连接共享同一个缓冲区
+// 调用方持有的缓冲区引用需由调用方自行释放
+func (s *Session) MulticastBuffer(kind Kind, targets []int64, buf *network.Buffer) (n int64, err error) {
 	if len(targets) == 0 {
 		return
 	}
@@ -231,7 +362,18 @@ func (s *Session) Multicast(kind Kind, targets []int64, msg []byte) (n int64, er
 	case Conn:
 		conns = s.conns
 	case User:
+		if s.opts.multiConn {
+			for _, target := range targets {
+				if s.pushUser(target, buf) {
+					n++
+				}
+			}
+			return
+		}
 		conns = s.users
+	case Group:
+		n = s.pushGroups(targets, buf)
+		return
 	default:
 		err = errors.ErrInvalidSessionKind
 		return
@@ -242,7 +384,7 @@ func (s *Session) Multicast(kind Kind, targets []int64, msg []byte) (n int64, er
 		if !ok {
 			continue
 		}
-		if conn.Push(msg) == nil {
+		if network.PushBuffer(conn, buf) == nil {
 			n++
 		}
 	}
@@ -250,8 +392,17 @@ func (s *Session) Multicast(kind Kind, targets []int64, msg []byte) (n int64, er
 	return
 }
 
-// Broadcast 推送广播消息（异步）
+// Broadcast 推送广播消息（异步），会话类型为分组时推送给所有分组中的用户
 func (s *Session) Broadcast(kind Kind, msg []byte) (n int64, err error) {
+	buf := network.WrapBuffer(msg)
+	defer buf.Release()
+
+	return s.BroadcastBuffer(kind, buf)
+}
+
+// BroadcastBuffer 推送共享缓冲区广播消息（异步），所有连接共享同一个缓冲区
+// 调用方持有的缓冲区引用需由调用方自行释放
+func (s *Session) BroadcastBuffer(kind Kind, buf *network.Buffer) (n int64, err error) {
 	s.rw.RLock()
 	defer s.rw.RUnlock()
 
@@ -260,14 +411,29 @@ func (s *Session) Broadcast(kind Kind, msg []byte) (n int64, err error) {
 	case Conn:
 		conns = s.conns
 	case User:
+		if s.opts.multiConn {
+			for uid := range s.users {
+				if s.pushUser(uid, buf) {
+					n++
+				}
+			}
+			return
+		}
 		conns = s.users
+	case Group:
+		for uid := range s.userGroups {
+			if s.pushUser(uid, buf) {
+				n++
+			}
+		}
+		return
 	default:
 		err = errors.ErrInvalidSessionKind
 		return
 	}
 
 	for _, conn := range conns {
-		if conn.Push(msg) == nil {
+		if network.PushBuffer(conn, buf) == nil {
 			n++
 		}
 	}
@@ -285,11 +451,175 @@ func (s *Session) Stat(kind Kind) (int64, error) {
 		return int64(len(s.conns)), nil
 	case User:
 		return int64(len(s.users)), nil
+	case Group:
+		return int64(len(s.groups)), nil
 	default:
 		return 0, errors.ErrInvalidSessionKind
 	}
 }
 
+// JoinGroup 将用户加入分组，仅当前会话中存在的用户会被加入，返回加入分组的用户数
+// 分组关系不会持久化，用户解绑或断开全部连接时将退出所有分组
+func (s *Session) JoinGroup(gid int64, uids ...int64) (n int64) {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	for _, uid := range uids {
+		if _, ok := s.users[uid]; !ok {
+			continue
+		}
+
+		members, ok := s.groups[gid]
+		if !ok {
+			members = make(map[int64]struct{})
+			s.groups[gid] = members
+		}
+
+		if _, ok = members[uid]; ok {
+			continue
+		}
+
+		members[uid] = struct{}{}
+
+		groups, ok := s.userGroups[uid]
+		if !ok {
+			groups = make(map[int64]struct{})
+			s.userGroups[uid] = groups
+		}
+
+		groups[gid] = struct{}{}
+
+		n++
+	}
+
+	return
+}
+
+// LeaveGroup 将用户移出分组，返回移出分组的用户数；分组中不存在用户时分组将被移除
+func (s *Session) LeaveGroup(gid int64, uids ...int64) (n int64) {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+
+	for _, uid := range uids {
+		if s.leaveGroup(gid, uid) {
+			n++
+		}
+	}
+
+	return
+}
+
+// 将用户移出分组
+func (s *Session) leaveGroup(gid, uid int64) bool {
+	members, ok := s.groups[gid]
+	if !ok {
+		return false
+	}
+
+	if _, ok = members[uid]; !ok {
+		return false
+	}
+
+	delete(members, uid)
+
+	if len(members) == 0 {
+		delete(s.groups, gid)
+	}
+
+	if groups, ok := s.userGroups[uid]; ok {
+		delete(groups, gid)
+
+		if len(groups) == 0 {
+			delete(s.userGroups, uid)
+		}
+	}
+
+	return true
+}
+
+// 将用户移出所在的所有分组
+func (s *Session) leaveGroups(uid int64) {
+	for gid := range s.userGroups[uid] {
+		s.leaveGroup(gid, uid)
+	}
+}
+
+// 推送消息给多个分组中的用户
+func (s *Session) pushGroups(gids []int64, buf *network.Buffer) (n int64) {
+	pushed := make(map[int64]struct{})
+
+	for _, gid := range gids {
+		for uid := range s.groups[gid] {
+			if _, ok := pushed[uid]; ok {
+				continue
+			}
+
+			pushed[uid] = struct{}{}
+
+			if s.pushUser(uid, buf) {
+				n++
+			}
+		}
+	}
+
+	return
+}
+
+// 绑定用户连接
+func (s *Session) bindUser(uid int64, conn network.Conn) {
+	s.users[uid] = conn
+
+	if !s.opts.multiConn {
+		return
+	}
+
+	devices, ok := s.devices[uid]
+	if !ok {
+		devices = make(map[int64]network.Conn)
+		s.devices[uid] = devices
+	}
+
+	devices[conn.ID()] = conn
+}
+
+// 解绑用户连接，多端登录模式下用户仍存在其他连接时仅移除当前连接
+func (s *Session) unbindUser(uid int64, conn network.Conn) {
+	if devices, ok := s.devices[uid]; ok {
+		delete(devices, conn.ID())
+
+		if len(devices) > 0 {
+			if s.users[uid] == conn {
+				for _, device := range devices {
+					s.users[uid] = device
+					break
+				}
+			}
+			return
+		}
+
+		delete(s.devices, uid)
+	}
+
+	delete(s.users, uid)
+	s.leaveGroups(uid)
+}
+
+// 推送消息给用户，多端登录模式下推送给用户的所有连接，任意连接推送成功即视为推送成功
+func (s *Session) pushUser(uid int64, buf *network.Buffer) (ok bool) {
+	if !s.opts.multiConn {
+		conn, exists := s.users[uid]
+		return exists && network.PushBuffer(conn, buf) == nil
+	}
+
+	for _, conn := range s.devices[uid] {
+		if network.PushBuffer(conn, buf) == nil {
+			ok = true
+		}
+	}
+
+	return
+}
+
 // 获取会话
 func (s *Session) conn(kind Kind, target int64) (network.Conn, error) {
 	switch kind {
@@ -30,6 +30,10 @@ type GateClient interface {
 	Stat(ctx context.Context, kind session.Kind) (total int64, err error)
 	// Disconnect 断开连接
 	Disconnect(ctx context.Context, kind session.Kind, target int64, isForce bool) (miss bool, err error)
+	// JoinGroup 将用户加入分组
+	JoinGroup(ctx context.Context, gid int64, uids []int64) (total int64, err error)
+	// LeaveGroup 将用户移出分组
+	LeaveGroup(ctx context.Context, gid int64, uids []int64) (total int64, err error)
 }
 
 type ServiceClient interface {
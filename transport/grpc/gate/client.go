@@ -134,3 +134,29 @@ func (c *Client) Disconnect(ctx context.Context, kind session.Kind, target int64
 
 	return
 }
+
+// JoinGroup 将用户加入分组
+func (c *Client) JoinGroup(ctx context.Context, gid int64, uids []int64) (int64, error) {
+	reply, err := c.client.JoinGroup(ctx, &pb.JoinGroupRequest{
+		GID:  gid,
+		UIDs: uids,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return reply.Total, nil
+}
+
+// LeaveGroup 将用户移出分组
+func (c *Client) LeaveGroup(ctx context.Context, gid int64, uids []int64) (int64, error) {
+	reply, err := c.client.LeaveGroup(ctx, &pb.LeaveGroupRequest{
+		GID:  gid,
+		UIDs: uids,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return reply.Total, nil
+}
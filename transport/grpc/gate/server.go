@@ -176,3 +176,23 @@ func (e *endpoint) Disconnect(ctx context.Context, req *pb.DisconnectRequest) (*
 
 	return &pb.DisconnectReply{}, nil
 }
+
+// JoinGroup 将用户加入分组
+func (e *endpoint) JoinGroup(ctx context.Context, req *pb.JoinGroupRequest) (*pb.JoinGroupReply, error) {
+	total, err := e.provider.JoinGroup(ctx, req.GID, req.UIDs)
+	if err != nil {
+		return nil, status.New(codes.Internal, err.Error()).Err()
+	}
+
+	return &pb.JoinGroupReply{Total: total}, nil
+}
+
+// LeaveGroup 将用户移出分组
+func (e *endpoint) LeaveGroup(ctx context.Context, req *pb.LeaveGroupRequest) (*pb.LeaveGroupReply, error) {
+	total, err := e.provider.LeaveGroup(ctx, req.GID, req.UIDs)
+	if err != nil {
+		return nil, status.New(codes.Internal, err.Error()).Err()
+	}
+
+	return &pb.LeaveGroupReply{Total: total}, nil
+}
@@ -814,6 +814,210 @@ func (m *StatReply) GetTotal() int64 {
 	return 0
 }
 
+type JoinGroupRequest struct {
+	GID                  int64    `protobuf:"varint,1,opt,name=GID,proto3" json:"GID,omitempty"`
+	UIDs                 []int64  `protobuf:"varint,2,rep,packed,name=UIDs,proto3" json:"UIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinGroupRequest) Reset()         { *m = JoinGroupRequest{} }
+func (m *JoinGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinGroupRequest) ProtoMessage()    {}
+func (*JoinGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_743bb58a714d8b7d, []int{16}
+}
+func (m *JoinGroupRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JoinGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JoinGroupRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JoinGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinGroupRequest.Merge(m, src)
+}
+func (m *JoinGroupRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *JoinGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinGroupRequest proto.InternalMessageInfo
+
+func (m *JoinGroupRequest) GetGID() int64 {
+	if m != nil {
+		return m.GID
+	}
+	return 0
+}
+
+func (m *JoinGroupRequest) GetUIDs() []int64 {
+	if m != nil {
+		return m.UIDs
+	}
+	return nil
+}
+
+type JoinGroupReply struct {
+	Total                int64    `protobuf:"varint,1,opt,name=Total,proto3" json:"Total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinGroupReply) Reset()         { *m = JoinGroupReply{} }
+func (m *JoinGroupReply) String() string { return proto.CompactTextString(m) }
+func (*JoinGroupReply) ProtoMessage()    {}
+func (*JoinGroupReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_743bb58a714d8b7d, []int{17}
+}
+func (m *JoinGroupReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JoinGroupReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JoinGroupReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JoinGroupReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinGroupReply.Merge(m, src)
+}
+func (m *JoinGroupReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *JoinGroupReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinGroupReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinGroupReply proto.InternalMessageInfo
+
+func (m *JoinGroupReply) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type LeaveGroupRequest struct {
+	GID                  int64    `protobuf:"varint,1,opt,name=GID,proto3" json:"GID,omitempty"`
+	UIDs                 []int64  `protobuf:"varint,2,rep,packed,name=UIDs,proto3" json:"UIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaveGroupRequest) Reset()         { *m = LeaveGroupRequest{} }
+func (m *LeaveGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaveGroupRequest) ProtoMessage()    {}
+func (*LeaveGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_743bb58a714d8b7d, []int{18}
+}
+func (m *LeaveGroupRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeaveGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeaveGroupRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LeaveGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveGroupRequest.Merge(m, src)
+}
+func (m *LeaveGroupRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeaveGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaveGroupRequest proto.InternalMessageInfo
+
+func (m *LeaveGroupRequest) GetGID() int64 {
+	if m != nil {
+		return m.GID
+	}
+	return 0
+}
+
+func (m *LeaveGroupRequest) GetUIDs() []int64 {
+	if m != nil {
+		return m.UIDs
+	}
+	return nil
+}
+
+type LeaveGroupReply struct {
+	Total                int64    `protobuf:"varint,1,opt,name=Total,proto3" json:"Total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaveGroupReply) Reset()         { *m = LeaveGroupReply{} }
+func (m *LeaveGroupReply) String() string { return proto.CompactTextString(m) }
+func (*LeaveGroupReply) ProtoMessage()    {}
+func (*LeaveGroupReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_743bb58a714d8b7d, []int{19}
+}
+func (m *LeaveGroupReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeaveGroupReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeaveGroupReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LeaveGroupReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveGroupReply.Merge(m, src)
+}
+func (m *LeaveGroupReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeaveGroupReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveGroupReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaveGroupReply proto.InternalMessageInfo
+
+func (m *LeaveGroupReply) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*BindRequest)(nil), "pb.BindRequest")
 	proto.RegisterType((*BindReply)(nil), "pb.BindReply")
@@ -831,44 +1035,52 @@ func init() {
 	proto.RegisterType((*BroadcastReply)(nil), "pb.BroadcastReply")
 	proto.RegisterType((*StatRequest)(nil), "pb.StatRequest")
 	proto.RegisterType((*StatReply)(nil), "pb.StatReply")
+	proto.RegisterType((*JoinGroupRequest)(nil), "pb.JoinGroupRequest")
+	proto.RegisterType((*JoinGroupReply)(nil), "pb.JoinGroupReply")
+	proto.RegisterType((*LeaveGroupRequest)(nil), "pb.LeaveGroupRequest")
+	proto.RegisterType((*LeaveGroupReply)(nil), "pb.LeaveGroupReply")
 }
 
 func init() { proto.RegisterFile("gate.proto", fileDescriptor_743bb58a714d8b7d) }
 
 var fileDescriptor_743bb58a714d8b7d = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x4d, 0xab, 0xd3, 0x40,
-	0x14, 0x6d, 0x3e, 0xda, 0xda, 0x13, 0xfb, 0x35, 0x3e, 0x1f, 0x21, 0x48, 0x69, 0x07, 0x94, 0x80,
-	0x10, 0xf1, 0xb9, 0x10, 0xde, 0xb2, 0x16, 0x1f, 0x41, 0x0a, 0x25, 0xbe, 0x2e, 0x74, 0x65, 0xd2,
-	0x0e, 0xb5, 0x50, 0x93, 0xd8, 0x4c, 0x17, 0xef, 0x57, 0xb8, 0xf5, 0x27, 0xb9, 0xf4, 0x27, 0x48,
-	0xfd, 0x23, 0x32, 0x99, 0xa4, 0x9d, 0x56, 0x5e, 0x95, 0xee, 0x32, 0xe7, 0xde, 0x73, 0xcf, 0x9d,
-	0x7b, 0xcf, 0x04, 0x58, 0x84, 0x9c, 0x79, 0xe9, 0x3a, 0xe1, 0x09, 0xd1, 0xd3, 0xc8, 0x69, 0x7e,
-	0x61, 0x59, 0x16, 0x2e, 0x0a, 0x88, 0xbe, 0x84, 0x35, 0x5c, 0xc6, 0xf3, 0x80, 0x7d, 0xdd, 0xb0,
-	0x8c, 0x93, 0x0e, 0x8c, 0x37, 0xfe, 0xc8, 0xd6, 0xfa, 0x9a, 0x6b, 0x04, 0xe2, 0x53, 0x20, 0x53,
-	0x7f, 0x64, 0xeb, 0x12, 0x99, 0xfa, 0x23, 0x6a, 0xa1, 0x21, 0x29, 0xe9, 0xea, 0x8e, 0x0e, 0xd0,
-	0x9c, 0xc6, 0xd1, 0x61, 0x85, 0xe9, 0xbe, 0x82, 0xc8, 0x6f, 0xc2, 0x2a, 0x53, 0x04, 0xe3, 0x1a,
-	0x0f, 0x6f, 0x18, 0xf7, 0x27, 0x25, 0x81, 0xc0, 0x7c, 0xb7, 0x8c, 0xe7, 0x39, 0xa3, 0x1a, 0xe4,
-	0xdf, 0xe4, 0x12, 0xb5, 0xdb, 0x70, 0xbd, 0x60, 0xbc, 0xd0, 0x2d, 0x4e, 0xf4, 0x09, 0x50, 0x70,
-	0xd3, 0xd5, 0x1d, 0x69, 0x41, 0xf7, 0x27, 0x39, 0xaf, 0x11, 0xe8, 0xfe, 0x84, 0x7e, 0x40, 0x77,
-	0xb4, 0xcc, 0x66, 0x49, 0x1c, 0xb3, 0x19, 0x3f, 0xa3, 0x3c, 0xb1, 0x51, 0xf7, 0xb3, 0xb7, 0xc9,
-	0x7a, 0xc6, 0x6c, 0xa3, 0xaf, 0xb9, 0x0f, 0x82, 0xf2, 0x48, 0xbb, 0x68, 0xab, 0xa5, 0xc5, 0x3d,
-	0x3e, 0xc1, 0x9a, 0x6c, 0xb2, 0xcf, 0xe7, 0xe8, 0x3c, 0x45, 0x7d, 0x2c, 0xb7, 0x90, 0xeb, 0x58,
-	0x57, 0x96, 0x97, 0x46, 0x5e, 0x01, 0x05, 0x65, 0x4c, 0x0c, 0x5a, 0x2a, 0x08, 0xb9, 0x05, 0x3a,
-	0xe3, 0xcd, 0x8a, 0x2f, 0x67, 0x61, 0x76, 0xf2, 0x6e, 0x36, 0xea, 0x52, 0x25, 0xb3, 0xf5, 0xbe,
-	0xe1, 0x1a, 0x41, 0x79, 0xfc, 0x5f, 0xd5, 0x67, 0x68, 0x29, 0x42, 0x62, 0xce, 0x17, 0xa8, 0xde,
-	0x26, 0x3c, 0x5c, 0x15, 0x4b, 0x95, 0x07, 0x3a, 0x46, 0x67, 0xb8, 0x4e, 0xc2, 0xf9, 0xbf, 0x1a,
-	0x52, 0x64, 0xf5, 0xd3, 0xb2, 0x4a, 0xb9, 0xfb, 0x65, 0x07, 0xb0, 0xde, 0xf3, 0xf0, 0x94, 0x22,
-	0x1d, 0xa0, 0x21, 0x53, 0xee, 0xad, 0x72, 0xf5, 0xcd, 0x80, 0x79, 0x13, 0x72, 0x46, 0x5c, 0x98,
-	0xc2, 0xcc, 0xa4, 0x2d, 0x9a, 0x52, 0x5e, 0x82, 0xd3, 0xdc, 0x03, 0x62, 0xfc, 0x15, 0xe2, 0xa1,
-	0x26, 0x6d, 0x4c, 0xba, 0x22, 0x74, 0xe0, 0x7a, 0xa7, 0xad, 0x42, 0x32, 0xff, 0x39, 0xaa, 0xb9,
-	0x57, 0x49, 0x47, 0xc4, 0x54, 0xcb, 0x3b, 0x2d, 0x05, 0x91, 0xc9, 0x2e, 0x4c, 0xb1, 0x6a, 0xd9,
-	0x86, 0x62, 0x2b, 0xd9, 0xc6, 0xde, 0x05, 0x15, 0xf2, 0x1a, 0x8d, 0xdd, 0x7a, 0xc8, 0x45, 0x3e,
-	0xca, 0x23, 0x5b, 0x38, 0xe4, 0x08, 0xdd, 0x11, 0x77, 0x03, 0x96, 0xc4, 0xe3, 0xf5, 0x49, 0xe2,
-	0xe1, 0x16, 0x64, 0x6f, 0x62, 0x9c, 0xb2, 0x37, 0x65, 0xf6, 0xb2, 0xb7, 0xdd, 0xa4, 0x69, 0x85,
-	0x5c, 0x03, 0xfb, 0x57, 0x42, 0x1e, 0x8b, 0xf0, 0x5f, 0x0f, 0xd2, 0x79, 0x74, 0x0c, 0xe7, 0xdc,
-	0xe1, 0xe5, 0x8f, 0x6d, 0x4f, 0xfb, 0xb9, 0xed, 0x69, 0xbf, 0xb6, 0x3d, 0xed, 0xfb, 0xef, 0x5e,
-	0xe5, 0xa3, 0xe9, 0xbd, 0x48, 0xa3, 0xa8, 0x96, 0xff, 0xa7, 0x5e, 0xfd, 0x09, 0x00, 0x00, 0xff,
-	0xff, 0xb1, 0x53, 0x4d, 0x14, 0xc8, 0x04, 0x00, 0x00,
+	// 570 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xdd, 0x8a, 0xd3, 0x40,
+	0x18, 0xdd, 0xa4, 0x69, 0x6b, 0xbf, 0x98, 0xfe, 0xcc, 0xae, 0x4b, 0x18, 0xa4, 0xb4, 0x01, 0xb5,
+	0x20, 0x54, 0x5c, 0x2f, 0xd4, 0xbd, 0xac, 0xc1, 0x10, 0xb5, 0x50, 0xe2, 0xe6, 0x42, 0xaf, 0x4c,
+	0xda, 0xa1, 0x06, 0x6a, 0x12, 0x93, 0xa9, 0xb0, 0xf7, 0x3e, 0x84, 0x8f, 0xe4, 0xa5, 0x8f, 0x20,
+	0xf5, 0x45, 0x64, 0x32, 0xf9, 0x99, 0xcd, 0xd2, 0x2a, 0xbd, 0x9b, 0x39, 0xf3, 0x9d, 0xef, 0x7c,
+	0x99, 0x39, 0x27, 0x00, 0x6b, 0x8f, 0x92, 0x69, 0x9c, 0x44, 0x34, 0x42, 0x72, 0xec, 0x63, 0xed,
+	0x0b, 0x49, 0x53, 0x6f, 0x9d, 0x43, 0xc6, 0x53, 0x50, 0x67, 0x41, 0xb8, 0x72, 0xc8, 0xd7, 0x2d,
+	0x49, 0x29, 0xea, 0x43, 0xe3, 0x95, 0x6d, 0xea, 0xd2, 0x48, 0x9a, 0x34, 0x1c, 0xb6, 0x64, 0x88,
+	0x6b, 0x9b, 0xba, 0xcc, 0x11, 0xd7, 0x36, 0x0d, 0x15, 0x3a, 0x9c, 0x12, 0x6f, 0xae, 0x8d, 0x31,
+	0x68, 0x6e, 0xe8, 0xdf, 0xec, 0xe0, 0x56, 0x1d, 0x58, 0xbd, 0x06, 0x6a, 0x51, 0xc2, 0x18, 0x97,
+	0x70, 0xd7, 0x22, 0xd4, 0x5e, 0x14, 0x04, 0x04, 0xca, 0xdb, 0x20, 0x5c, 0x65, 0x8c, 0xa6, 0x93,
+	0xad, 0xd1, 0x39, 0xb4, 0xae, 0xbc, 0x64, 0x4d, 0x68, 0xae, 0x9b, 0xef, 0x8c, 0xfb, 0x00, 0x39,
+	0x37, 0xde, 0x5c, 0xa3, 0x2e, 0xc8, 0xf6, 0x22, 0xe3, 0x75, 0x1c, 0xd9, 0x5e, 0x18, 0x1f, 0x60,
+	0x60, 0x06, 0xe9, 0x32, 0x0a, 0x43, 0xb2, 0xa4, 0x47, 0xb4, 0x47, 0x3a, 0xb4, 0xed, 0xf4, 0x75,
+	0x94, 0x2c, 0x89, 0xde, 0x18, 0x49, 0x93, 0x3b, 0x4e, 0xb1, 0x35, 0x06, 0xd0, 0x13, 0x5b, 0xb3,
+	0xef, 0xf8, 0x04, 0xea, 0x62, 0x9b, 0x7e, 0x3e, 0x46, 0xe7, 0x01, 0xb4, 0xe7, 0xfc, 0x15, 0x32,
+	0x1d, 0xf5, 0x42, 0x9d, 0xc6, 0xfe, 0x34, 0x87, 0x9c, 0xe2, 0x8c, 0x5d, 0x34, 0x57, 0x60, 0x72,
+	0x6b, 0xe8, 0xcf, 0xb7, 0x1b, 0x1a, 0x2c, 0xbd, 0xf4, 0xe0, 0xb7, 0xe9, 0xd0, 0xe6, 0x2a, 0xa9,
+	0x2e, 0x8f, 0x1a, 0x93, 0x86, 0x53, 0x6c, 0xff, 0x57, 0xf5, 0x21, 0x74, 0x05, 0x21, 0x76, 0xcf,
+	0x67, 0xd0, 0xbc, 0x8a, 0xa8, 0xb7, 0xc9, 0x1f, 0x95, 0x6f, 0x8c, 0x39, 0xf4, 0x67, 0x49, 0xe4,
+	0xad, 0xfe, 0x35, 0x90, 0x20, 0x2b, 0x1f, 0x96, 0x15, 0xda, 0xed, 0x97, 0x1d, 0x83, 0xfa, 0x9e,
+	0x7a, 0x87, 0x14, 0x8d, 0x31, 0x74, 0x78, 0xc9, 0xfe, 0x2e, 0x2f, 0xa0, 0xff, 0x26, 0x0a, 0x42,
+	0x2b, 0x89, 0xb6, 0xb1, 0xe0, 0x5c, 0xab, 0x72, 0xae, 0x65, 0x9b, 0xac, 0xb9, 0x6b, 0x9b, 0xc5,
+	0x45, 0x66, 0x6b, 0x36, 0xa7, 0xc0, 0xdc, 0xaf, 0xf0, 0x12, 0x06, 0xef, 0x88, 0xf7, 0x8d, 0x1c,
+	0x21, 0xf1, 0x08, 0x7a, 0x22, 0x75, 0xaf, 0xc6, 0xc5, 0x77, 0x05, 0x14, 0xcb, 0xa3, 0x04, 0x4d,
+	0x40, 0x61, 0x91, 0x44, 0x3d, 0x76, 0xb5, 0x42, 0x9e, 0xb1, 0x56, 0x01, 0xcc, 0x44, 0x27, 0x68,
+	0x0a, 0x2d, 0x1e, 0x46, 0x34, 0x60, 0x47, 0x37, 0xb2, 0x8b, 0x7b, 0x22, 0xc4, 0xeb, 0x1f, 0x43,
+	0x33, 0x4b, 0x1c, 0xea, 0xb3, 0x33, 0x31, 0xb8, 0xb8, 0x2b, 0x20, 0xbc, 0x78, 0x02, 0x0a, 0x33,
+	0x2c, 0x1f, 0x43, 0x08, 0x07, 0xd6, 0x2a, 0x80, 0x57, 0x3e, 0x87, 0x4e, 0x69, 0x32, 0x74, 0x96,
+	0x19, 0xa2, 0x66, 0x6e, 0x8c, 0x6a, 0x68, 0x49, 0x2c, 0x6d, 0xc2, 0x89, 0x75, 0x13, 0x62, 0x54,
+	0x43, 0xcb, 0xd9, 0x98, 0x29, 0xf8, 0x6c, 0x82, 0x83, 0xb0, 0x56, 0x01, 0xbc, 0xf2, 0x12, 0xa0,
+	0xca, 0x3a, 0xba, 0xc7, 0x8e, 0x6f, 0xfd, 0x56, 0xf0, 0x69, 0x1d, 0x2e, 0xc7, 0x2b, 0xdd, 0xc1,
+	0xc7, 0xab, 0xdb, 0x0c, 0xa3, 0x1a, 0x5a, 0x8a, 0x56, 0x6f, 0xce, 0x45, 0x6f, 0xd9, 0x07, 0x9f,
+	0xd6, 0xe1, 0x8c, 0x3b, 0x3b, 0xff, 0xb9, 0x1b, 0x4a, 0xbf, 0x76, 0x43, 0xe9, 0xf7, 0x6e, 0x28,
+	0xfd, 0xf8, 0x33, 0x3c, 0xf9, 0xa8, 0x4c, 0x9f, 0xc4, 0xbe, 0xdf, 0xca, 0x7e, 0xf1, 0xcf, 0xfe,
+	0x0e, 0x00, 0xd5, 0xc3, 0xc7, 0xda, 0x03, 0x06, 0x00, 0x00,
 }
 
 func (m *BindRequest) Marshal() (dAtA []byte, err error) {
@@ -1450,65 +1662,231 @@ func (m *StatReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintGate(dAtA []byte, offset int, v uint64) int {
-	offset -= sovGate(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *JoinGroupRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *BindRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *JoinGroupRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JoinGroupRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CID != 0 {
-		n += 1 + sovGate(uint64(m.CID))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.UID != 0 {
-		n += 1 + sovGate(uint64(m.UID))
+	if len(m.UIDs) > 0 {
+		dAtA7 := make([]byte, len(m.UIDs)*10)
+		var j6 int
+		for _, num1 := range m.UIDs {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		i -= j6
+		copy(dAtA[i:], dAtA7[:j6])
+		i = encodeVarintGate(dAtA, i, uint64(j6))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.GID != 0 {
+		i = encodeVarintGate(dAtA, i, uint64(m.GID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BindReply) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *JoinGroupReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *JoinGroupReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JoinGroupReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.Total != 0 {
+		i = encodeVarintGate(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *UnbindRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LeaveGroupRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaveGroupRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaveGroupRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.UID != 0 {
-		n += 1 + sovGate(uint64(m.UID))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.UIDs) > 0 {
+		dAtA9 := make([]byte, len(m.UIDs)*10)
+		var j8 int
+		for _, num1 := range m.UIDs {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA9[j8] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j8++
+			}
+			dAtA9[j8] = uint8(num)
+			j8++
+		}
+		i -= j8
+		copy(dAtA[i:], dAtA9[:j8])
+		i = encodeVarintGate(dAtA, i, uint64(j8))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.GID != 0 {
+		i = encodeVarintGate(dAtA, i, uint64(m.GID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *UnbindReply) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LeaveGroupReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaveGroupReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaveGroupReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Total != 0 {
+		i = encodeVarintGate(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintGate(dAtA []byte, offset int, v uint64) int {
+	offset -= sovGate(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *BindRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CID != 0 {
+		n += 1 + sovGate(uint64(m.CID))
+	}
+	if m.UID != 0 {
+		n += 1 + sovGate(uint64(m.UID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BindReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UnbindRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.UID != 0 {
+		n += 1 + sovGate(uint64(m.UID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UnbindReply) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -1724,6 +2102,80 @@ func (m *StatReply) Size() (n int) {
 	return n
 }
 
+func (m *JoinGroupRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GID != 0 {
+		n += 1 + sovGate(uint64(m.GID))
+	}
+	if len(m.UIDs) > 0 {
+		l = 0
+		for _, e := range m.UIDs {
+			l += sovGate(uint64(e))
+		}
+		n += 1 + sovGate(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *JoinGroupReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovGate(uint64(m.Total))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LeaveGroupRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GID != 0 {
+		n += 1 + sovGate(uint64(m.GID))
+	}
+	if len(m.UIDs) > 0 {
+		l = 0
+		for _, e := range m.UIDs {
+			l += sovGate(uint64(e))
+		}
+		n += 1 + sovGate(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LeaveGroupReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovGate(uint64(m.Total))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovGate(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -3067,6 +3519,438 @@ func (m *StatReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *JoinGroupRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinGroupRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinGroupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
+			}
+			m.GID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.UIDs = append(m.UIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGate
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthGate
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.UIDs) == 0 {
+					m.UIDs = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.UIDs = append(m.UIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field UIDs", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JoinGroupReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinGroupReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinGroupReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaveGroupRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaveGroupRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaveGroupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GID", wireType)
+			}
+			m.GID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.UIDs = append(m.UIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGate
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthGate
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.UIDs) == 0 {
+					m.UIDs = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.UIDs = append(m.UIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field UIDs", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaveGroupReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaveGroupReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaveGroupReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipGate(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -34,10 +34,14 @@ type GateClient interface {
 	Multicast(ctx context.Context, in *MulticastRequest, opts ...grpc.CallOption) (*MulticastReply, error)
 	// 推送广播消息
 	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastReply, error)
-	// 推送广播消息
+	// 统计会话总数
 	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatReply, error)
 	// 断开连接
 	Disconnect(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*DisconnectReply, error)
+	// 将用户加入分组
+	JoinGroup(ctx context.Context, in *JoinGroupRequest, opts ...grpc.CallOption) (*JoinGroupReply, error)
+	// 将用户移出分组
+	LeaveGroup(ctx context.Context, in *LeaveGroupRequest, opts ...grpc.CallOption) (*LeaveGroupReply, error)
 }
 
 type gateClient struct {
@@ -120,6 +124,24 @@ func (c *gateClient) Disconnect(ctx context.Context, in *DisconnectRequest, opts
 	return out, nil
 }
 
+func (c *gateClient) JoinGroup(ctx context.Context, in *JoinGroupRequest, opts ...grpc.CallOption) (*JoinGroupReply, error) {
+	out := new(JoinGroupReply)
+	err := c.cc.Invoke(ctx, "/pb.Gate/JoinGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gateClient) LeaveGroup(ctx context.Context, in *LeaveGroupRequest, opts ...grpc.CallOption) (*LeaveGroupReply, error) {
+	out := new(LeaveGroupReply)
+	err := c.cc.Invoke(ctx, "/pb.Gate/LeaveGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GateServer is the server API for Gate service.
 // All implementations must embed UnimplementedGateServer
 // for forward compatibility
@@ -136,10 +158,14 @@ type GateServer interface {
 	Multicast(context.Context, *MulticastRequest) (*MulticastReply, error)
 	// 推送广播消息
 	Broadcast(context.Context, *BroadcastRequest) (*BroadcastReply, error)
-	// 推送广播消息
+	// 统计会话总数
 	Stat(context.Context, *StatRequest) (*StatReply, error)
 	// 断开连接
 	Disconnect(context.Context, *DisconnectRequest) (*DisconnectReply, error)
+	// 将用户加入分组
+	JoinGroup(context.Context, *JoinGroupRequest) (*JoinGroupReply, error)
+	// 将用户移出分组
+	LeaveGroup(context.Context, *LeaveGroupRequest) (*LeaveGroupReply, error)
 	mustEmbedUnimplementedGateServer()
 }
 
@@ -171,6 +197,12 @@ func (UnimplementedGateServer) Stat(context.Context, *StatRequest) (*StatReply,
 func (UnimplementedGateServer) Disconnect(context.Context, *DisconnectRequest) (*DisconnectReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Disconnect not implemented")
 }
+func (UnimplementedGateServer) JoinGroup(context.Context, *JoinGroupRequest) (*JoinGroupReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinGroup not implemented")
+}
+func (UnimplementedGateServer) LeaveGroup(context.Context, *LeaveGroupRequest) (*LeaveGroupReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveGroup not implemented")
+}
 func (UnimplementedGateServer) mustEmbedUnimplementedGateServer() {}
 
 // UnsafeGateServer may be embedded to opt out of forward compatibility for this service.
@@ -328,6 +360,42 @@ func _Gate_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Gate_JoinGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GateServer).JoinGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Gate/JoinGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GateServer).JoinGroup(ctx, req.(*JoinGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gate_LeaveGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GateServer).LeaveGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Gate/LeaveGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GateServer).LeaveGroup(ctx, req.(*LeaveGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Gate_ServiceDesc is the grpc.ServiceDesc for Gate service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -367,6 +435,14 @@ var Gate_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Disconnect",
 			Handler:    _Gate_Disconnect_Handler,
 		},
+		{
+			MethodName: "JoinGroup",
+			Handler:    _Gate_JoinGroup_Handler,
+		},
+		{
+			MethodName: "LeaveGroup",
+			Handler:    _Gate_LeaveGroup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "gate.proto",
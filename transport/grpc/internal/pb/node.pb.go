@@ -27,6 +27,7 @@ type TriggerRequest struct {
 	GID                  string   `protobuf:"bytes,2,opt,name=GID,proto3" json:"GID,omitempty"`
 	CID                  int64    `protobuf:"varint,3,opt,name=CID,proto3" json:"CID,omitempty"`
 	UID                  int64    `protobuf:"varint,4,opt,name=UID,proto3" json:"UID,omitempty"`
+	Devices              int32    `protobuf:"varint,5,opt,name=Devices,proto3" json:"Devices,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -93,6 +94,13 @@ func (m *TriggerRequest) GetUID() int64 {
 	return 0
 }
 
+func (m *TriggerRequest) GetDevices() int32 {
+	if m != nil {
+		return m.Devices
+	}
+	return 0
+}
+
 type TriggerReply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -260,24 +268,25 @@ func init() {
 func init() { proto.RegisterFile("node.proto", fileDescriptor_0c843d59d2d938e7) }
 
 var fileDescriptor_0c843d59d2d938e7 = []byte{
-	// 264 bytes of a gzipped FileDescriptorProto
+	// 274 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xca, 0xcb, 0x4f, 0x49,
 	0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2a, 0x48, 0x92, 0xe2, 0xcd, 0x4d, 0x2d, 0x2e,
-	0x4e, 0x4c, 0x87, 0x0a, 0x29, 0xc5, 0x70, 0xf1, 0x85, 0x14, 0x65, 0xa6, 0xa7, 0xa7, 0x16, 0x05,
+	0x4e, 0x4c, 0x87, 0x0a, 0x29, 0x95, 0x71, 0xf1, 0x85, 0x14, 0x65, 0xa6, 0xa7, 0xa7, 0x16, 0x05,
 	0xa5, 0x16, 0x96, 0xa6, 0x16, 0x97, 0x08, 0x89, 0x70, 0xb1, 0xba, 0x96, 0xa5, 0xe6, 0x95, 0x48,
 	0x30, 0x2a, 0x30, 0x6a, 0xb0, 0x06, 0x41, 0x38, 0x42, 0x02, 0x5c, 0xcc, 0xee, 0x9e, 0x2e, 0x12,
 	0x4c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x20, 0x26, 0x48, 0xc4, 0xd9, 0xd3, 0x45, 0x82, 0x59, 0x81,
-	0x51, 0x83, 0x39, 0x08, 0xc4, 0x04, 0x89, 0x84, 0x7a, 0xba, 0x48, 0xb0, 0x40, 0x44, 0x42, 0x3d,
-	0x5d, 0x94, 0xf8, 0xb8, 0x78, 0xe0, 0xa6, 0x17, 0xe4, 0x54, 0x2a, 0xd5, 0x73, 0xf1, 0xb9, 0xa4,
-	0xe6, 0x64, 0x96, 0x21, 0x6c, 0x83, 0x9a, 0xcb, 0x88, 0x62, 0xae, 0x1f, 0xc2, 0x26, 0x3f, 0xe2,
-	0x6c, 0x12, 0x52, 0xe5, 0x62, 0xf7, 0x85, 0x78, 0x4c, 0x82, 0x55, 0x81, 0x51, 0x83, 0xdb, 0x88,
-	0x5b, 0xaf, 0x20, 0x49, 0x0f, 0x2a, 0x14, 0x04, 0x93, 0x03, 0x39, 0x08, 0xee, 0x80, 0x82, 0x9c,
-	0x4a, 0xa3, 0x1c, 0x2e, 0x16, 0xbf, 0xfc, 0x94, 0x54, 0x21, 0x43, 0x2e, 0x76, 0xa8, 0x43, 0x85,
-	0x84, 0x40, 0x1a, 0x51, 0xc3, 0x44, 0x4a, 0x00, 0x45, 0x0c, 0xe4, 0x13, 0x06, 0x90, 0x16, 0xa8,
-	0x51, 0x10, 0x2d, 0xa8, 0x1e, 0x83, 0x68, 0x41, 0xb6, 0x4b, 0x89, 0xc1, 0x49, 0xec, 0xc4, 0x23,
-	0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f, 0x3c, 0x92, 0x63, 0x9c, 0xf1, 0x58, 0x8e, 0x21, 0x8a,
-	0x45, 0x4f, 0xbf, 0x20, 0x29, 0x89, 0x0d, 0x1c, 0x17, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x91, 0x80, 0xfc, 0x6b, 0xac, 0x01, 0x00, 0x00,
+	0x51, 0x83, 0x39, 0x88, 0xd9, 0x19, 0x22, 0x12, 0xea, 0xe9, 0x22, 0xc1, 0x02, 0x11, 0x09, 0xf5,
+	0x74, 0x11, 0x92, 0xe0, 0x62, 0x77, 0x49, 0x2d, 0xcb, 0x4c, 0x4e, 0x2d, 0x96, 0x60, 0x05, 0x9b,
+	0x06, 0xe3, 0x2a, 0xf1, 0x71, 0xf1, 0xc0, 0xed, 0x2d, 0xc8, 0xa9, 0x54, 0xaa, 0xe7, 0xe2, 0x73,
+	0x49, 0xcd, 0xc9, 0x2c, 0x43, 0xb8, 0x03, 0x6a, 0x23, 0x23, 0x8a, 0x8d, 0x7e, 0x08, 0x37, 0xf8,
+	0x11, 0xe9, 0x06, 0x55, 0x2e, 0x76, 0x5f, 0x88, 0x97, 0xc1, 0x6e, 0xe0, 0x36, 0xe2, 0xd6, 0x2b,
+	0x48, 0xd2, 0x83, 0x0a, 0x05, 0xc1, 0xe4, 0x40, 0x0e, 0x82, 0x3b, 0xa0, 0x20, 0xa7, 0xd2, 0x28,
+	0x87, 0x8b, 0xc5, 0x2f, 0x3f, 0x25, 0x55, 0xc8, 0x90, 0x8b, 0x1d, 0xea, 0x50, 0x21, 0x21, 0x90,
+	0x46, 0xd4, 0xd0, 0x92, 0x12, 0x40, 0x11, 0x03, 0xf9, 0x84, 0x01, 0xa4, 0x05, 0x6a, 0x14, 0x44,
+	0x0b, 0xaa, 0xc7, 0xa4, 0x04, 0x50, 0xc4, 0xc0, 0x5a, 0x9c, 0xc4, 0xa2, 0x58, 0xf4, 0xf4, 0x0b,
+	0x92, 0x4e, 0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39, 0xc6, 0x19, 0x8f,
+	0xe5, 0x18, 0x92, 0xd8, 0xc0, 0xb1, 0x64, 0x0c, 0x18, 0x00, 0x57, 0x3f, 0xf8, 0x87, 0xc6, 0x01,
+	0x00, 0x00,
 }
 
 func (m *TriggerRequest) Marshal() (dAtA []byte, err error) {
@@ -304,6 +313,11 @@ func (m *TriggerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Devices != 0 {
+		i = encodeVarintNode(dAtA, i, uint64(m.Devices))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.UID != 0 {
 		i = encodeVarintNode(dAtA, i, uint64(m.UID))
 		i--
@@ -476,6 +490,9 @@ func (m *TriggerRequest) Size() (n int) {
 	if m.UID != 0 {
 		n += 1 + sovNode(uint64(m.UID))
 	}
+	if m.Devices != 0 {
+		n += 1 + sovNode(uint64(m.Devices))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -660,6 +677,25 @@ func (m *TriggerRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			m.Devices = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNode
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Devices |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNode(dAtA[iNdEx:])
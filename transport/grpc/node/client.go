@@ -21,10 +21,11 @@ func NewClient(cc *grpc.ClientConn) *Client {
 // Trigger 触发事件
 func (c *Client) Trigger(ctx context.Context, args *transport.TriggerArgs) (miss bool, err error) {
 	_, err = c.client.Trigger(ctx, &pb.TriggerRequest{
-		Event: int32(args.Event),
-		GID:   args.GID,
-		CID:   args.CID,
-		UID:   args.UID,
+		Event:   int32(args.Event),
+		GID:     args.GID,
+		CID:     args.CID,
+		UID:     args.UID,
+		Devices: args.Devices,
 	})
 
 	miss = status.Code(err) == code.NotFoundSession
@@ -33,10 +33,11 @@ type endpoint struct {
 // Trigger 触发事件
 func (e *endpoint) Trigger(ctx context.Context, req *pb.TriggerRequest) (*pb.TriggerReply, error) {
 	miss, err := e.provider.Trigger(ctx, &transport.TriggerArgs{
-		GID:   req.GID,
-		CID:   req.CID,
-		UID:   req.UID,
-		Event: int(req.Event),
+		GID:     req.GID,
+		CID:     req.CID,
+		UID:     req.UID,
+		Event:   int(req.Event),
+		Devices: req.Devices,
 	})
 	if err != nil {
 		if miss {
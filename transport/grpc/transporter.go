@@ -13,22 +13,30 @@ import (
 )
 
 type Transporter struct {
-	opts    *options
-	once    sync.Once
-	builder *client.Builder
+	mu       sync.RWMutex
+	opts     *options
+	rawOpts  []Option
+	revision int64
+	once     sync.Once
+	builder  *client.Builder
 }
 
+var _ transport.Reloader = &Transporter{}
+
 func NewTransporter(opts ...Option) *Transporter {
 	o := defaultOptions()
 	for _, opt := range opts {
 		opt(o)
 	}
 
-	return &Transporter{opts: o}
+	return &Transporter{opts: o, rawOpts: opts}
 }
 
 // SetDefaultDiscovery 设置默认的服务发现组件
 func (t *Transporter) SetDefaultDiscovery(discovery registry.Discovery) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	if t.opts.client.Discovery == nil {
 		t.opts.client.Discovery = discovery
 	}
@@ -36,23 +44,63 @@ func (t *Transporter) SetDefaultDiscovery(discovery registry.Discovery) {
 
 // NewGateServer 新建网关服务器
 func (t *Transporter) NewGateServer(provider transport.GateProvider) (transport.Server, error) {
-	return gate.NewServer(provider, &t.opts.server)
+	return gate.NewServer(provider, t.serverOptions())
 }
 
 // NewNodeServer 新建节点服务器
 func (t *Transporter) NewNodeServer(provider transport.NodeProvider) (transport.Server, error) {
-	return node.NewServer(provider, &t.opts.server)
+	return node.NewServer(provider, t.serverOptions())
 }
 
 // NewServiceServer 新建微服务服务器
 func (t *Transporter) NewServiceServer() (transport.Server, error) {
-	return server.NewServer(&t.opts.server, pb.Gate_ServiceDesc.ServiceName, pb.Node_ServiceDesc.ServiceName)
+	return server.NewServer(t.serverOptions(), pb.Gate_ServiceDesc.ServiceName, pb.Node_ServiceDesc.ServiceName)
+}
+
+// Reload 重新加载配置，服务器监听地址或证书发生变更时递增配置版本号
+func (t *Transporter) Reload() int64 {
+	o := defaultOptions()
+	for _, opt := range t.rawOpts {
+		opt(o)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if o.server.Addr != t.opts.server.Addr || o.server.KeyFile != t.opts.server.KeyFile || o.server.CertFile != t.opts.server.CertFile {
+		t.opts.server.Addr = o.server.Addr
+		t.opts.server.KeyFile = o.server.KeyFile
+		t.opts.server.CertFile = o.server.CertFile
+		t.revision++
+	}
+
+	return t.revision
+}
+
+// Revision 获取配置版本号
+func (t *Transporter) Revision() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.revision
+}
+
+// 获取服务器配置副本，避免重新加载配置时影响运行中的服务器
+func (t *Transporter) serverOptions() *server.Options {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	opts := t.opts.server
+
+	return &opts
 }
 
 // NewGateClient 新建网关客户端
 func (t *Transporter) NewGateClient(ep *endpoint.Endpoint) (transport.GateClient, error) {
 	t.once.Do(func() {
+		t.mu.RLock()
 		t.builder = client.NewBuilder(&t.opts.client)
+		t.mu.RUnlock()
 	})
 
 	cc, err := t.builder.Build(ep.Target())
@@ -66,7 +114,9 @@ func (t *Transporter) NewGateClient(ep *endpoint.Endpoint) (transport.GateClient
 // NewNodeClient 新建节点客户端
 func (t *Transporter) NewNodeClient(ep *endpoint.Endpoint) (transport.NodeClient, error) {
 	t.once.Do(func() {
+		t.mu.RLock()
 		t.builder = client.NewBuilder(&t.opts.client)
+		t.mu.RUnlock()
 	})
 
 	cc, err := t.builder.Build(ep.Target())
@@ -80,7 +130,9 @@ func (t *Transporter) NewNodeClient(ep *endpoint.Endpoint) (transport.NodeClient
 // NewServiceClient 新建微服务客户端
 func (t *Transporter) NewServiceClient(target string) (transport.ServiceClient, error) {
 	t.once.Do(func() {
+		t.mu.RLock()
 		t.builder = client.NewBuilder(&t.opts.client)
+		t.mu.RUnlock()
 	})
 
 	cc, err := t.builder.Build(target)
@@ -0,0 +1,174 @@
+package local
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/packet"
+	"github.com/dobyte/due/v2/session"
+	"github.com/dobyte/due/v2/transport"
+)
+
+type gateClient struct {
+	address string
+}
+
+// Bind 绑定用户与连接
+func (c *gateClient) Bind(ctx context.Context, cid, uid int64) (miss bool, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return false, err
+	}
+
+	err = provider.Bind(ctx, cid, uid)
+	miss = errors.Is(err, errors.ErrNotFoundSession)
+
+	return
+}
+
+// Unbind 解绑用户与连接
+func (c *gateClient) Unbind(ctx context.Context, uid int64) (miss bool, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return false, err
+	}
+
+	err = provider.Unbind(ctx, uid)
+	miss = errors.Is(err, errors.ErrNotFoundSession)
+
+	return
+}
+
+// GetIP 获取客户端IP
+func (c *gateClient) GetIP(ctx context.Context, kind session.Kind, target int64) (ip string, miss bool, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return "", false, err
+	}
+
+	ip, err = provider.GetIP(ctx, kind, target)
+	miss = errors.Is(err, errors.ErrNotFoundSession)
+
+	return
+}
+
+// Push 推送消息
+func (c *gateClient) Push(ctx context.Context, kind session.Kind, target int64, message *packet.Message) (miss bool, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return false, err
+	}
+
+	err = provider.Push(ctx, kind, target, message)
+	miss = errors.Is(err, errors.ErrNotFoundSession)
+
+	return
+}
+
+// Multicast 推送组播消息
+func (c *gateClient) Multicast(ctx context.Context, kind session.Kind, targets []int64, message *packet.Message) (total int64, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return 0, err
+	}
+
+	return provider.Multicast(ctx, kind, targets, message)
+}
+
+// Broadcast 推送广播消息
+func (c *gateClient) Broadcast(ctx context.Context, kind session.Kind, message *packet.Message) (total int64, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return 0, err
+	}
+
+	return provider.Broadcast(ctx, kind, message)
+}
+
+// Stat 统计会话总数
+func (c *gateClient) Stat(ctx context.Context, kind session.Kind) (total int64, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return 0, err
+	}
+
+	return provider.Stat(ctx, kind)
+}
+
+// Disconnect 断开连接
+func (c *gateClient) Disconnect(ctx context.Context, kind session.Kind, target int64, isForce bool) (miss bool, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return false, err
+	}
+
+	err = provider.Disconnect(ctx, kind, target, isForce)
+	miss = errors.Is(err, errors.ErrNotFoundSession)
+
+	return
+}
+
+// JoinGroup 将用户加入分组
+func (c *gateClient) JoinGroup(ctx context.Context, gid int64, uids []int64) (total int64, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return 0, err
+	}
+
+	return provider.JoinGroup(ctx, gid, uids)
+}
+
+// LeaveGroup 将用户移出分组
+func (c *gateClient) LeaveGroup(ctx context.Context, gid int64, uids []int64) (total int64, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return 0, err
+	}
+
+	return provider.LeaveGroup(ctx, gid, uids)
+}
+
+// 获取网关提供者，网关服务器停止后将无法获取
+func (c *gateClient) provider() (transport.GateProvider, error) {
+	if v, ok := loadProvider(c.address); ok {
+		if provider, ok := v.(transport.GateProvider); ok {
+			return provider, nil
+		}
+	}
+
+	return nil, errors.ErrNotFoundEndpoint
+}
+
+type nodeClient struct {
+	address string
+}
+
+// Trigger 触发事件
+func (c *nodeClient) Trigger(ctx context.Context, args *transport.TriggerArgs) (miss bool, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return false, err
+	}
+
+	return provider.Trigger(ctx, args)
+}
+
+// Deliver 投递消息
+func (c *nodeClient) Deliver(ctx context.Context, args *transport.DeliverArgs) (miss bool, err error) {
+	provider, err := c.provider()
+	if err != nil {
+		return false, err
+	}
+
+	return provider.Deliver(ctx, args)
+}
+
+// 获取节点提供者，节点服务器停止后将无法获取
+func (c *nodeClient) provider() (transport.NodeProvider, error) {
+	if v, ok := loadProvider(c.address); ok {
+		if provider, ok := v.(transport.NodeProvider); ok {
+			return provider, nil
+		}
+	}
+
+	return nil, errors.ErrNotFoundEndpoint
+}
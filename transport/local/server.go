@@ -0,0 +1,64 @@
+package local
+
+import (
+	"github.com/dobyte/due/v2/core/endpoint"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/utils/xuuid"
+	"sync"
+)
+
+const scheme = "local"
+
+// 当前进程内已启动的服务器提供者
+var providers sync.Map
+
+type server struct {
+	provider interface{}
+	endpoint *endpoint.Endpoint
+}
+
+func newServer(provider interface{}) *server {
+	return &server{
+		provider: provider,
+		endpoint: endpoint.NewEndpoint(scheme, xuuid.UUID(), false),
+	}
+}
+
+// Start 启动服务器
+func (s *server) Start() error {
+	providers.Store(s.endpoint.Address(), s.provider)
+
+	return nil
+}
+
+// Stop 停止服务器
+func (s *server) Stop() error {
+	providers.Delete(s.endpoint.Address())
+
+	return nil
+}
+
+// Addr 监听地址
+func (s *server) Addr() string {
+	return s.endpoint.Address()
+}
+
+// Scheme 协议
+func (s *server) Scheme() string {
+	return scheme
+}
+
+// Endpoint 服务端口
+func (s *server) Endpoint() *endpoint.Endpoint {
+	return s.endpoint
+}
+
+// RegisterService 注册服务
+func (s *server) RegisterService(_, _ interface{}) error {
+	return errors.ErrNotSupportService
+}
+
+// 获取服务器提供者
+func loadProvider(address string) (interface{}, bool) {
+	return providers.Load(address)
+}
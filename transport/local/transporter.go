@@ -0,0 +1,59 @@
+package local
+
+import (
+	"github.com/dobyte/due/v2/core/endpoint"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/registry"
+	"github.com/dobyte/due/v2/transport"
+)
+
+// Transporter 进程内传输器
+// 网关与节点部署于同一进程时，网关与节点间的调用将直接调用对端的提供者，无需经过序列化与网络传输
+// 进程内传输器暴露的服务端口仅在当前进程内有效，跨进程部署时需替换为grpc或rpcx等远程传输器
+// 进程内传输器不支持微服务
+type Transporter struct{}
+
+func NewTransporter() *Transporter {
+	return &Transporter{}
+}
+
+// SetDefaultDiscovery 设置默认的服务发现组件
+func (t *Transporter) SetDefaultDiscovery(_ registry.Discovery) {}
+
+// NewGateServer 新建网关服务器
+func (t *Transporter) NewGateServer(provider transport.GateProvider) (transport.Server, error) {
+	return newServer(provider), nil
+}
+
+// NewNodeServer 新建节点服务器
+func (t *Transporter) NewNodeServer(provider transport.NodeProvider) (transport.Server, error) {
+	return newServer(provider), nil
+}
+
+// NewServiceServer 新建微服务服务器
+func (t *Transporter) NewServiceServer() (transport.Server, error) {
+	return nil, errors.ErrNotSupportService
+}
+
+// NewGateClient 新建网关客户端
+func (t *Transporter) NewGateClient(ep *endpoint.Endpoint) (transport.GateClient, error) {
+	if ep.Scheme() != scheme {
+		return nil, errors.ErrNotFoundEndpoint
+	}
+
+	return &gateClient{address: ep.Address()}, nil
+}
+
+// NewNodeClient 新建节点客户端
+func (t *Transporter) NewNodeClient(ep *endpoint.Endpoint) (transport.NodeClient, error) {
+	if ep.Scheme() != scheme {
+		return nil, errors.ErrNotFoundEndpoint
+	}
+
+	return &nodeClient{address: ep.Address()}, nil
+}
+
+// NewServiceClient 新建微服务客户端
+func (t *Transporter) NewServiceClient(_ string) (transport.ServiceClient, error) {
+	return nil, errors.ErrNotSupportService
+}
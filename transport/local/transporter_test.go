@@ -0,0 +1,59 @@
+package local_test
+
+import (
+	"context"
+	"github.com/dobyte/due/v2/errors"
+	"github.com/dobyte/due/v2/transport"
+	"github.com/dobyte/due/v2/transport/local"
+	"testing"
+)
+
+type nodeProvider struct {
+	uid int64
+}
+
+func (p *nodeProvider) Trigger(_ context.Context, _ *transport.TriggerArgs) (bool, error) {
+	return false, nil
+}
+
+func (p *nodeProvider) Deliver(_ context.Context, args *transport.DeliverArgs) (bool, error) {
+	if args.UID != p.uid {
+		return true, errors.ErrNotFoundSession
+	}
+
+	return false, nil
+}
+
+func TestTransporter_NodeClient(t *testing.T) {
+	transporter := local.NewTransporter()
+
+	server, err := transporter.NewNodeServer(&nodeProvider{uid: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = server.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := transporter.NewNodeClient(server.Endpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if miss, err := client.Deliver(context.Background(), &transport.DeliverArgs{UID: 1}); err != nil || miss {
+		t.Fatalf("deliver failed, miss: %v err: %v", miss, err)
+	}
+
+	if miss, _ := client.Deliver(context.Background(), &transport.DeliverArgs{UID: 2}); !miss {
+		t.Fatal("deliver to unknown user should be missed")
+	}
+
+	if err = server.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.Deliver(context.Background(), &transport.DeliverArgs{UID: 1}); err != errors.ErrNotFoundEndpoint {
+		t.Fatalf("deliver after server stopped should fail, err: %v", err)
+	}
+}
@@ -89,3 +89,21 @@ func (c *Client) Disconnect(ctx context.Context, kind session.Kind, target int64
 	miss = reply.Code == code.NotFoundSession
 	return
 }
+
+// JoinGroup 将用户加入分组
+func (c *Client) JoinGroup(ctx context.Context, gid int64, uids []int64) (total int64, err error) {
+	req := &protocol.JoinGroupRequest{GID: gid, UIDs: uids}
+	reply := &protocol.JoinGroupReply{}
+	err = c.cli.Call(ctx, ServicePath, serviceMethodJoinGroup, req, reply)
+	total = reply.Total
+	return
+}
+
+// LeaveGroup 将用户移出分组
+func (c *Client) LeaveGroup(ctx context.Context, gid int64, uids []int64) (total int64, err error) {
+	req := &protocol.LeaveGroupRequest{GID: gid, UIDs: uids}
+	reply := &protocol.LeaveGroupReply{}
+	err = c.cli.Call(ctx, ServicePath, serviceMethodLeaveGroup, req, reply)
+	total = reply.Total
+	return
+}
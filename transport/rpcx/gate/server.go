@@ -20,6 +20,8 @@ const (
 	serviceMethodBroadcast  = "Broadcast"
 	serviceMethodStat       = "Stat"
 	serviceMethodDisconnect = "Disconnect"
+	serviceMethodJoinGroup  = "JoinGroup"
+	serviceMethodLeaveGroup = "LeaveGroup"
 )
 
 func NewServer(provider transport.GateProvider, opts *server.Options) (*server.Server, error) {
@@ -200,3 +202,27 @@ func (e *endpoint) Disconnect(ctx context.Context, req *protocol.DisconnectReque
 
 	return err
 }
+
+// JoinGroup 将用户加入分组
+func (e *endpoint) JoinGroup(ctx context.Context, req *protocol.JoinGroupRequest, reply *protocol.JoinGroupReply) error {
+	total, err := e.provider.JoinGroup(ctx, req.GID, req.UIDs)
+	if err != nil {
+		reply.Code = code.Internal
+	}
+
+	reply.Total = total
+
+	return err
+}
+
+// LeaveGroup 将用户移出分组
+func (e *endpoint) LeaveGroup(ctx context.Context, req *protocol.LeaveGroupRequest, reply *protocol.LeaveGroupReply) error {
+	total, err := e.provider.LeaveGroup(ctx, req.GID, req.UIDs)
+	if err != nil {
+		reply.Code = code.Internal
+	}
+
+	reply.Total = total
+
+	return err
+}
@@ -91,3 +91,23 @@ type DisconnectRequest struct {
 type DisconnectReply struct {
 	Code int
 }
+
+type JoinGroupRequest struct {
+	GID  int64
+	UIDs []int64
+}
+
+type JoinGroupReply struct {
+	Code  int
+	Total int64
+}
+
+type LeaveGroupRequest struct {
+	GID  int64
+	UIDs []int64
+}
+
+type LeaveGroupReply struct {
+	Code  int
+	Total int64
+}
@@ -5,10 +5,11 @@ import (
 )
 
 type TriggerRequest struct {
-	Event int
-	GID   string
-	CID   int64
-	UID   int64
+	Event   int
+	GID     string
+	CID     int64
+	UID     int64
+	Devices int32
 }
 
 type TriggerReply struct {
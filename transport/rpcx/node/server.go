@@ -35,10 +35,11 @@ type endpoint struct {
 // Trigger 触发事件
 func (e *endpoint) Trigger(ctx context.Context, req *protocol.TriggerRequest, reply *protocol.TriggerReply) error {
 	miss, err := e.provider.Trigger(ctx, &transport.TriggerArgs{
-		Event: req.Event,
-		GID:   req.GID,
-		CID:   req.CID,
-		UID:   req.UID,
+		Event:   req.Event,
+		GID:     req.GID,
+		CID:     req.CID,
+		UID:     req.UID,
+		Devices: req.Devices,
 	})
 	if err != nil {
 		if miss {
@@ -41,6 +41,10 @@ type GateProvider interface {
 	Stat(ctx context.Context, kind session.Kind) (total int64, err error)
 	// Disconnect 断开连接
 	Disconnect(ctx context.Context, kind session.Kind, target int64, isForce bool) error
+	// JoinGroup 将用户加入分组
+	JoinGroup(ctx context.Context, gid int64, uids []int64) (total int64, err error)
+	// LeaveGroup 将用户移出分组
+	LeaveGroup(ctx context.Context, gid int64, uids []int64) (total int64, err error)
 }
 
 type NodeProvider interface {
@@ -59,8 +63,9 @@ type DeliverArgs struct {
 }
 
 type TriggerArgs struct {
-	Event int
-	GID   string
-	CID   int64
-	UID   int64
+	Event   int
+	GID     string
+	CID     int64
+	UID     int64
+	Devices int32 // 用户在网关上剩余的连接数，仅多端登录模式下的断开连接事件有效
 }
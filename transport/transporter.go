@@ -21,3 +21,10 @@ type Transporter interface {
 	// NewServiceClient 新建微服务客户端
 	NewServiceClient(target string) (ServiceClient, error)
 }
+
+type Reloader interface {
+	// Reload 重新加载配置，返回配置版本号，服务器配置发生变更时版本号递增
+	Reload() int64
+	// Revision 获取当前配置版本号
+	Revision() int64
+}
@@ -41,3 +41,8 @@ func FulfillAddr(addr string) string {
 func AssignRandPort(ip ...string) (int, error) {
 	return innernet.AssignRandPort(ip...)
 }
+
+// CheckListenAddr 检测TCP监听地址是否可用
+func CheckListenAddr(addr string) error {
+	return innernet.CheckListenAddr(addr)
+}